@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nitrictech/nitric/cloud/aws/ifaces/apigatewaymanagementapiiface (interfaces: ApiGatewayManagementAPI)
+
+// Package mock_apigatewaymanagementapiiface is a generated GoMock package.
+package mock_apigatewaymanagementapiiface
+
+import (
+	context "context"
+	reflect "reflect"
+
+	apigatewaymanagementapi "github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockApiGatewayManagementAPI is a mock of ApiGatewayManagementAPI interface.
+type MockApiGatewayManagementAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiGatewayManagementAPIMockRecorder
+}
+
+// MockApiGatewayManagementAPIMockRecorder is the mock recorder for MockApiGatewayManagementAPI.
+type MockApiGatewayManagementAPIMockRecorder struct {
+	mock *MockApiGatewayManagementAPI
+}
+
+// NewMockApiGatewayManagementAPI creates a new mock instance.
+func NewMockApiGatewayManagementAPI(ctrl *gomock.Controller) *MockApiGatewayManagementAPI {
+	mock := &MockApiGatewayManagementAPI{ctrl: ctrl}
+	mock.recorder = &MockApiGatewayManagementAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiGatewayManagementAPI) EXPECT() *MockApiGatewayManagementAPIMockRecorder {
+	return m.recorder
+}
+
+// DeleteConnection mocks base method.
+func (m *MockApiGatewayManagementAPI) DeleteConnection(arg0 context.Context, arg1 *apigatewaymanagementapi.DeleteConnectionInput, arg2 ...func(*apigatewaymanagementapi.Options)) (*apigatewaymanagementapi.DeleteConnectionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteConnection", varargs...)
+	ret0, _ := ret[0].(*apigatewaymanagementapi.DeleteConnectionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteConnection indicates an expected call of DeleteConnection.
+func (mr *MockApiGatewayManagementAPIMockRecorder) DeleteConnection(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConnection", reflect.TypeOf((*MockApiGatewayManagementAPI)(nil).DeleteConnection), varargs...)
+}
+
+// PostToConnection mocks base method.
+func (m *MockApiGatewayManagementAPI) PostToConnection(arg0 context.Context, arg1 *apigatewaymanagementapi.PostToConnectionInput, arg2 ...func(*apigatewaymanagementapi.Options)) (*apigatewaymanagementapi.PostToConnectionOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PostToConnection", varargs...)
+	ret0, _ := ret[0].(*apigatewaymanagementapi.PostToConnectionOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostToConnection indicates an expected call of PostToConnection.
+func (mr *MockApiGatewayManagementAPIMockRecorder) PostToConnection(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostToConnection", reflect.TypeOf((*MockApiGatewayManagementAPI)(nil).PostToConnection), varargs...)
+}
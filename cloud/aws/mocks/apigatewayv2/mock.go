@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/nitrictech/nitric/cloud/aws/ifaces/apigatewayv2iface (interfaces: ApiGatewayV2API)
+
+// Package mock_apigatewayv2iface is a generated GoMock package.
+package mock_apigatewayv2iface
+
+import (
+	context "context"
+	reflect "reflect"
+
+	apigatewayv2 "github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockApiGatewayV2API is a mock of ApiGatewayV2API interface.
+type MockApiGatewayV2API struct {
+	ctrl     *gomock.Controller
+	recorder *MockApiGatewayV2APIMockRecorder
+}
+
+// MockApiGatewayV2APIMockRecorder is the mock recorder for MockApiGatewayV2API.
+type MockApiGatewayV2APIMockRecorder struct {
+	mock *MockApiGatewayV2API
+}
+
+// NewMockApiGatewayV2API creates a new mock instance.
+func NewMockApiGatewayV2API(ctrl *gomock.Controller) *MockApiGatewayV2API {
+	mock := &MockApiGatewayV2API{ctrl: ctrl}
+	mock.recorder = &MockApiGatewayV2APIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApiGatewayV2API) EXPECT() *MockApiGatewayV2APIMockRecorder {
+	return m.recorder
+}
+
+// GetApi mocks base method.
+func (m *MockApiGatewayV2API) GetApi(arg0 context.Context, arg1 *apigatewayv2.GetApiInput, arg2 ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetApi", varargs...)
+	ret0, _ := ret[0].(*apigatewayv2.GetApiOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApi indicates an expected call of GetApi.
+func (mr *MockApiGatewayV2APIMockRecorder) GetApi(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApi", reflect.TypeOf((*MockApiGatewayV2API)(nil).GetApi), varargs...)
+}
+
+// GetApiMappings mocks base method.
+func (m *MockApiGatewayV2API) GetApiMappings(arg0 context.Context, arg1 *apigatewayv2.GetApiMappingsInput, arg2 ...func(*apigatewayv2.Options)) (*apigatewayv2.GetApiMappingsOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetApiMappings", varargs...)
+	ret0, _ := ret[0].(*apigatewayv2.GetApiMappingsOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiMappings indicates an expected call of GetApiMappings.
+func (mr *MockApiGatewayV2APIMockRecorder) GetApiMappings(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiMappings", reflect.TypeOf((*MockApiGatewayV2API)(nil).GetApiMappings), varargs...)
+}
+
+// GetDomainNames mocks base method.
+func (m *MockApiGatewayV2API) GetDomainNames(arg0 context.Context, arg1 *apigatewayv2.GetDomainNamesInput, arg2 ...func(*apigatewayv2.Options)) (*apigatewayv2.GetDomainNamesOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetDomainNames", varargs...)
+	ret0, _ := ret[0].(*apigatewayv2.GetDomainNamesOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDomainNames indicates an expected call of GetDomainNames.
+func (mr *MockApiGatewayV2APIMockRecorder) GetDomainNames(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDomainNames", reflect.TypeOf((*MockApiGatewayV2API)(nil).GetDomainNames), varargs...)
+}
@@ -27,8 +27,10 @@ import (
 	sqs_service "github.com/nitrictech/nitric/cloud/aws/runtime/queue"
 	secrets_manager_secret_service "github.com/nitrictech/nitric/cloud/aws/runtime/secret"
 	s3_service "github.com/nitrictech/nitric/cloud/aws/runtime/storage"
+	apigateway_websocket_service "github.com/nitrictech/nitric/cloud/aws/runtime/websocket"
 	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
 	"github.com/nitrictech/nitric/core/pkg/membrane"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -51,8 +53,15 @@ func main() {
 	switch gatewayEnv {
 	case "lambda":
 		membraneOpts.GatewayPlugin, _ = lambda_service.New(provider)
+		membraneOpts.WebsocketPlugin, _ = apigateway_websocket_service.New(provider)
+		membraneOpts.ResourcesPlugin = provider
 	default:
 		membraneOpts.GatewayPlugin, _ = base_http.New(nil)
+		membraneOpts.ResourcesPlugin = provider
+		// APIs are served by the gateway itself, which reports their details in place of the provider
+		if apis, ok := membraneOpts.GatewayPlugin.(common.ResourceService); ok {
+			membraneOpts.ResourcesPlugin = common.WithApiDetails(provider, apis)
+		}
 	}
 
 	membraneOpts.SecretPlugin, _ = secrets_manager_secret_service.New(provider)
@@ -60,8 +69,8 @@ func main() {
 	membraneOpts.EventsPlugin, _ = sns_service.New(provider)
 	membraneOpts.QueuePlugin, _ = sqs_service.New(provider)
 	membraneOpts.StoragePlugin, _ = s3_service.New(provider)
-	membraneOpts.ResourcesPlugin = provider
 	membraneOpts.CreateTracerProvider = newTracerProvider
+	membraneOpts.CreateMeterProvider = newMeterProvider
 
 	m, err := membrane.New(membraneOpts)
 	if err != nil {
@@ -22,13 +22,16 @@ import (
 	lambdadetector "go.opentelemetry.io/contrib/detectors/aws/lambda"
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 	"github.com/nitrictech/nitric/core/pkg/span"
+	"github.com/nitrictech/nitric/core/pkg/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -36,21 +39,18 @@ func newTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
 	span.FunctionName = lambdacontext.FunctionName
 	span.UseFuncNameAsSpanName = true
 
-	exp, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
+	// Export to the local collector unless an OTLP endpoint has been configured
+	opts := []otlptracegrpc.Option{}
+	if !telemetry.EndpointConfigured(telemetry.Signal_Traces) {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exp, err := otlptracegrpc.New(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithDetectors(lambdadetector.NewResourceDetector()),
-		resource.WithTelemetrySDK(),
-		resource.WithAttributes(
-			semconv.CloudProviderAWS,
-			semconv.CloudPlatformAWSLambda,
-			semconv.ServiceNameKey.String(span.FunctionName),
-			semconv.ServiceNamespaceKey.String(utils.GetEnv("NITRIC_STACK", "")),
-		),
-	)
+	res, err := newResource(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -73,3 +73,38 @@ func newTracerProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
 		sdktrace.WithResource(res),
 	), nil
 }
+
+func newResource(ctx context.Context) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithDetectors(lambdadetector.NewResourceDetector()),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.CloudProviderAWS,
+			semconv.CloudPlatformAWSLambda,
+			semconv.ServiceNameKey.String(lambdacontext.FunctionName),
+			semconv.ServiceNamespaceKey.String(utils.GetEnv("NITRIC_STACK", "")),
+		),
+	)
+}
+
+func newMeterProvider(ctx context.Context) (*sdkmetric.MeterProvider, error) {
+	opts := []otlpmetricgrpc.Option{}
+	if !telemetry.EndpointConfigured(telemetry.Signal_Metrics) {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	exp, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := newResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+		sdkmetric.WithResource(res),
+	), nil
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
@@ -45,7 +47,12 @@ const (
 )
 
 var resourceTypeMap = map[common.ResourceType]AwsResource{
-	common.ResourceType_Api: AwsResource_Api,
+	common.ResourceType_Api:        AwsResource_Api,
+	common.ResourceType_Bucket:     AwsResource_Bucket,
+	common.ResourceType_Topic:      AwsResource_Topic,
+	common.ResourceType_Queue:      AwsResource_Queue,
+	common.ResourceType_Collection: AwsResource_Collection,
+	common.ResourceType_Secret:     AwsResource_Secret,
 }
 
 type AwsProvider interface {
@@ -58,9 +65,13 @@ type AwsProvider interface {
 // Aws core utility provider
 type awsProviderImpl struct {
 	stack     string
+	region    string
 	client    resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 	apiClient apigatewayv2iface.ApiGatewayV2API
 	cache     map[AwsResource]map[string]string
+	// The url of the first custom domain mapped to each api, keyed by api id
+	domainUrls     map[string]string
+	domainUrlsLock sync.Mutex
 }
 
 var _ AwsProvider = &awsProviderImpl{}
@@ -77,20 +88,25 @@ func (a *awsProviderImpl) Details(ctx context.Context, typ common.ResourceType,
 		return nil, err
 	}
 
-	arn, ok := resources[name]
+	resourceArn, ok := resources[name]
 	if !ok {
 		return nil, fmt.Errorf("unable to find resource %s for name: %s", typ, name)
 	}
 
 	details := &common.DetailsResponse[any]{
-		Id:       arn,
+		Id:       resourceArn,
 		Provider: "aws",
 	}
 
+	parsedArn, err := arn.Parse(resourceArn)
+	if err != nil {
+		return nil, err
+	}
+
 	switch rt {
 	case AwsResource_Api:
 		// split arn to find the apiId
-		arnParts := strings.Split(arn, "/")
+		arnParts := strings.Split(resourceArn, "/")
 		apiId := arnParts[len(arnParts)-1]
 		// Get api detail
 		api, err := a.apiClient.GetApi(context.TODO(), &apigatewayv2.GetApiInput{
@@ -100,9 +116,68 @@ func (a *awsProviderImpl) Details(ctx context.Context, typ common.ResourceType,
 			return nil, err
 		}
 
+		url := *api.ApiEndpoint
+
+		// Custom domains take precedence over the default endpoint
+		customUrl, err := a.customDomainUrl(ctx, apiId)
+		if err != nil {
+			return nil, err
+		}
+
+		if customUrl != "" {
+			url = customUrl
+		}
+
 		details.Service = "ApiGateway"
 		details.Detail = common.ApiDetails{
-			URL: *api.ApiEndpoint,
+			URL: url,
+		}
+
+		return details, nil
+	case AwsResource_Bucket:
+		// bucket arns are of the form arn:aws:s3:::{bucket}
+		details.Service = "S3"
+		details.Detail = common.BucketDetails{
+			Name: parsedArn.Resource,
+			URL:  fmt.Sprintf("https://%s.s3.%s.amazonaws.com", parsedArn.Resource, a.region),
+		}
+
+		return details, nil
+	case AwsResource_Topic:
+		// topic arns are of the form arn:aws:sns:{region}:{account}:{topic}
+		details.Service = "SNS"
+		details.Detail = common.TopicDetails{
+			Name: parsedArn.Resource,
+		}
+
+		return details, nil
+	case AwsResource_Queue:
+		// queue arns are of the form arn:aws:sqs:{region}:{account}:{queue}
+		details.Service = "SQS"
+		details.Detail = common.QueueDetails{
+			Name: parsedArn.Resource,
+			URL:  fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", parsedArn.Region, parsedArn.AccountID, parsedArn.Resource),
+		}
+
+		return details, nil
+	case AwsResource_Collection:
+		// table arns are of the form arn:aws:dynamodb:{region}:{account}:table/{table}
+		details.Service = "DynamoDB"
+		details.Detail = common.CollectionDetails{
+			Name: strings.TrimPrefix(parsedArn.Resource, "table/"),
+		}
+
+		return details, nil
+	case AwsResource_Secret:
+		// secret arns are of the form arn:aws:secretsmanager:{region}:{account}:secret:{secret}-{6 random characters}
+		secretName := strings.TrimPrefix(parsedArn.Resource, "secret:")
+		if idx := strings.LastIndex(secretName, "-"); idx > 0 {
+			secretName = secretName[:idx]
+		}
+
+		details.Service = "SecretsManager"
+		details.Detail = common.SecretDetails{
+			Name: secretName,
 		}
 
 		return details, nil
@@ -111,6 +186,78 @@ func (a *awsProviderImpl) Details(ctx context.Context, typ common.ResourceType,
 	}
 }
 
+// customDomainUrl - returns the url of the first custom domain mapped to the api, or an empty string if it has none.
+// The domains are listed again for apis without a cached url, so domains mapped after the first lookup are found.
+func (a *awsProviderImpl) customDomainUrl(ctx context.Context, apiId string) (string, error) {
+	a.domainUrlsLock.Lock()
+	defer a.domainUrlsLock.Unlock()
+
+	if url, ok := a.domainUrls[apiId]; ok {
+		return url, nil
+	}
+
+	domainUrls, err := a.getDomainUrls(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(domainUrls) > 0 {
+		a.domainUrls = domainUrls
+	}
+
+	return domainUrls[apiId], nil
+}
+
+// getDomainUrls - returns the url of the first custom domain mapped to each api, keyed by api id
+func (a *awsProviderImpl) getDomainUrls(ctx context.Context) (map[string]string, error) {
+	domainUrls := map[string]string{}
+	domainsInput := &apigatewayv2.GetDomainNamesInput{}
+
+	for {
+		domains, err := a.apiClient.GetDomainNames(ctx, domainsInput)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, domain := range domains.Items {
+			mappingsInput := &apigatewayv2.GetApiMappingsInput{
+				DomainName: domain.DomainName,
+			}
+
+			for {
+				mappings, err := a.apiClient.GetApiMappings(ctx, mappingsInput)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, mapping := range mappings.Items {
+					apiId := aws.ToString(mapping.ApiId)
+					if _, ok := domainUrls[apiId]; ok {
+						continue
+					}
+
+					url := "https://" + aws.ToString(domain.DomainName)
+					if key := aws.ToString(mapping.ApiMappingKey); key != "" {
+						url = url + "/" + key
+					}
+
+					domainUrls[apiId] = url
+				}
+
+				if mappings.NextToken == nil {
+					break
+				}
+				mappingsInput.NextToken = mappings.NextToken
+			}
+		}
+
+		if domains.NextToken == nil {
+			return domainUrls, nil
+		}
+		domainsInput.NextToken = domains.NextToken
+	}
+}
+
 func (a *awsProviderImpl) GetResources(ctx context.Context, typ AwsResource) (map[string]string, error) {
 	if a.cache[typ] == nil {
 		resources := make(map[string]string)
@@ -164,6 +311,7 @@ func New() (AwsProvider, error) {
 
 	return &awsProviderImpl{
 		stack:     stack,
+		region:    awsRegion,
 		client:    client,
 		apiClient: apiClient,
 		cache:     make(map[AwsResource]map[string]string),
@@ -19,13 +19,17 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
+	apitypes "github.com/aws/aws-sdk-go-v2/service/apigatewayv2/types"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi/types"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	mock_apigatewayv2 "github.com/nitrictech/nitric/cloud/aws/mocks/apigatewayv2"
 	mocks "github.com/nitrictech/nitric/cloud/aws/mocks/resourcetaggingapi"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
 )
 
 var _ = Describe("AwsProvider", func() {
@@ -105,4 +109,208 @@ var _ = Describe("AwsProvider", func() {
 			})
 		})
 	})
+
+	When("Calling details for an api", func() {
+		apiArn := "arn:aws:apigateway:us-east-1::/apis/test-api-id"
+
+		When("The api has a custom domain", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockApiClient := mock_apigatewayv2.NewMockApiGatewayV2API(ctrl)
+			provider := &awsProviderImpl{
+				cache: map[string]map[string]string{
+					AwsResource_Api: {
+						"test": apiArn,
+					},
+				},
+				apiClient: mockApiClient,
+			}
+
+			It("should return the custom domain url", func() {
+				defer ctrl.Finish()
+
+				mockApiClient.EXPECT().GetApi(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetApiOutput{
+					ApiEndpoint: aws.String("https://test-api-id.execute-api.us-east-1.amazonaws.com"),
+				}, nil).Times(2)
+
+				By("listing the custom domains only once")
+				mockApiClient.EXPECT().GetDomainNames(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetDomainNamesOutput{
+					Items: []apitypes.DomainName{
+						{DomainName: aws.String("other.example.com")},
+						{DomainName: aws.String("api.example.com")},
+					},
+				}, nil)
+
+				mockApiClient.EXPECT().GetApiMappings(gomock.Any(), &apigatewayv2.GetApiMappingsInput{
+					DomainName: aws.String("other.example.com"),
+				}).Return(&apigatewayv2.GetApiMappingsOutput{
+					Items: []apitypes.ApiMapping{{ApiId: aws.String("other-api-id")}},
+				}, nil)
+
+				mockApiClient.EXPECT().GetApiMappings(gomock.Any(), &apigatewayv2.GetApiMappingsInput{
+					DomainName: aws.String("api.example.com"),
+				}).Return(&apigatewayv2.GetApiMappingsOutput{
+					Items: []apitypes.ApiMapping{{ApiId: aws.String("test-api-id"), ApiMappingKey: aws.String("v1")}},
+				}, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Api, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Detail).To(Equal(common.ApiDetails{
+					URL: "https://api.example.com/v1",
+				}))
+
+				details, err = provider.Details(context.TODO(), common.ResourceType_Api, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Detail).To(Equal(common.ApiDetails{
+					URL: "https://api.example.com/v1",
+				}))
+			})
+		})
+
+		When("The api has no custom domain", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockApiClient := mock_apigatewayv2.NewMockApiGatewayV2API(ctrl)
+			provider := &awsProviderImpl{
+				cache: map[string]map[string]string{
+					AwsResource_Api: {
+						"test": apiArn,
+					},
+				},
+				apiClient: mockApiClient,
+			}
+
+			It("should return the default endpoint until a custom domain is mapped", func() {
+				defer ctrl.Finish()
+
+				mockApiClient.EXPECT().GetApi(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetApiOutput{
+					ApiEndpoint: aws.String("https://test-api-id.execute-api.us-east-1.amazonaws.com"),
+				}, nil).Times(2)
+
+				gomock.InOrder(
+					mockApiClient.EXPECT().GetDomainNames(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetDomainNamesOutput{}, nil),
+					mockApiClient.EXPECT().GetDomainNames(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetDomainNamesOutput{
+						Items: []apitypes.DomainName{{DomainName: aws.String("api.example.com")}},
+					}, nil),
+				)
+
+				mockApiClient.EXPECT().GetApiMappings(gomock.Any(), gomock.Any()).Return(&apigatewayv2.GetApiMappingsOutput{
+					Items: []apitypes.ApiMapping{{ApiId: aws.String("test-api-id")}},
+				}, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Api, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Detail).To(Equal(common.ApiDetails{
+					URL: "https://test-api-id.execute-api.us-east-1.amazonaws.com",
+				}))
+
+				By("listing the custom domains again once one is mapped")
+				details, err = provider.Details(context.TODO(), common.ResourceType_Api, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Detail).To(Equal(common.ApiDetails{
+					URL: "https://api.example.com",
+				}))
+			})
+		})
+	})
+
+	When("Calling details for a bucket", func() {
+		provider := &awsProviderImpl{
+			region: "us-east-1",
+			cache: map[string]map[string]string{
+				AwsResource_Bucket: {
+					"test": "arn:aws:s3:::test-bucket-1234",
+				},
+			},
+		}
+
+		It("should return the bucket name and url", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Bucket, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("arn:aws:s3:::test-bucket-1234"))
+			Expect(details.Service).To(Equal("S3"))
+			Expect(details.Detail).To(Equal(common.BucketDetails{
+				Name: "test-bucket-1234",
+				URL:  "https://test-bucket-1234.s3.us-east-1.amazonaws.com",
+			}))
+		})
+	})
+
+	When("Calling details for a queue", func() {
+		provider := &awsProviderImpl{
+			cache: map[string]map[string]string{
+				AwsResource_Queue: {
+					"test": "arn:aws:sqs:us-east-1:123456789012:test-queue",
+				},
+			},
+		}
+
+		It("should return the queue name and url", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Queue, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Service).To(Equal("SQS"))
+			Expect(details.Detail).To(Equal(common.QueueDetails{
+				Name: "test-queue",
+				URL:  "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			}))
+		})
+	})
+
+	When("Calling details for a collection", func() {
+		provider := &awsProviderImpl{
+			cache: map[string]map[string]string{
+				AwsResource_Collection: {
+					"test": "arn:aws:dynamodb:us-east-1:123456789012:table/test-table",
+				},
+			},
+		}
+
+		It("should return the table name", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Collection, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Service).To(Equal("DynamoDB"))
+			Expect(details.Detail).To(Equal(common.CollectionDetails{
+				Name: "test-table",
+			}))
+		})
+	})
+
+	When("Calling details for a secret", func() {
+		provider := &awsProviderImpl{
+			cache: map[string]map[string]string{
+				AwsResource_Secret: {
+					"test": "arn:aws:secretsmanager:us-east-1:123456789012:secret:test-secret-a1b2c3",
+				},
+			},
+		}
+
+		It("should return the secret name without its random suffix", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Secret, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Service).To(Equal("SecretsManager"))
+			Expect(details.Detail).To(Equal(common.SecretDetails{
+				Name: "test-secret",
+			}))
+		})
+	})
+
+	When("Calling details for a resource that does not exist", func() {
+		provider := &awsProviderImpl{
+			cache: map[string]map[string]string{
+				AwsResource_Topic: {},
+			},
+		}
+
+		It("should return an error", func() {
+			_, err := provider.Details(context.TODO(), common.ResourceType_Topic, "missing")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
 })
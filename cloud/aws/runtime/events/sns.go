@@ -34,6 +34,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	utils2 "github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -67,6 +68,11 @@ func (s *SnsEventService) publish(ctx context.Context, topic string, message str
 	mc := propagation.MapCarrier{}
 	xray.Propagator{}.Inject(ctx, mc)
 
+	// W3C trace context is also included, restoring the trace on delivery regardless of the subscriber's propagator
+	for k, v := range span.ToAttributes(ctx) {
+		mc[k] = v
+	}
+
 	attrs := map[string]types.MessageAttributeValue{}
 	for k, v := range mc {
 		attrs[k] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: &v}
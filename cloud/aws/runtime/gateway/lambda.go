@@ -18,7 +18,9 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"strings"
@@ -31,8 +33,9 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/nitrictech/nitric/cloud/aws/runtime/core"
-	ep "github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/auth"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
@@ -42,7 +45,13 @@ type eventType int
 const (
 	unknown eventType = iota
 	sns
+	sqs
+	s3
+	eventBridge
 	httpEvent
+	restEvent
+	albEvent
+	websocketEvent
 	healthcheck
 	xforwardHeader string = "x-forwarded-for"
 )
@@ -50,11 +59,28 @@ const (
 type LambdaRuntimeHandler func(handler interface{})
 
 func getEventType(request map[string]interface{}) eventType {
+	if rc, ok := request["requestContext"].(map[string]interface{}); ok {
+		if _, ok := rc["connectionId"]; ok {
+			return websocketEvent
+		}
+		if _, ok := rc["elb"]; ok {
+			return albEvent
+		}
+	}
+
 	// If our event is a HTTP request
 	if _, ok := request["rawPath"]; ok {
 		return httpEvent
+	} else if _, ok := request["httpMethod"]; ok {
+		// API Gateway REST APIs use the v1 payload format
+		return restEvent
+	} else if _, ok := request["detail-type"]; ok {
+		return eventBridge
 	} else if records, ok := request["Records"]; ok {
 		recordsList, _ := records.([]interface{})
+		if len(recordsList) == 0 {
+			return unknown
+		}
 		record, _ := recordsList[0].(map[string]interface{})
 		// We have some kind of event here...
 		// we'll assume its an SNS
@@ -68,25 +94,55 @@ func getEventType(request map[string]interface{}) eventType {
 		switch eventSource {
 		case "aws:sns":
 			return sns
+		case "aws:sqs":
+			return sqs
+		case "aws:s3":
+			return s3
 		}
 	}
 
 	return unknown
 }
 
+// getNameForArn - returns the nitric name of the resource with the given arn
+func (s *LambdaGateway) getNameForArn(ctx context.Context, typ core.AwsResource, resourceArn string) (string, error) {
+	resources, err := s.provider.GetResources(ctx, typ)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving %s resources: %w", typ, err)
+	}
+
+	for name, arn := range resources {
+		if arn == resourceArn {
+			return name, nil
+		}
+	}
+
+	return "", fmt.Errorf("could not find %s for arn %s", typ, resourceArn)
+}
+
 func (s *LambdaGateway) getTopicNameForArn(ctx context.Context, topicArn string) (string, error) {
-	topics, err := s.provider.GetResources(ctx, core.AwsResource_Topic)
+	return s.getNameForArn(ctx, core.AwsResource_Topic, topicArn)
+}
+
+func (s *LambdaGateway) getSocketNameForApiId(ctx context.Context, apiId string) (string, error) {
+	apis, err := s.provider.GetResources(ctx, core.AwsResource_Api)
 	if err != nil {
-		return "", fmt.Errorf("error retrieving topics: %w", err)
+		return "", fmt.Errorf("error retrieving apis: %w", err)
 	}
 
-	for name, arn := range topics {
-		if arn == topicArn {
+	for name, arn := range apis {
+		if strings.HasSuffix(arn, "/apis/"+apiId) {
 			return name, nil
 		}
 	}
 
-	return "", fmt.Errorf("could not find topic for arn %s", topicArn)
+	return "", fmt.Errorf("could not find socket for api id %s", apiId)
+}
+
+var websocketEventTypes = map[string]triggers.WebsocketEventType{
+	"CONNECT":    triggers.WebsocketEventType_Connect,
+	"DISCONNECT": triggers.WebsocketEventType_Disconnect,
+	"MESSAGE":    triggers.WebsocketEventType_Message,
 }
 
 func (s *LambdaGateway) isHealthCheck(data map[string]interface{}) bool {
@@ -104,45 +160,67 @@ func (s *LambdaGateway) triggersFromRequest(ctx context.Context, data map[string
 		snsEvent := &events.SNSEvent{}
 		if err := json.Unmarshal(bytes, snsEvent); err == nil {
 			for _, snsRecord := range snsEvent.Records {
-				messageString := snsRecord.SNS.Message
-				// FIXME: What about non-nitric SNS events???
-				messageJson := &ep.NitricEvent{}
-				var payloadBytes []byte
-				var id string
-				attrs := map[string]string{}
-
-				for k, v := range snsRecord.SNS.MessageAttributes {
-					sv, ok := v.(string)
-					if ok {
-						attrs[k] = sv
-					}
-				}
-
-				// Populate the JSON
-				if err := json.Unmarshal([]byte(messageString), messageJson); err == nil {
-					payloadMap := messageJson.Payload
-					id = messageJson.ID
-					payloadBytes, _ = json.Marshal(&payloadMap)
-				} else {
-					// just try to capture the raw message
-					payloadBytes = []byte(messageString)
-					id = snsRecord.SNS.MessageID
-				}
+				attrs := snsAttributes(snsRecord.SNS.MessageAttributes)
+				// Messages that weren't published by nitric are delivered as is
+				id, payloadBytes := nitricEvent(snsRecord.SNS.Message, snsRecord.SNS.MessageID)
 
 				tName, err := s.getTopicNameForArn(ctx, snsRecord.SNS.TopicArn)
-
-				if err == nil {
-					trigs = append(trigs, &triggers.Event{
-						ID:         id,
-						Topic:      tName,
-						Payload:    payloadBytes,
-						Attributes: attrs,
-					})
-				} else {
-					log.Default().Printf("unable to find nitric topic: %v", err)
+				if err != nil {
+					// Fail the invocation so SNS retries delivery, or moves the message to the subscription's dead-letter queue
+					return nil, fmt.Errorf("unable to find nitric topic: %w", err)
 				}
+
+				trigs = append(trigs, &triggers.Event{
+					ID:         id,
+					Topic:      tName,
+					Payload:    payloadBytes,
+					Attributes: attrs,
+					Time:       snsRecord.SNS.Timestamp,
+				})
 			}
 		}
+	case s3:
+		evt := &events.S3Event{}
+		if err := json.Unmarshal(bytes, evt); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal s3 event: %w", err)
+		}
+
+		trigs = append(trigs, s.s3Events(ctx, evt)...)
+
+	case eventBridge:
+		evt := &events.CloudWatchEvent{}
+		if err := json.Unmarshal(bytes, evt); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal eventbridge event: %w", err)
+		}
+
+		trigs = append(trigs, eventBridgeEvent(evt))
+
+	case restEvent:
+		evt := &events.APIGatewayProxyRequest{}
+		if err := json.Unmarshal(bytes, evt); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal restEvent: %w", err)
+		}
+
+		req, err := restRequest(evt)
+		if err != nil {
+			return nil, err
+		}
+
+		trigs = append(trigs, req)
+
+	case albEvent:
+		evt := &events.ALBTargetGroupRequest{}
+		if err := json.Unmarshal(bytes, evt); err != nil {
+			return nil, fmt.Errorf("unable to unmarshal albEvent: %w", err)
+		}
+
+		req, err := albRequest(evt)
+		if err != nil {
+			return nil, err
+		}
+
+		trigs = append(trigs, req)
+
 	case httpEvent:
 		evt := &events.APIGatewayV2HTTPRequest{}
 
@@ -179,6 +257,49 @@ func (s *LambdaGateway) triggersFromRequest(ctx context.Context, data map[string
 			Path:   evt.RawPath,
 			URL:    evt.RawPath,
 			Query:  qVals,
+			Claims: jwtAuthorizerClaims(evt.RequestContext.Authorizer),
+		})
+
+	case websocketEvent:
+		evt := &events.APIGatewayWebsocketProxyRequest{}
+
+		err := json.Unmarshal(bytes, evt)
+		if err != nil {
+			return nil, fmt.Errorf("unable to unmarshal websocketEvent: %w", err)
+		}
+
+		eventType, ok := websocketEventTypes[evt.RequestContext.EventType]
+		if !ok {
+			return nil, fmt.Errorf("unhandled websocket event type %s", evt.RequestContext.EventType)
+		}
+
+		socket, err := s.getSocketNameForApiId(ctx, evt.RequestContext.APIID)
+		if err != nil {
+			return nil, err
+		}
+
+		body := []byte(evt.Body)
+		if evt.IsBase64Encoded {
+			body, err = base64.StdEncoding.DecodeString(evt.Body)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding websocket message: %w", err)
+			}
+		}
+
+		query := evt.MultiValueQueryStringParameters
+		if query == nil {
+			query = make(map[string][]string)
+			for k, v := range evt.QueryStringParameters {
+				query[k] = []string{v}
+			}
+		}
+
+		trigs = append(trigs, &triggers.WebsocketEvent{
+			Socket:       socket,
+			Event:        eventType,
+			ConnectionID: evt.RequestContext.ConnectionID,
+			Query:        query,
+			Body:         body,
 		})
 
 	case healthcheck:
@@ -195,7 +316,150 @@ type LambdaGateway struct {
 	provider core.AwsProvider
 	runtime  LambdaRuntimeHandler
 	gateway.UnimplementedGatewayPlugin
-	finished chan int
+}
+
+// jwtAuthorizerClaims - returns the claims of a token validated by an API Gateway JWT authorizer, so the membrane doesn't validate it again
+func jwtAuthorizerClaims(authorizer *events.APIGatewayV2HTTPRequestContextAuthorizerDescription) map[string]interface{} {
+	if authorizer == nil || authorizer.JWT == nil || len(authorizer.JWT.Claims) == 0 {
+		return nil
+	}
+
+	claims := make(map[string]interface{}, len(authorizer.JWT.Claims))
+	for k, v := range authorizer.JWT.Claims {
+		claims[k] = v
+	}
+
+	return claims
+}
+
+// handleHttpRequest - handles an HTTP request from API Gateway or an ALB, returning the response in the API Gateway proxy format
+func (s *LambdaGateway) handleHttpRequest(ctx context.Context, httpEvent *triggers.HttpRequest) (events.APIGatewayProxyResponse, error) {
+	wrkr, err := s.pool.GetWorker(&worker.GetWorkerOptions{
+		Http: httpEvent,
+	})
+	if errors.Is(err, worker.ErrDraining) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 503,
+			Body:       "Service is shutting down",
+		}, nil
+	} else if errors.Is(err, worker.ErrSaturated) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 503,
+			Body:       "Service is at capacity",
+		}, nil
+	} else if err != nil {
+		return events.APIGatewayProxyResponse{}, fmt.Errorf("unable to get worker to handle http trigger")
+	}
+
+	var hc propagation.HeaderCarrier = httpEvent.Header
+
+	response, err := wrkr.HandleHttpRequest(xray.Propagator{}.Extract(ctx, hc), httpEvent)
+	if errors.Is(err, auth.ErrUnauthenticated) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 401,
+			Headers:    map[string]string{"WWW-Authenticate": "Bearer"},
+			Body:       "Unauthorized",
+		}, nil
+	} else if errors.Is(err, auth.ErrForbidden) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 403,
+			Body:       "Forbidden",
+		}, nil
+	} else if errors.Is(err, worker.ErrDraining) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 503,
+			Body:       "Service is shutting down",
+		}, nil
+	} else if errors.Is(err, worker.ErrSaturated) {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 503,
+			Body:       "Service is at capacity",
+		}, nil
+	} else if err != nil {
+		return events.APIGatewayProxyResponse{
+			StatusCode: 500,
+			Body:       "Error processing lambda request",
+			// TODO: Need to determine best case when to use this...
+			IsBase64Encoded: true,
+		}, nil
+	}
+
+	lambdaHTTPHeaders := make(map[string]string)
+
+	if response.Header != nil {
+		response.Header.VisitAll(func(key []byte, val []byte) {
+			lambdaHTTPHeaders[string(key)] = string(val)
+		})
+	}
+
+	body := response.Body
+	if response.BodyStream != nil {
+		// Lambda responses can't be streamed, so the body is buffered
+		body, err = io.ReadAll(response.BodyStream)
+		_ = response.BodyStream.Close()
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, fmt.Errorf("error reading response body: %w", err)
+		}
+	}
+
+	responseString := base64.StdEncoding.EncodeToString(body)
+
+	// We want to sniff the content type of the body that we have here as lambda cannot gzip it...
+	return events.APIGatewayProxyResponse{
+		StatusCode: response.StatusCode,
+		Headers:    lambdaHTTPHeaders,
+		Body:       responseString,
+		// TODO: Need to determine best case when to use this...
+		IsBase64Encoded: true,
+	}, nil
+}
+
+// handleEvent - handles an event from a topic, queue, bucket or schedule
+func (s *LambdaGateway) handleEvent(ctx context.Context, event *triggers.Event) error {
+	wrkr, err := s.pool.GetWorker(&worker.GetWorkerOptions{
+		Event: event,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to get worker to event trigger: %w", err)
+	}
+
+	var mc propagation.MapCarrier = event.Attributes
+
+	// W3C trace context takes precedence over X-Ray headers when both are present
+	eventCtx := span.FromAttributes(xray.Propagator{}.Extract(ctx, mc), event.Attributes)
+
+	return wrkr.HandleEvent(eventCtx, event)
+}
+
+// handleSqs - handles each message in an SQS batch, reporting the messages that failed
+// so only they are returned to the queue
+func (s *LambdaGateway) handleSqs(ctx context.Context, data map[string]interface{}) (interface{}, error) {
+	bytes, _ := json.Marshal(data)
+
+	evt := &events.SQSEvent{}
+	if err := json.Unmarshal(bytes, evt); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal sqs event: %w", err)
+	}
+
+	response := events.SQSEventResponse{
+		BatchItemFailures: []events.SQSBatchItemFailure{},
+	}
+
+	for _, record := range evt.Records {
+		event, err := s.sqsEvent(ctx, record)
+		if err == nil {
+			err = s.handleEvent(ctx, event)
+		}
+
+		if err != nil {
+			log.Default().Printf("error handling sqs message %s: %v", record.MessageId, err)
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{
+				ItemIdentifier: record.MessageId,
+			})
+		}
+	}
+
+	return response, nil
 }
 
 func (s *LambdaGateway) handle(ctx context.Context, data map[string]interface{}) (interface{}, error) {
@@ -205,68 +469,69 @@ func (s *LambdaGateway) handle(ctx context.Context, data map[string]interface{})
 		}, nil
 	}
 
+	evtType := getEventType(data)
+	if evtType == sqs {
+		return s.handleSqs(ctx, data)
+	}
+
 	trigs, err := s.triggersFromRequest(ctx, data)
 	if err != nil {
 		return nil, err
 	}
 
+	// Every event in a batch is handled, failures are collected and reported together
+	// so the invocation is retried rather than silently dropping the failed events
+	failures := []string{}
+
 	for _, request := range trigs {
 		switch request.GetTriggerType() {
 		case triggers.TriggerType_Request:
 			if httpEvent, ok := request.(*triggers.HttpRequest); ok {
-				wrkr, err := s.pool.GetWorker(&worker.GetWorkerOptions{
-					Http: httpEvent,
-				})
-				if err != nil {
-					return nil, fmt.Errorf("unable to get worker to handle http trigger")
-				}
-
-				var hc propagation.HeaderCarrier = httpEvent.Header
-
-				response, err := wrkr.HandleHttpRequest(xray.Propagator{}.Extract(ctx, hc), httpEvent)
+				response, err := s.handleHttpRequest(ctx, httpEvent)
 				if err != nil {
-					return events.APIGatewayProxyResponse{
-						StatusCode: 500,
-						Body:       "Error processing lambda request",
-						// TODO: Need to determine best case when to use this...
-						IsBase64Encoded: true,
-					}, nil
+					return nil, err
 				}
 
-				lambdaHTTPHeaders := make(map[string]string)
-
-				if response.Header != nil {
-					response.Header.VisitAll(func(key []byte, val []byte) {
-						lambdaHTTPHeaders[string(key)] = string(val)
-					})
+				if evtType == albEvent {
+					_, multiValueHeaders := data["multiValueHeaders"]
+					return albResponse(response, multiValueHeaders), nil
 				}
 
-				responseString := base64.StdEncoding.EncodeToString(response.Body)
-
-				// We want to sniff the content type of the body that we have here as lambda cannot gzip it...
-				return events.APIGatewayProxyResponse{
-					StatusCode: response.StatusCode,
-					Headers:    lambdaHTTPHeaders,
-					Body:       responseString,
-					// TODO: Need to determine best case when to use this...
-					IsBase64Encoded: true,
-				}, nil
+				return response, nil
 			} else {
 				return nil, fmt.Errorf("found non HttpRequest in event with trigger type: %s", triggers.TriggerType_Request.String())
 			}
-		case triggers.TriggerType_Subscription:
-			if event, ok := request.(*triggers.Event); ok {
+		case triggers.TriggerType_Websocket:
+			if wsEvent, ok := request.(*triggers.WebsocketEvent); ok {
 				wrkr, err := s.pool.GetWorker(&worker.GetWorkerOptions{
-					Event: event,
+					Websocket: wsEvent,
 				})
+				if err != nil && wsEvent.Event != triggers.WebsocketEventType_Message {
+					// Connect and disconnect handlers are optional
+					return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+				} else if err != nil {
+					return nil, fmt.Errorf("unable to get worker to handle websocket event")
+				}
+
+				response, err := wrkr.HandleWebsocketEvent(ctx, wsEvent)
 				if err != nil {
-					return nil, fmt.Errorf("unable to get worker to event trigger")
+					return nil, err
 				}
 
-				var mc propagation.MapCarrier = event.Attributes
+				if !response.Success {
+					// Rejects the connection for connect events
+					return events.APIGatewayProxyResponse{StatusCode: 403}, nil
+				}
 
-				if err := wrkr.HandleEvent(xray.Propagator{}.Extract(ctx, mc), event); err != nil {
-					return nil, err
+				return events.APIGatewayProxyResponse{StatusCode: 200}, nil
+			} else {
+				return nil, fmt.Errorf("found non WebsocketEvent in event with trigger type: %s", triggers.TriggerType_Websocket.String())
+			}
+		case triggers.TriggerType_Subscription:
+			if event, ok := request.(*triggers.Event); ok {
+				if err := s.handleEvent(ctx, event); err != nil {
+					log.Default().Printf("error handling event %s: %v", event.ID, err)
+					failures = append(failures, fmt.Sprintf("%s: %v", event.ID, err))
 				}
 			} else {
 				return nil, fmt.Errorf("found non Event in event with trigger type: %s", triggers.TriggerType_Subscription.String())
@@ -274,12 +539,15 @@ func (s *LambdaGateway) handle(ctx context.Context, data map[string]interface{})
 		}
 	}
 
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("%d of %d events failed: %s", len(failures), len(trigs), strings.Join(failures, "; "))
+	}
+
 	return nil, nil
 }
 
 // Start the lambda gateway handler
 func (s *LambdaGateway) Start(pool worker.WorkerPool) error {
-	// s.finished = make(chan int)
 	s.pool = pool
 	// Here we want to begin polling lambda for incoming requests...
 	s.runtime(func(ctx context.Context, data map[string]interface{}) (interface{}, error) {
@@ -292,17 +560,14 @@ func (s *LambdaGateway) Start(pool worker.WorkerPool) error {
 
 		return a, err
 	})
-	// Unblock the 'Stop' function if it's waiting.
-	go func() { s.finished <- 1 }()
 	return nil
 }
 
 func (s *LambdaGateway) Stop() error {
 	// XXX: This is a NO_OP Process, as this is a pull based system
-	// We don't need to stop listening to anything
-	log.Default().Println("gateway 'Stop' called, waiting for lambda runtime to finish")
-	// Lambda can't be stopped, need to wait for it to finish
-	<-s.finished
+	// We don't need to stop listening to anything.
+	// The lambda runtime never returns, in-flight invocations are drained by the membrane's worker pool instead
+	log.Default().Println("gateway 'Stop' called")
 	return nil
 }
 
@@ -310,7 +575,6 @@ func New(provider core.AwsProvider) (gateway.GatewayService, error) {
 	return &LambdaGateway{
 		provider: provider,
 		runtime:  lambda.Start,
-		finished: make(chan int),
 	}, nil
 }
 
@@ -318,6 +582,5 @@ func NewWithRuntime(provider core.AwsProvider, runtime LambdaRuntimeHandler) (ga
 	return &LambdaGateway{
 		provider: provider,
 		runtime:  runtime,
-		finished: make(chan int),
 	}, nil
 }
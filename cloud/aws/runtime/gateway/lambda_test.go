@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/golang/mock/gomock"
@@ -38,9 +39,17 @@ type MockLambdaRuntime struct {
 	lambda_service.LambdaRuntimeHandler
 	// FIXME: Make this a union array of stuff to send....
 	eventQueue []interface{}
+	// The responses returned by the handler for each event
+	results []interface{}
+	// Record handler errors instead of failing on them
+	allowErrors bool
+	errs        []error
 }
 
 func (m *MockLambdaRuntime) Start(handler interface{}) {
+	m.results = make([]interface{}, 0, len(m.eventQueue))
+	m.errs = make([]error, 0, len(m.eventQueue))
+
 	// cast the function type to what we know it will be
 	typedFunc := handler.(func(ctx context.Context, data map[string]interface{}) (interface{}, error))
 	for _, event := range m.eventQueue {
@@ -51,9 +60,14 @@ func (m *MockLambdaRuntime) Start(handler interface{}) {
 		Expect(err).To(BeNil())
 
 		// Unmarshal the thing into the event type we expect...
-		// TODO: Do something with out results here...
-		_, err = typedFunc(context.TODO(), evt)
-		Expect(err).To(BeNil())
+		result, err := typedFunc(context.TODO(), evt)
+		if m.allowErrors {
+			m.errs = append(m.errs, err)
+		} else {
+			Expect(err).To(BeNil())
+		}
+
+		m.results = append(m.results, result)
 	}
 }
 
@@ -135,6 +149,41 @@ var _ = Describe("Lambda", func() {
 	})
 
 	Context("SNS Events", func() {
+		When("Sending a HTTP Event validated by a JWT authorizer", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.APIGatewayV2HTTPRequest{
+					RawPath: "/test",
+					RequestContext: events.APIGatewayV2HTTPRequestContext{
+						HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+							Method: "GET",
+						},
+						Authorizer: &events.APIGatewayV2HTTPRequestContextAuthorizerDescription{
+							JWT: &events.APIGatewayV2HTTPRequestContextAuthorizerJWTDescription{
+								Claims: map[string]string{"sub": "user", "iss": "https://example.com"},
+							},
+						},
+					},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should pass the validated claims with the request", func() {
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				Expect(mockHandler.ReceivedRequests).To(HaveLen(1))
+				Expect(mockHandler.ReceivedRequests[0].Claims).To(Equal(map[string]interface{}{
+					"sub": "user",
+					"iss": "https://example.com",
+				}))
+			})
+		})
+
 		When("The Lambda Gateway receives SNS events", func() {
 			ctrl := gomock.NewController(GinkgoT())
 			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
@@ -195,5 +244,456 @@ var _ = Describe("Lambda", func() {
 				Expect(request.Topic).To(Equal("MyTopic"))
 			})
 		})
+
+		When("An event in the SNS batch fails", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			snsPool := worker.NewProcessPool(&worker.ProcessPoolOptions{})
+			snsHandler := mock_worker.NewMockWorker(&mock_worker.MockWorkerOptions{
+				EventError: func(trigger *triggers.Event) error {
+					if trigger.ID == "fail" {
+						return fmt.Errorf("mock error")
+					}
+					return nil
+				},
+			})
+			Expect(snsPool.AddWorker(snsHandler)).To(Succeed())
+
+			record := func(id string) events.SNSEventRecord {
+				return events.SNSEventRecord{
+					EventSource: "aws:sns",
+					SNS: events.SNSEntity{
+						MessageID: id,
+						TopicArn:  "some:arbitrary:topic:arn:MyTopic",
+						Message:   id,
+					},
+				}
+			}
+
+			runtime := MockLambdaRuntime{
+				allowErrors: true,
+				eventQueue: []interface{}{&events.SNSEvent{
+					Records: []events.SNSEventRecord{record("fail"), record("ok")},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("Should handle every event and fail the invocation", func() {
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AwsResource_Topic).AnyTimes().Return(map[string]string{
+					"MyTopic": "some:arbitrary:topic:arn:MyTopic",
+				}, nil)
+
+				Expect(client.Start(snsPool)).To(Succeed())
+
+				By("Handling the events after the failure")
+				Expect(snsHandler.ReceivedEvents).To(HaveLen(2))
+
+				By("Returning an error so the invocation is retried")
+				Expect(runtime.errs).To(HaveLen(1))
+				Expect(runtime.errs[0]).To(MatchError(ContainSubstring("1 of 2 events failed")))
+			})
+		})
+	})
+
+	Context("Websocket Events", func() {
+		When("The Lambda Gateway receives API Gateway websocket events", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				// Setup mock events for our runtime to process...
+				eventQueue: []interface{}{&events.APIGatewayWebsocketProxyRequest{
+					Body: "Test Message",
+					QueryStringParameters: map[string]string{
+						"key": "test",
+					},
+					RequestContext: events.APIGatewayWebsocketProxyRequestContext{
+						APIID:        "test-api-id",
+						ConnectionID: "test-connection-id",
+						EventType:    "MESSAGE",
+					},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into a websocket event", func() {
+				By("having the socket available")
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AwsResource_Api).Return(map[string]string{
+					"MySocket": "arn:aws:apigateway:us-east-1::/apis/test-api-id",
+				}, nil)
+
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single websocket event")
+				Expect(len(mockHandler.ReceivedSockets)).To(Equal(1))
+
+				evt := mockHandler.ReceivedSockets[0]
+
+				By("Resolving the socket name")
+				Expect(evt.Socket).To(Equal("MySocket"))
+				By("Retaining the event type")
+				Expect(evt.Event).To(Equal(triggers.WebsocketEventType_Message))
+				By("Retaining the connection id")
+				Expect(evt.ConnectionID).To(Equal("test-connection-id"))
+				By("Retaining the message body")
+				Expect(string(evt.Body)).To(Equal("Test Message"))
+				By("Retaining the query parameters")
+				Expect(evt.Query["key"]).To(Equal([]string{"test"}))
+			})
+		})
+	})
+
+	Context("API Gateway REST Events", func() {
+		When("The Lambda Gateway receives a REST API proxy request", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.APIGatewayProxyRequest{
+					HTTPMethod: "POST",
+					Path:       "/test/test",
+					MultiValueHeaders: map[string][]string{
+						"Content-Type": {"text/plain"},
+					},
+					MultiValueQueryStringParameters: map[string][]string{
+						"key": {"test", "test2"},
+					},
+					Body:            "VGVzdCBQYXlsb2Fk",
+					IsBase64Encoded: true,
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into a standard NitricRequest", func() {
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single HTTP request")
+				Expect(len(mockHandler.ReceivedRequests)).To(Equal(1))
+
+				request := mockHandler.ReceivedRequests[0]
+
+				By("Decoding the body")
+				Expect(string(request.Body)).To(Equal("Test Payload"))
+				By("Retaining the method and path")
+				Expect(request.Method).To(Equal("POST"))
+				Expect(request.Path).To(Equal("/test/test"))
+				By("Retaining the headers and query parameters")
+				Expect(request.Header["Content-Type"]).To(Equal([]string{"text/plain"}))
+				Expect(request.Query["key"]).To(Equal([]string{"test", "test2"}))
+
+				By("Returning an API Gateway proxy response")
+				Expect(runtime.results).To(HaveLen(1))
+				Expect(runtime.results[0].(events.APIGatewayProxyResponse).StatusCode).To(Equal(200))
+			})
+		})
+	})
+
+	Context("ALB Events", func() {
+		When("The Lambda Gateway receives an ALB target group request", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.ALBTargetGroupRequest{
+					HTTPMethod: "GET",
+					Path:       "/test/test",
+					Headers: map[string]string{
+						"user-agent": "Test",
+					},
+					QueryStringParameters: map[string]string{
+						"key": "hello%20world",
+					},
+					RequestContext: events.ALBTargetGroupRequestContext{
+						ELB: events.ELBContext{
+							TargetGroupArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/test/1234",
+						},
+					},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into a standard NitricRequest", func() {
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single HTTP request")
+				Expect(len(mockHandler.ReceivedRequests)).To(Equal(1))
+
+				request := mockHandler.ReceivedRequests[0]
+
+				By("Retaining the method and path")
+				Expect(request.Method).To(Equal("GET"))
+				Expect(request.Path).To(Equal("/test/test"))
+				By("Decoding the query parameters")
+				Expect(request.Query["key"]).To(Equal([]string{"hello world"}))
+
+				By("Returning an ALB response")
+				Expect(runtime.results).To(HaveLen(1))
+				response := runtime.results[0].(events.ALBTargetGroupResponse)
+				Expect(response.StatusCode).To(Equal(200))
+				Expect(response.StatusDescription).To(Equal("200 OK"))
+			})
+		})
+	})
+
+	Context("Draining", func() {
+		When("The Lambda Gateway receives HTTP requests while the pool is draining", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			drainingPool := worker.NewProcessPool(&worker.ProcessPoolOptions{})
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{
+					&events.APIGatewayV2HTTPRequest{
+						RawPath: "/test/test",
+						RequestContext: events.APIGatewayV2HTTPRequestContext{
+							HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+								Method: "GET",
+							},
+						},
+					},
+					&events.APIGatewayProxyRequest{
+						HTTPMethod: "GET",
+						Path:       "/test/test",
+					},
+					&events.ALBTargetGroupRequest{
+						HTTPMethod: "GET",
+						Path:       "/test/test",
+						RequestContext: events.ALBTargetGroupRequestContext{
+							ELB: events.ELBContext{
+								TargetGroupArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/test/1234",
+							},
+						},
+					},
+				},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("should respond with service unavailable", func() {
+				Expect(drainingPool.Drain(context.TODO())).To(Succeed())
+
+				err := client.Start(drainingPool)
+				Expect(err).To(BeNil())
+
+				Expect(runtime.results).To(HaveLen(3))
+
+				By("returning 503 for API Gateway requests")
+				Expect(runtime.results[0].(events.APIGatewayProxyResponse).StatusCode).To(Equal(503))
+
+				By("returning 503 for REST API requests")
+				Expect(runtime.results[1].(events.APIGatewayProxyResponse).StatusCode).To(Equal(503))
+
+				By("returning 503 for ALB requests")
+				Expect(runtime.results[2].(events.ALBTargetGroupResponse).StatusCode).To(Equal(503))
+			})
+		})
+	})
+
+	Context("SQS Events", func() {
+		When("The Lambda Gateway receives a batch of SQS messages", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			sqsPool := worker.NewProcessPool(&worker.ProcessPoolOptions{})
+			sqsHandler := mock_worker.NewMockWorker(&mock_worker.MockWorkerOptions{
+				EventError: func(trigger *triggers.Event) error {
+					if string(trigger.Payload) == "fail" {
+						return fmt.Errorf("mock error")
+					}
+					return nil
+				},
+			})
+			Expect(sqsPool.AddWorker(sqsHandler)).To(Succeed())
+
+			snsNotification, err := json.Marshal(map[string]interface{}{
+				"Type":      "Notification",
+				"MessageId": "sns-message-id",
+				"TopicArn":  "arn:aws:sns:us-east-1:123456789012:MyTopic",
+				"Message":   "from topic",
+			})
+			Expect(err).To(BeNil())
+
+			queueArn := "arn:aws:sqs:us-east-1:123456789012:my-queue-1234"
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.SQSEvent{
+					Records: []events.SQSMessage{
+						{MessageId: "1", EventSource: "aws:sqs", EventSourceARN: queueArn, Body: "ok"},
+						{MessageId: "2", EventSource: "aws:sqs", EventSourceARN: queueArn, Body: "fail"},
+						{MessageId: "3", EventSource: "aws:sqs", EventSourceARN: queueArn, Body: string(snsNotification)},
+					},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should report the messages that failed", func() {
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AwsResource_Queue).Return(map[string]string{
+					"my-queue": queueArn,
+				}, nil).AnyTimes()
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AwsResource_Topic).Return(map[string]string{
+					"MyTopic": "arn:aws:sns:us-east-1:123456789012:MyTopic",
+				}, nil)
+
+				err := client.Start(sqsPool)
+				Expect(err).To(BeNil())
+
+				By("Handling every message")
+				Expect(sqsHandler.ReceivedEvents).To(HaveLen(3))
+
+				By("Resolving the queue name")
+				Expect(sqsHandler.ReceivedEvents[0].Topic).To(Equal("my-queue"))
+				Expect(sqsHandler.ReceivedEvents[0].ID).To(Equal("1"))
+
+				By("Unwrapping messages delivered from SNS topics")
+				Expect(sqsHandler.ReceivedEvents[2].Topic).To(Equal("MyTopic"))
+				Expect(string(sqsHandler.ReceivedEvents[2].Payload)).To(Equal("from topic"))
+
+				By("Returning the failed message as a batch item failure")
+				Expect(runtime.results).To(HaveLen(1))
+				Expect(runtime.results[0]).To(Equal(events.SQSEventResponse{
+					BatchItemFailures: []events.SQSBatchItemFailure{{ItemIdentifier: "2"}},
+				}))
+			})
+		})
+	})
+
+	Context("S3 Events", func() {
+		When("The Lambda Gateway receives S3 object notifications", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.S3Event{
+					Records: []events.S3EventRecord{{
+						EventSource: "aws:s3",
+						EventName:   "ObjectCreated:Put",
+						S3: events.S3Entity{
+							Bucket: events.S3Bucket{
+								Name: "my-bucket-1234",
+								Arn:  "arn:aws:s3:::my-bucket-1234",
+							},
+							Object: events.S3Object{
+								Key:       "images/my+photo.png",
+								Size:      1024,
+								Sequencer: "0055AED6DCD90281E5",
+							},
+						},
+					}},
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into an event for the bucket", func() {
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AwsResource_Bucket).Return(map[string]string{
+					"my-bucket": "arn:aws:s3:::my-bucket-1234",
+				}, nil)
+
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single event")
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(1))
+
+				evt := mockHandler.ReceivedEvents[0]
+
+				By("Resolving the bucket name")
+				Expect(evt.Topic).To(Equal("my-bucket"))
+				By("Decoding the object key")
+				Expect(evt.Attributes["key"]).To(Equal("images/my photo.png"))
+				Expect(evt.Attributes["eventName"]).To(Equal("ObjectCreated:Put"))
+			})
+		})
+	})
+
+	Context("EventBridge Events", func() {
+		When("The Lambda Gateway receives a scheduled event", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.CloudWatchEvent{
+					ID:         "test-event-id",
+					DetailType: "Scheduled Event",
+					Source:     "aws.events",
+					Time:       time.Now(),
+					Resources:  []string{"arn:aws:events:us-east-1:123456789012:rule/Prune Orders"},
+					Detail:     json.RawMessage("{}"),
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into an event for the schedule", func() {
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single event")
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(1))
+
+				evt := mockHandler.ReceivedEvents[0]
+
+				By("Resolving the schedule from the rule name")
+				Expect(evt.Topic).To(Equal("prune-orders"))
+				Expect(evt.ID).To(Equal("test-event-id"))
+				Expect(evt.Attributes["source"]).To(Equal("aws.events"))
+				By("Marking the event as raised by a schedule")
+				Expect(evt.Schedule).To(BeTrue())
+				Expect(evt.Time).ToNot(BeZero())
+			})
+		})
+
+		When("The Lambda Gateway receives a custom event", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+
+			runtime := MockLambdaRuntime{
+				eventQueue: []interface{}{&events.CloudWatchEvent{
+					ID:         "test-event-id",
+					DetailType: "OrderPlaced",
+					Source:     "com.example.orders",
+					Time:       time.Now(),
+					Resources:  []string{"arn:aws:events:us-east-1:123456789012:rule/orders-bus/order-events"},
+					Detail:     json.RawMessage(`{"orderId":"1234"}`),
+				}},
+			}
+
+			client, err := lambda_service.NewWithRuntime(mockProvider, runtime.Start)
+			Expect(err).To(BeNil())
+
+			It("The gateway should translate into an event for the rule", func() {
+				err := client.Start(pool)
+				Expect(err).To(BeNil())
+
+				By("Handling a single event")
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(1))
+
+				evt := mockHandler.ReceivedEvents[0]
+
+				By("Resolving the rule name")
+				Expect(evt.Topic).To(Equal("order-events"))
+				By("Retaining the event detail")
+				Expect(string(evt.Payload)).To(Equal(`{"orderId":"1234"}`))
+				Expect(evt.Schedule).To(BeFalse())
+			})
+		})
 	})
 })
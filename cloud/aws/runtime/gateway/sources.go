@@ -0,0 +1,306 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/nitrictech/nitric/cloud/aws/runtime/core"
+	ep "github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+)
+
+// nitricEvent - unwraps a message published by a nitric SDK, falling back to the raw message and the given ID
+func nitricEvent(message string, id string) (string, []byte) {
+	messageJson := &ep.NitricEvent{}
+	if err := json.Unmarshal([]byte(message), messageJson); err != nil {
+		return id, []byte(message)
+	}
+
+	payloadBytes, _ := json.Marshal(&messageJson.Payload)
+
+	return messageJson.ID, payloadBytes
+}
+
+// snsAttributes - flattens SNS message attributes, which are delivered as {"Type": "String", "Value": "..."}
+func snsAttributes(messageAttributes map[string]interface{}) map[string]string {
+	attrs := map[string]string{}
+
+	for k, v := range messageAttributes {
+		switch av := v.(type) {
+		case string:
+			attrs[k] = av
+		case map[string]interface{}:
+			if sv, ok := av["Value"].(string); ok {
+				attrs[k] = sv
+			}
+		}
+	}
+
+	return attrs
+}
+
+// arnName - returns the final segment of an arn, e.g. the name of a queue
+func arnName(arn string) string {
+	return arn[strings.LastIndexAny(arn, ":/")+1:]
+}
+
+// sqsEvent - converts an SQS message to an event for the topic or queue it was delivered from
+func (s *LambdaGateway) sqsEvent(ctx context.Context, record events.SQSMessage) (*triggers.Event, error) {
+	attrs := map[string]string{}
+	for k, v := range record.MessageAttributes {
+		if v.StringValue != nil {
+			attrs[k] = *v.StringValue
+		}
+	}
+
+	// Messages delivered to the queue by an SNS subscription are wrapped in an SNS notification
+	notification := &events.SNSEntity{}
+	if err := json.Unmarshal([]byte(record.Body), notification); err == nil && notification.Type == "Notification" && notification.TopicArn != "" {
+		topic, err := s.getTopicNameForArn(ctx, notification.TopicArn)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range snsAttributes(notification.MessageAttributes) {
+			attrs[k] = v
+		}
+
+		id, payload := nitricEvent(notification.Message, notification.MessageID)
+
+		return &triggers.Event{
+			ID:         id,
+			Topic:      topic,
+			Payload:    payload,
+			Attributes: attrs,
+			Time:       notification.Timestamp,
+		}, nil
+	}
+
+	queue, err := s.getNameForArn(ctx, core.AwsResource_Queue, record.EventSourceARN)
+	if err != nil {
+		// Queues that weren't deployed by nitric are identified by their name
+		queue = arnName(record.EventSourceARN)
+	}
+
+	id, payload := nitricEvent(record.Body, record.MessageId)
+
+	return &triggers.Event{
+		ID:         id,
+		Topic:      queue,
+		Payload:    payload,
+		Attributes: attrs,
+		Time:       sqsSentTime(record),
+	}, nil
+}
+
+// sqsSentTime - returns when the message was sent to the queue, zero if it wasn't recorded
+func sqsSentTime(record events.SQSMessage) time.Time {
+	millis, err := strconv.ParseInt(record.Attributes["SentTimestamp"], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.UnixMilli(millis)
+}
+
+// s3Events - converts S3 object notifications to events for the bucket they occurred in
+func (s *LambdaGateway) s3Events(ctx context.Context, evt *events.S3Event) []triggers.Trigger {
+	trigs := make([]triggers.Trigger, 0, len(evt.Records))
+
+	for _, record := range evt.Records {
+		bucket, err := s.getNameForArn(ctx, core.AwsResource_Bucket, record.S3.Bucket.Arn)
+		if err != nil {
+			// Buckets that weren't deployed by nitric are identified by their name
+			bucket = record.S3.Bucket.Name
+		}
+
+		payload, _ := json.Marshal(map[string]interface{}{
+			"bucket": bucket,
+			"key":    record.S3.Object.URLDecodedKey,
+			"event":  record.EventName,
+			"size":   record.S3.Object.Size,
+			"eTag":   record.S3.Object.ETag,
+		})
+
+		trigs = append(trigs, &triggers.Event{
+			ID:      record.S3.Object.Sequencer,
+			Topic:   bucket,
+			Payload: payload,
+			Attributes: map[string]string{
+				"eventName": record.EventName,
+				"key":       record.S3.Object.URLDecodedKey,
+			},
+			Time: record.EventTime,
+		})
+	}
+
+	return trigs
+}
+
+// eventBridgeEvent - converts an EventBridge event to an event for the rule that matched it,
+// scheduled events are delivered to the schedule named by their rule
+func eventBridgeEvent(evt *events.CloudWatchEvent) *triggers.Event {
+	topic := evt.DetailType
+	for _, resource := range evt.Resources {
+		if _, rule, ok := strings.Cut(resource, ":rule/"); ok {
+			// Rules on custom event buses are named bus-name/rule-name
+			topic = rule[strings.LastIndex(rule, "/")+1:]
+			break
+		}
+	}
+
+	schedule := evt.Source == "aws.events" && evt.DetailType == "Scheduled Event"
+	if schedule {
+		topic = worker.ScheduleKeyToTopicName(topic)
+	}
+
+	payload := []byte(evt.Detail)
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	return &triggers.Event{
+		ID:      evt.ID,
+		Topic:   topic,
+		Payload: payload,
+		Attributes: map[string]string{
+			"source":      evt.Source,
+			"detail-type": evt.DetailType,
+		},
+		Schedule: schedule,
+		Time:     evt.Time,
+	}
+}
+
+// proxyRequest - converts the common fields of API Gateway REST and ALB requests to an HTTP request
+func proxyRequest(method string, path string, header map[string][]string, query map[string][]string, body string, isBase64Encoded bool) (*triggers.HttpRequest, error) {
+	headerCopy := make(map[string][]string)
+	for key, val := range header {
+		if strings.ToLower(key) == "host" {
+			headerCopy[xforwardHeader] = append(headerCopy[xforwardHeader], val...)
+		} else {
+			headerCopy[key] = append(headerCopy[key], val...)
+		}
+	}
+
+	bodyBytes := []byte(body)
+	if isBase64Encoded {
+		var err error
+		if bodyBytes, err = base64.StdEncoding.DecodeString(body); err != nil {
+			return nil, fmt.Errorf("error decoding request body: %w", err)
+		}
+	}
+
+	if query == nil {
+		query = make(map[string][]string)
+	}
+
+	return &triggers.HttpRequest{
+		Header: headerCopy,
+		Body:   bodyBytes,
+		Method: method,
+		Path:   path,
+		URL:    path,
+		Query:  query,
+	}, nil
+}
+
+// singleValues - converts single value headers or query parameters to their multi value form
+func singleValues(values map[string]string) map[string][]string {
+	multi := make(map[string][]string, len(values))
+	for k, v := range values {
+		multi[k] = []string{v}
+	}
+
+	return multi
+}
+
+// restRequest - converts an API Gateway REST API (v1) proxy request to an HTTP request
+func restRequest(evt *events.APIGatewayProxyRequest) (*triggers.HttpRequest, error) {
+	header := evt.MultiValueHeaders
+	if header == nil {
+		header = singleValues(evt.Headers)
+	}
+
+	query := evt.MultiValueQueryStringParameters
+	if query == nil {
+		query = singleValues(evt.QueryStringParameters)
+	}
+
+	return proxyRequest(evt.HTTPMethod, evt.Path, header, query, evt.Body, evt.IsBase64Encoded)
+}
+
+// albRequest - converts an ALB target group request to an HTTP request
+func albRequest(evt *events.ALBTargetGroupRequest) (*triggers.HttpRequest, error) {
+	header := evt.MultiValueHeaders
+	if header == nil {
+		header = singleValues(evt.Headers)
+	}
+
+	rawQuery := evt.MultiValueQueryStringParameters
+	if rawQuery == nil {
+		rawQuery = singleValues(evt.QueryStringParameters)
+	}
+
+	// ALB passes query parameters through without decoding them
+	query := make(map[string][]string, len(rawQuery))
+	for k, vals := range rawQuery {
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			key = k
+		}
+
+		for _, v := range vals {
+			val, err := url.QueryUnescape(v)
+			if err != nil {
+				val = v
+			}
+			query[key] = append(query[key], val)
+		}
+	}
+
+	return proxyRequest(evt.HTTPMethod, evt.Path, header, query, evt.Body, evt.IsBase64Encoded)
+}
+
+// albResponse - converts a proxy response to the format expected by ALB target groups,
+// which must use multi value headers when they are enabled for the target group
+func albResponse(resp events.APIGatewayProxyResponse, multiValueHeaders bool) events.ALBTargetGroupResponse {
+	albResp := events.ALBTargetGroupResponse{
+		StatusCode:        resp.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		Body:              resp.Body,
+		IsBase64Encoded:   resp.IsBase64Encoded,
+	}
+
+	if multiValueHeaders {
+		albResp.MultiValueHeaders = singleValues(resp.Headers)
+	} else {
+		albResp.Headers = resp.Headers
+	}
+
+	return albResp
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -74,6 +75,21 @@ func (s *SQSQueueService) getUrlForQueueName(ctx context.Context, queue string)
 	return out.QueueUrl, nil
 }
 
+// traceAttributes - returns the W3C trace context of ctx as SQS message attributes, nil if there is no active trace
+func traceAttributes(ctx context.Context) map[string]types.MessageAttributeValue {
+	var attrs map[string]types.MessageAttributeValue
+
+	for k, v := range span.ToAttributes(ctx) {
+		if attrs == nil {
+			attrs = map[string]types.MessageAttributeValue{}
+		}
+
+		attrs[k] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String(v)}
+	}
+
+	return attrs
+}
+
 func (s *SQSQueueService) Send(ctx context.Context, queueName string, task queue.NitricTask) error {
 	newErr := errors.ErrorsWithScope(
 		"SQSQueueService.Send",
@@ -105,13 +121,15 @@ func (s *SQSQueueService) SendBatch(ctx context.Context, queueName string, tasks
 
 	if url, err := s.getUrlForQueueName(ctx, queueName); err == nil {
 		entries := make([]types.SendMessageBatchRequestEntry, 0)
+		attrs := traceAttributes(ctx)
 
 		for _, task := range tasks {
 			if bytes, err := json.Marshal(task); err == nil {
 				entries = append(entries, types.SendMessageBatchRequestEntry{
 					// Share the request ID here...
-					Id:          &task.ID,
-					MessageBody: aws.String(string(bytes)),
+					Id:                &task.ID,
+					MessageBody:       aws.String(string(bytes)),
+					MessageAttributes: attrs,
 				})
 			} else {
 				// TODO: Do we want to just mark this one as having errored?
@@ -215,11 +233,19 @@ func (s *SQSQueueService) Receive(ctx context.Context, options queue.ReceiveOpti
 				)
 			}
 
+			attrs := map[string]string{}
+			for k, v := range m.MessageAttributes {
+				if v.StringValue != nil {
+					attrs[k] = *v.StringValue
+				}
+			}
+
 			tasks = append(tasks, queue.NitricTask{
-				ID:          nitricTask.ID,
-				Payload:     nitricTask.Payload,
-				PayloadType: nitricTask.PayloadType,
-				LeaseID:     *m.ReceiptHandle,
+				ID:           nitricTask.ID,
+				Payload:      nitricTask.Payload,
+				PayloadType:  nitricTask.PayloadType,
+				LeaseID:      *m.ReceiptHandle,
+				TraceContext: span.TraceContextAttributes(attrs),
 			})
 		}
 
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 	"github.com/golang/mock/gomock"
+	"go.opentelemetry.io/otel/trace"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,6 +33,19 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
 )
 
+const testTraceParent = "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01"
+
+func tracedContext() context.Context {
+	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
+	spanID, _ := trace.SpanIDFromHex("0102030405060708")
+
+	return trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+}
+
 var _ = Describe("Sqs", func() {
 	Context("getUrlForQueueName", func() {
 		When("GetResources returns an error", func() {
@@ -121,6 +135,55 @@ var _ = Describe("Sqs", func() {
 			})
 		})
 
+		When("Publishing with an active trace", func() {
+			It("Should include the W3C trace context in the message attributes", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+				providerMock := mock_provider.NewMockAwsProvider(ctrl)
+				queueUrl := aws.String("https://example.com/test-queue")
+				plugin := NewWithClient(providerMock, sqsMock)
+
+				By("The queue being available")
+				providerMock.EXPECT().GetResources(gomock.Any(), core.AwsResource_Queue).Return(map[string]string{
+					"test-queue": "arn:aws:sqs:us-east-2:444455556666:test-queue",
+				}, nil)
+
+				sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.GetQueueUrlOutput{
+					QueueUrl: queueUrl,
+				}, nil)
+
+				By("Calling SendMessageBatch with the traceparent attribute")
+				sqsMock.EXPECT().SendMessageBatch(gomock.Any(), &sqs.SendMessageBatchInput{
+					QueueUrl: queueUrl,
+					Entries: []types.SendMessageBatchRequestEntry{
+						{
+							Id:          aws.String("1234"),
+							MessageBody: aws.String(`{"id":"1234","payloadType":"test-payload","payload":{"Test":"Test"}}`),
+							MessageAttributes: map[string]types.MessageAttributeValue{
+								"traceparent": {
+									DataType:    aws.String("String"),
+									StringValue: aws.String(testTraceParent),
+								},
+							},
+						},
+					},
+				}).Return(&sqs.SendMessageBatchOutput{}, nil)
+
+				_, err := plugin.SendBatch(tracedContext(), "test-queue", []queue.NitricTask{
+					{
+						ID:          "1234",
+						PayloadType: "test-payload",
+						Payload: map[string]interface{}{
+							"Test": "Test",
+						},
+					},
+				})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				ctrl.Finish()
+			})
+		})
+
 		When("Publishing to a queue that doesn't exist", func() {
 			When("List queues returns an error", func() {
 				It("Should fail to publish the message", func() {
@@ -212,6 +275,60 @@ var _ = Describe("Sqs", func() {
 				})
 			})
 
+			When("The message was sent with a trace context", func() {
+				It("Should return the trace context with the task", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					sqsMock := mocks_sqs.NewMockSQSAPI(ctrl)
+					providerMock := mock_provider.NewMockAwsProvider(ctrl)
+					plugin := NewWithClient(providerMock, sqsMock)
+
+					queueUrl := aws.String("https://example.com/test-queue")
+
+					providerMock.EXPECT().GetResources(gomock.Any(), core.AwsResource_Queue).Return(map[string]string{
+						"mock-queue": "arn:aws:sqs:us-east-2:444455556666:mock-queue",
+					}, nil)
+
+					sqsMock.EXPECT().GetQueueUrl(gomock.Any(), gomock.Any()).Return(&sqs.GetQueueUrlOutput{
+						QueueUrl: queueUrl,
+					}, nil)
+
+					By("ReceiveMessage returning a message with trace attributes")
+					sqsMock.EXPECT().ReceiveMessage(gomock.Any(), gomock.Any()).Times(1).Return(&sqs.ReceiveMessageOutput{
+						Messages: []types.Message{
+							{
+								ReceiptHandle: aws.String("mockreceipthandle"),
+								Body:          aws.String(`{"id":"1234","payloadType":"test-payload","payload":{"Test":"Test"}}`),
+								MessageAttributes: map[string]types.MessageAttributeValue{
+									"traceparent": {
+										DataType:    aws.String("String"),
+										StringValue: aws.String(testTraceParent),
+									},
+									"other": {
+										DataType:    aws.String("String"),
+										StringValue: aws.String("value"),
+									},
+								},
+							},
+						},
+					}, nil)
+
+					depth := uint32(10)
+
+					messages, err := plugin.Receive(context.TODO(), queue.ReceiveOptions{
+						QueueName: "mock-queue",
+						Depth:     &depth,
+					})
+
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(messages).To(HaveLen(1))
+					Expect(messages[0].TraceContext).To(Equal(map[string]string{
+						"traceparent": testTraceParent,
+					}))
+
+					ctrl.Finish()
+				})
+			})
+
 			When("There are no messages on the queue", func() {
 				It("Should receive no messages", func() {
 					ctrl := gomock.NewController(GinkgoT())
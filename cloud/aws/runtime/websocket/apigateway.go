@@ -0,0 +1,121 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi/types"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
+
+	"github.com/nitrictech/nitric/cloud/aws/ifaces/apigatewaymanagementapiiface"
+	"github.com/nitrictech/nitric/cloud/aws/runtime/core"
+	"github.com/nitrictech/nitric/core/pkg/plugins/websocket"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+	"github.com/nitrictech/nitric/core/pkg/utils"
+)
+
+type apiGatewayWebsocketService struct {
+	websocket.UnimplementedWebsocketPlugin
+	provider core.AwsProvider
+	client   apigatewaymanagementapiiface.ApiGatewayManagementAPI
+	stage    string
+}
+
+var _ websocket.WebsocketService = &apiGatewayWebsocketService{}
+
+// endpointFor - returns an option that points the management client at the callback url of the given socket
+func (a *apiGatewayWebsocketService) endpointFor(ctx context.Context, socket string) (func(*apigatewaymanagementapi.Options), error) {
+	details, err := a.provider.Details(ctx, common.ResourceType_Api, socket)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving socket %s: %w", socket, err)
+	}
+
+	apiDetails, ok := details.Detail.(common.ApiDetails)
+	if !ok {
+		return nil, fmt.Errorf("unable to determine endpoint for socket %s", socket)
+	}
+
+	// Websocket APIs report a wss:// endpoint, the management API is served over https from the deployed stage
+	callbackUrl := strings.Replace(apiDetails.URL, "wss://", "https://", 1) + "/" + a.stage
+
+	return apigatewaymanagementapi.WithEndpointResolver(apigatewaymanagementapi.EndpointResolverFromURL(callbackUrl)), nil
+}
+
+func (a *apiGatewayWebsocketService) Send(ctx context.Context, socket string, connectionId string, message []byte) error {
+	endpoint, err := a.endpointFor(ctx, socket)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.client.PostToConnection(ctx, &apigatewaymanagementapi.PostToConnectionInput{
+		ConnectionId: aws.String(connectionId),
+		Data:         message,
+	}, endpoint)
+	if err != nil {
+		return fmt.Errorf("error sending message to connection %s: %w", connectionId, err)
+	}
+
+	return nil
+}
+
+func (a *apiGatewayWebsocketService) Close(ctx context.Context, socket string, connectionId string) error {
+	endpoint, err := a.endpointFor(ctx, socket)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.client.DeleteConnection(ctx, &apigatewaymanagementapi.DeleteConnectionInput{
+		ConnectionId: aws.String(connectionId),
+	}, endpoint)
+	if err != nil {
+		// The connection has already been closed by the client
+		var gone *types.GoneException
+		if errors.As(err, &gone) {
+			return nil
+		}
+
+		return fmt.Errorf("error closing connection %s: %w", connectionId, err)
+	}
+
+	return nil
+}
+
+func New(provider core.AwsProvider) (websocket.WebsocketService, error) {
+	awsRegion := utils.GetEnv("AWS_REGION", "us-east-1")
+
+	cfg, sessionError := config.LoadDefaultConfig(context.TODO(), config.WithRegion(awsRegion))
+	if sessionError != nil {
+		return nil, fmt.Errorf("error creating new AWS session %w", sessionError)
+	}
+
+	otelaws.AppendMiddlewares(&cfg.APIOptions)
+
+	return NewWithClient(provider, apigatewaymanagementapi.NewFromConfig(cfg)), nil
+}
+
+func NewWithClient(provider core.AwsProvider, client apigatewaymanagementapiiface.ApiGatewayManagementAPI) websocket.WebsocketService {
+	return &apiGatewayWebsocketService{
+		provider: provider,
+		client:   client,
+		stage:    utils.GetEnv("WEBSOCKET_STAGE", "$default"),
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi"
+	"github.com/aws/aws-sdk-go-v2/service/apigatewaymanagementapi/types"
+	"github.com/golang/mock/gomock"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mock_apigatewaymanagementapiiface "github.com/nitrictech/nitric/cloud/aws/mocks/apigatewaymanagementapi"
+	mock_provider "github.com/nitrictech/nitric/cloud/aws/mocks/provider"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+var _ = Describe("ApiGatewayWebsocket", func() {
+	socketDetails := &common.DetailsResponse[any]{
+		Id:       "arn:aws:apigateway:us-east-1::/apis/test-api-id",
+		Provider: "aws",
+		Service:  "ApiGateway",
+		Detail: common.ApiDetails{
+			URL: "wss://test-api-id.execute-api.us-east-1.amazonaws.com",
+		},
+	}
+
+	Context("Send", func() {
+		When("the socket exists", func() {
+			It("should post the message to the connection", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockClient := mock_apigatewaymanagementapiiface.NewMockApiGatewayManagementAPI(ctrl)
+				mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+				plugin := NewWithClient(mockProvider, mockClient)
+
+				By("resolving the socket endpoint")
+				mockProvider.EXPECT().Details(gomock.Any(), common.ResourceType_Api, "test-socket").Return(socketDetails, nil)
+
+				By("posting to the connection")
+				mockClient.EXPECT().PostToConnection(gomock.Any(), &apigatewaymanagementapi.PostToConnectionInput{
+					ConnectionId: aws.String("test-connection"),
+					Data:         []byte("test"),
+				}, gomock.Any()).Return(&apigatewaymanagementapi.PostToConnectionOutput{}, nil)
+
+				err := plugin.Send(context.TODO(), "test-socket", "test-connection", []byte("test"))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				ctrl.Finish()
+			})
+		})
+
+		When("the socket does not exist", func() {
+			It("should return an error", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockClient := mock_apigatewaymanagementapiiface.NewMockApiGatewayManagementAPI(ctrl)
+				mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+				plugin := NewWithClient(mockProvider, mockClient)
+
+				mockProvider.EXPECT().Details(gomock.Any(), common.ResourceType_Api, "test-socket").Return(nil, fmt.Errorf("mock-error"))
+
+				err := plugin.Send(context.TODO(), "test-socket", "test-connection", []byte("test"))
+				Expect(err).Should(HaveOccurred())
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	Context("Close", func() {
+		When("the connection has already gone", func() {
+			It("should not return an error", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				mockClient := mock_apigatewaymanagementapiiface.NewMockApiGatewayManagementAPI(ctrl)
+				mockProvider := mock_provider.NewMockAwsProvider(ctrl)
+				plugin := NewWithClient(mockProvider, mockClient)
+
+				mockProvider.EXPECT().Details(gomock.Any(), common.ResourceType_Api, "test-socket").Return(socketDetails, nil)
+
+				By("deleting the connection")
+				mockClient.EXPECT().DeleteConnection(gomock.Any(), &apigatewaymanagementapi.DeleteConnectionInput{
+					ConnectionId: aws.String("test-connection"),
+				}, gomock.Any()).Return(nil, &types.GoneException{})
+
+				err := plugin.Close(context.TODO(), "test-socket", "test-connection")
+				Expect(err).ShouldNot(HaveOccurred())
+
+				ctrl.Finish()
+			})
+		})
+	})
+})
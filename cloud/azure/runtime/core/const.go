@@ -25,3 +25,9 @@ const AZURE_RESOURCE_GROUP = "AZURE_RESOURCE_GROUP"
 
 // AZURE_SUBSCRIPTION_ID - The subscription this azure resource belongs to
 const AZURE_SUBSCRIPTION_ID = "AZURE_SUBSCRIPTION_ID"
+
+// AZURE_KEYVAULT_NAME - The name of the key vault secrets are stored in
+const AZURE_KEYVAULT_NAME = "KVAULT_NAME"
+
+// MONGODB_DATABASE - The name of the mongodb database collections are stored in
+const MONGODB_DATABASE = "MONGODB_DATABASE"
@@ -17,7 +17,9 @@ package core
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2018-03-01/resources/mgmt/resources"
 	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2020-12-01/apimanagement"
@@ -49,6 +51,7 @@ const (
 )
 
 type AzGenericResource struct {
+	ID         string
 	Name       string
 	Type       string
 	Location   string
@@ -78,12 +81,18 @@ func (p *azProviderImpl) getApiDetails(ctx context.Context, name string) (*commo
 		service := res.Value()
 
 		if t, ok := service.Tags["x-nitric-name"]; ok && t != nil && *t == name {
+			gatewayUrl := *service.GatewayURL
+			// Custom domains take precedence over the default gateway url
+			if hostname := customProxyHostname(service); hostname != "" {
+				gatewayUrl = "https://" + hostname
+			}
+
 			return &common.DetailsResponse[any]{
 				Id:       *service.ID,
 				Provider: "azure",
 				Service:  "ApiManagement",
 				Detail: common.ApiDetails{
-					URL: *service.GatewayURL,
+					URL: gatewayUrl,
 				},
 			}, nil
 		}
@@ -97,10 +106,144 @@ func (p *azProviderImpl) getApiDetails(ctx context.Context, name string) (*commo
 	return nil, fmt.Errorf("api resource %s not found", name)
 }
 
+// customProxyHostname - returns the first custom gateway hostname of an api management service,
+// or an empty string if it only has the default hostname
+func customProxyHostname(service apimanagement.ServiceResource) string {
+	if service.ServiceProperties == nil || service.HostnameConfigurations == nil || service.GatewayURL == nil {
+		return ""
+	}
+
+	defaultUrl, err := url.Parse(*service.GatewayURL)
+	if err != nil {
+		return ""
+	}
+
+	for _, hc := range *service.HostnameConfigurations {
+		if hc.Type != apimanagement.HostnameTypeProxy || hc.HostName == nil {
+			continue
+		}
+
+		if !strings.EqualFold(*hc.HostName, defaultUrl.Hostname()) {
+			return *hc.HostName
+		}
+	}
+
+	return ""
+}
+
+// storageUrl - returns the url of a container or queue under a storage account endpoint
+func storageUrl(endpointEnv string, name string) (string, error) {
+	endpoint := os.Getenv(endpointEnv)
+	if endpoint == "" {
+		return "", fmt.Errorf("envvar %s is not set", endpointEnv)
+	}
+
+	return strings.TrimSuffix(endpoint, "/") + "/" + name, nil
+}
+
+func (p *azProviderImpl) getBucketDetails(name string) (*common.DetailsResponse[any], error) {
+	// buckets are blob containers named after the bucket
+	containerUrl, err := storageUrl(AZURE_STORAGE_BLOB_ENDPOINT, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       containerUrl,
+		Provider: "azure",
+		Service:  "Storage",
+		Detail: common.BucketDetails{
+			Name: name,
+			URL:  containerUrl,
+		},
+	}, nil
+}
+
+func (p *azProviderImpl) getTopicDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	topics, err := p.GetResources(ctx, AzResource_Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := topics[name]
+	if !ok {
+		return nil, fmt.Errorf("topic resource %s not found", name)
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       t.ID,
+		Provider: "azure",
+		Service:  "EventGrid",
+		Detail: common.TopicDetails{
+			Name: t.Name,
+		},
+	}, nil
+}
+
+func (p *azProviderImpl) getQueueDetails(name string) (*common.DetailsResponse[any], error) {
+	// queues are storage queues named after the queue
+	queueUrl, err := storageUrl(AZURE_STORAGE_QUEUE_ENDPOINT, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       queueUrl,
+		Provider: "azure",
+		Service:  "Storage",
+		Detail: common.QueueDetails{
+			Name: name,
+			URL:  queueUrl,
+		},
+	}, nil
+}
+
+func (p *azProviderImpl) getCollectionDetails(name string) (*common.DetailsResponse[any], error) {
+	database := os.Getenv(MONGODB_DATABASE)
+	if database == "" {
+		return nil, fmt.Errorf("envvar %s is not set", MONGODB_DATABASE)
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       database + "/" + name,
+		Provider: "azure",
+		Service:  "MongoDB",
+		Detail: common.CollectionDetails{
+			Name: name,
+		},
+	}, nil
+}
+
+func (p *azProviderImpl) getSecretDetails(name string) (*common.DetailsResponse[any], error) {
+	vaultName := os.Getenv(AZURE_KEYVAULT_NAME)
+	if vaultName == "" {
+		return nil, fmt.Errorf("envvar %s is not set", AZURE_KEYVAULT_NAME)
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       fmt.Sprintf("https://%s.vault.azure.net/secrets/%s", vaultName, name),
+		Provider: "azure",
+		Service:  "KeyVault",
+		Detail: common.SecretDetails{
+			Name: name,
+		},
+	}, nil
+}
+
 func (p *azProviderImpl) Details(ctx context.Context, typ common.ResourceType, name string) (*common.DetailsResponse[any], error) {
 	switch typ {
 	case common.ResourceType_Api:
 		return p.getApiDetails(ctx, name)
+	case common.ResourceType_Bucket:
+		return p.getBucketDetails(name)
+	case common.ResourceType_Topic:
+		return p.getTopicDetails(ctx, name)
+	case common.ResourceType_Queue:
+		return p.getQueueDetails(name)
+	case common.ResourceType_Collection:
+		return p.getCollectionDetails(name)
+	case common.ResourceType_Secret:
+		return p.getSecretDetails(name)
 	default:
 		return nil, fmt.Errorf("unsupported resource type %s", typ)
 	}
@@ -123,6 +266,7 @@ func (p *azProviderImpl) GetResources(ctx context.Context, r AzResource) (map[st
 			if tagV, ok := resource.Tags["x-nitric-name"]; ok && tagV != nil {
 				// Add it to the cache
 				p.cache[r][*tagV] = AzGenericResource{
+					ID:         *resource.ID,
 					Name:       *resource.Name,
 					Type:       *resource.Type,
 					Location:   *resource.Location,
@@ -0,0 +1,195 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/services/apimanagement/mgmt/2020-12-01/apimanagement"
+	"github.com/Azure/go-autorest/autorest/to"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+var _ = Describe("AzProvider", func() {
+	When("Finding the custom hostname of an api management service", func() {
+		cases := []struct {
+			description string
+			hostnames   []apimanagement.HostnameConfiguration
+			expected    string
+		}{
+			{
+				description: "only has the default hostname",
+				hostnames: []apimanagement.HostnameConfiguration{
+					{Type: apimanagement.HostnameTypeProxy, HostName: to.StringPtr("test.azure-api.net")},
+				},
+				expected: "",
+			},
+			{
+				description: "has a custom proxy hostname",
+				hostnames: []apimanagement.HostnameConfiguration{
+					{Type: apimanagement.HostnameTypeProxy, HostName: to.StringPtr("test.azure-api.net")},
+					{Type: apimanagement.HostnameTypeProxy, HostName: to.StringPtr("api.example.com")},
+				},
+				expected: "api.example.com",
+			},
+			{
+				description: "only has a custom portal hostname",
+				hostnames: []apimanagement.HostnameConfiguration{
+					{Type: apimanagement.HostnameTypeProxy, HostName: to.StringPtr("test.azure-api.net")},
+					{Type: apimanagement.HostnameTypePortal, HostName: to.StringPtr("portal.example.com")},
+				},
+				expected: "",
+			},
+		}
+
+		for _, c := range cases {
+			c := c
+
+			It(fmt.Sprintf("should return %q when the service %s", c.expected, c.description), func() {
+				service := apimanagement.ServiceResource{
+					ServiceProperties: &apimanagement.ServiceProperties{
+						GatewayURL:             to.StringPtr("https://test.azure-api.net"),
+						HostnameConfigurations: &c.hostnames,
+					},
+				}
+
+				Expect(customProxyHostname(service)).To(Equal(c.expected))
+			})
+		}
+	})
+
+	When("Calling details with the storage and database environment set", func() {
+		provider := &azProviderImpl{}
+
+		BeforeEach(func() {
+			os.Setenv(AZURE_STORAGE_BLOB_ENDPOINT, "https://test.blob.core.windows.net/")
+			os.Setenv(AZURE_STORAGE_QUEUE_ENDPOINT, "https://test.queue.core.windows.net/")
+			os.Setenv(MONGODB_DATABASE, "test-db")
+			os.Setenv(AZURE_KEYVAULT_NAME, "test-vault")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv(AZURE_STORAGE_BLOB_ENDPOINT)
+			os.Unsetenv(AZURE_STORAGE_QUEUE_ENDPOINT)
+			os.Unsetenv(MONGODB_DATABASE)
+			os.Unsetenv(AZURE_KEYVAULT_NAME)
+		})
+
+		It("should return the container url for a bucket", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Bucket, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("https://test.blob.core.windows.net/test"))
+			Expect(details.Detail).To(Equal(common.BucketDetails{
+				Name: "test",
+				URL:  "https://test.blob.core.windows.net/test",
+			}))
+		})
+
+		It("should return the queue url for a queue", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Queue, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("https://test.queue.core.windows.net/test"))
+			Expect(details.Detail).To(Equal(common.QueueDetails{
+				Name: "test",
+				URL:  "https://test.queue.core.windows.net/test",
+			}))
+		})
+
+		It("should return the database scoped name for a collection", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Collection, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("test-db/test"))
+			Expect(details.Detail).To(Equal(common.CollectionDetails{
+				Name: "test",
+			}))
+		})
+
+		It("should return the key vault url for a secret", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Secret, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("https://test-vault.vault.azure.net/secrets/test"))
+			Expect(details.Detail).To(Equal(common.SecretDetails{
+				Name: "test",
+			}))
+		})
+	})
+
+	When("Calling details without the storage and database environment set", func() {
+		provider := &azProviderImpl{}
+
+		for _, typ := range []common.ResourceType{
+			common.ResourceType_Bucket,
+			common.ResourceType_Queue,
+			common.ResourceType_Collection,
+			common.ResourceType_Secret,
+		} {
+			typ := typ
+
+			It(fmt.Sprintf("should return an error for a %s", typ), func() {
+				_, err := provider.Details(context.TODO(), typ, "test")
+
+				Expect(err).Should(HaveOccurred())
+			})
+		}
+	})
+
+	When("Calling details for a topic", func() {
+		provider := &azProviderImpl{
+			cache: azResourceCache{
+				AzResource_Topic: {
+					"test": AzGenericResource{
+						ID:   "/subscriptions/test-sub/resourceGroups/test-rg/providers/Microsoft.EventGrid/topics/test-abc123",
+						Name: "test-abc123",
+					},
+				},
+			},
+		}
+
+		It("should return the event grid topic", func() {
+			details, err := provider.Details(context.TODO(), common.ResourceType_Topic, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("/subscriptions/test-sub/resourceGroups/test-rg/providers/Microsoft.EventGrid/topics/test-abc123"))
+			Expect(details.Detail).To(Equal(common.TopicDetails{
+				Name: "test-abc123",
+			}))
+		})
+
+		It("should return an error when the topic does not exist", func() {
+			_, err := provider.Details(context.TODO(), common.ResourceType_Topic, "missing")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	When("Calling details for an unsupported resource type", func() {
+		provider := &azProviderImpl{}
+
+		It("should return an error", func() {
+			_, err := provider.Details(context.TODO(), "unknown", "test")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -28,6 +28,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/span"
 )
 
 type EventGridEventService struct {
@@ -57,13 +58,34 @@ func (s *EventGridEventService) ListTopics(ctx context.Context) ([]string, error
 	return topicsList, nil
 }
 
-func (s *EventGridEventService) nitricEventsToAzureEvents(topic string, events []*events.NitricEvent) ([]eventgrid.Event, error) {
+// TracedDataVersion - the data version of events with their data wrapped in a TracedEventData envelope
+const TracedDataVersion = "1.0-traced"
+
+// TracedEventData - Event Grid events have no attributes, so the W3C trace context is carried alongside the event payload
+type TracedEventData struct {
+	Payload      map[string]interface{} `json:"payload"`
+	TraceContext map[string]string      `json:"traceContext"`
+}
+
+func (s *EventGridEventService) nitricEventsToAzureEvents(ctx context.Context, topic string, events []*events.NitricEvent) ([]eventgrid.Event, error) {
+	traceContext := span.TraceContextAttributes(span.ToAttributes(ctx))
+
 	var azureEvents []eventgrid.Event
 	for _, event := range events {
 		dataVersion := "1.0"
+		var data interface{} = event.Payload
+
+		if traceContext != nil {
+			dataVersion = TracedDataVersion
+			data = TracedEventData{
+				Payload:      event.Payload,
+				TraceContext: traceContext,
+			}
+		}
+
 		azureEvents = append(azureEvents, eventgrid.Event{
 			ID:          &event.ID,
-			Data:        event.Payload,
+			Data:        data,
 			EventType:   &event.PayloadType,
 			Subject:     &topic,
 			EventTime:   &date.Time{Time: time.Now()},
@@ -107,7 +129,7 @@ func (s *EventGridEventService) Publish(ctx context.Context, topic string, delay
 	// TODO: Determine correctness of availability zone in endpoint hostname
 	topicHostName := fmt.Sprintf("%s.%s-1.eventgrid.azure.net", t.Name, t.Location)
 
-	eventToPublish, err := s.nitricEventsToAzureEvents(topicHostName, []*events.NitricEvent{event})
+	eventToPublish, err := s.nitricEventsToAzureEvents(ctx, topicHostName, []*events.NitricEvent{event})
 	if err != nil {
 		return newErr(
 			codes.Internal,
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/Azure/azure-sdk-for-go/services/eventgrid/2018-01-01/eventgrid"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
 
 	mock_eventgrid "github.com/nitrictech/nitric/cloud/azure/mocks/mock_event_grid"
 	mock_provider "github.com/nitrictech/nitric/cloud/azure/mocks/provider"
@@ -162,5 +164,49 @@ var _ = Describe("Event Grid Plugin", func() {
 				ctrl.Finish()
 			})
 		})
+
+		When("With a trace context", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			eventgridClient := mock_eventgrid.NewMockBaseClientAPI(ctrl)
+			mockProvider := mock_provider.NewMockAzProvider(ctrl)
+			eventgridPlugin, _ := eventgrid_service.NewWithClient(mockProvider, eventgridClient)
+
+			traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+			spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+			ctx := trace.ContextWithSpanContext(context.TODO(), trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			}))
+
+			It("should carry the trace context with the event data", func() {
+				hostName := fmt.Sprintf("%s.%s-1.eventgrid.azure.net", getTopicResourcesResponse["Test"].Name, getTopicResourcesResponse["Test"].Location)
+
+				mockProvider.EXPECT().GetResources(gomock.Any(), core.AzResource_Topic).Return(getTopicResourcesResponse, nil)
+
+				var published []eventgrid.Event
+				eventgridClient.EXPECT().PublishEvents(gomock.Any(), hostName, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, evts []eventgrid.Event) (autorest.Response, error) {
+						published = evts
+						return autorest.Response{Response: &http.Response{StatusCode: 202}}, nil
+					}).Times(1)
+
+				err := eventgridPlugin.Publish(ctx, "Test", 0, event)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("leaving the subject unchanged")
+				Expect(published).To(HaveLen(1))
+				Expect(*published[0].Subject).To(Equal(hostName))
+
+				By("wrapping the payload with the trace context")
+				Expect(*published[0].DataVersion).To(Equal(eventgrid_service.TracedDataVersion))
+				Expect(published[0].Data).To(Equal(eventgrid_service.TracedEventData{
+					Payload:      event.Payload,
+					TraceContext: map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+				}))
+
+				ctrl.Finish()
+			})
+		})
 	})
 })
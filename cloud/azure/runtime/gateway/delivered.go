@@ -0,0 +1,68 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http_service
+
+import (
+	"container/list"
+	"sync"
+)
+
+// The least recently handled events are forgotten once this many have been handled
+const maxDeliveredEvents = 10000
+
+// deliveredEvents - the keys of events that were handled successfully, so they're skipped when their batch is redelivered.
+// Events are only remembered by the instance that handled them, redeliveries to another instance handle them again.
+type deliveredEvents struct {
+	lock   sync.Mutex
+	events map[string]*list.Element
+	// Event keys ordered from most to least recently handled
+	lru *list.List
+}
+
+func newDeliveredEvents() *deliveredEvents {
+	return &deliveredEvents{
+		events: make(map[string]*list.Element),
+		lru:    list.New(),
+	}
+}
+
+// contains - returns true if the event with the given key has already been handled
+func (d *deliveredEvents) contains(key string) bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	_, ok := d.events[key]
+
+	return ok
+}
+
+// add - records the event with the given key as handled
+func (d *deliveredEvents) add(key string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if e, ok := d.events[key]; ok {
+		d.lru.MoveToFront(e)
+		return
+	}
+
+	if d.lru.Len() >= maxDeliveredEvents {
+		oldest := d.lru.Back()
+		d.lru.Remove(oldest)
+		delete(d.events, oldest.Value.(string))
+	}
+
+	d.events[key] = d.lru.PushFront(key)
+}
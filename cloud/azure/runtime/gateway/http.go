@@ -17,6 +17,8 @@ package http_service
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"strings"
 
@@ -25,14 +27,17 @@ import (
 	"github.com/valyala/fasthttp"
 
 	"github.com/nitrictech/nitric/cloud/azure/runtime/core"
+	eventgrid_service "github.com/nitrictech/nitric/cloud/azure/runtime/events"
 	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
 
 type azMiddleware struct {
-	provider core.AzProvider
+	provider  core.AzProvider
+	delivered *deliveredEvents
 }
 
 func (a *azMiddleware) handleSubscriptionValidation(ctx *fasthttp.RequestCtx, events []eventgrid.Event) {
@@ -51,70 +56,187 @@ func (a *azMiddleware) handleSubscriptionValidation(ctx *fasthttp.RequestCtx, ev
 	ctx.Success("application/json", responseBody)
 }
 
-func (a *azMiddleware) handleNotifications(ctx *fasthttp.RequestCtx, events []eventgrid.Event, pool worker.WorkerPool) {
-	// TODO: As we are batch handling events
-	// how do we notify of failed event handling?
-	for _, event := range events {
-		// XXX: Assume we have a nitric event for now
-		// We have a valid nitric event
-		// Decode and pass to our function
-		var payloadBytes []byte
-		if stringData, ok := event.Data.(string); ok {
-			payloadBytes = []byte(stringData)
-		} else if byteData, ok := event.Data.([]byte); ok {
-			payloadBytes = byteData
-		} else {
-			// Assume a json serializable struct for now...
-			payloadBytes, _ = json.Marshal(event.Data)
-		}
+// unwrapEventData - returns the payload of an event and the W3C trace context of events published by the membrane
+func unwrapEventData(event eventgrid.Event) (interface{}, map[string]string) {
+	if stringValue(event.DataVersion) != eventgrid_service.TracedDataVersion {
+		return event.Data, map[string]string{}
+	}
 
-		var evt *triggers.Event
-		topics, err := a.provider.GetResources(context.TODO(), core.AzResource_Topic)
-		if err != nil {
-			log.Default().Println("could not get topic resources")
-			continue
-		}
+	dataBytes, err := json.Marshal(event.Data)
+	if err != nil {
+		return event.Data, map[string]string{}
+	}
+
+	traced := eventgrid_service.TracedEventData{}
+	if err := json.Unmarshal(dataBytes, &traced); err != nil {
+		return event.Data, map[string]string{}
+	}
+
+	attrs := span.TraceContextAttributes(traced.TraceContext)
+	if attrs == nil {
+		attrs = map[string]string{}
+	}
+
+	return traced.Payload, attrs
+}
+
+// eventResult - the outcome of handling a single event in a batch
+type eventResult struct {
+	ID     string `json:"id"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// handleNotification - handles a single event, returning a status code describing the outcome
+func (a *azMiddleware) handleNotification(event eventgrid.Event, pool worker.WorkerPool) (int, error) {
+	// XXX: Assume we have a nitric event for now
+	// We have a valid nitric event
+	// Decode and pass to our function
+	data, traceContext := unwrapEventData(event)
+
+	var payloadBytes []byte
+	if stringData, ok := data.(string); ok {
+		payloadBytes = []byte(stringData)
+	} else if byteData, ok := data.([]byte); ok {
+		payloadBytes = byteData
+	} else {
+		// Assume a json serializable struct for now...
+		payloadBytes, _ = json.Marshal(data)
+	}
 
-		topicName := ""
-		for name, t := range topics {
-			if strings.HasSuffix(*event.Topic, t.Name) {
-				topicName = name
-			}
+	topics, err := a.provider.GetResources(context.TODO(), core.AzResource_Topic)
+	if err != nil {
+		return 503, fmt.Errorf("could not get topic resources: %w", err)
+	}
+
+	topicName := ""
+	for name, t := range topics {
+		if event.Topic != nil && strings.HasSuffix(*event.Topic, t.Name) {
+			topicName = name
 		}
+	}
+
+	if topicName == "" {
+		// Redelivery won't help, so the event is dead-lettered unless it shares its batch with other events
+		return 400, fmt.Errorf("could not resolve nitric name for topic %s", stringValue(event.Topic))
+	}
+
+	// Just extract the payload from the event type (payload from nitric event is directly mapped)
+	evt := &triggers.Event{
+		ID:         stringValue(event.ID),
+		Topic:      topicName,
+		Payload:    payloadBytes,
+		Attributes: traceContext,
+	}
+
+	if event.EventTime != nil {
+		evt.Time = event.EventTime.Time
+	}
+
+	wrkr, err := pool.GetWorker(&worker.GetWorkerOptions{
+		Event: evt,
+	})
+	if errors.Is(err, worker.ErrSaturated) {
+		return 503, err
+	} else if err != nil {
+		return 404, fmt.Errorf("could not get worker for topic %s: %w", topicName, err)
+	}
 
-		if topicName == "" {
-			log.Default().Println("could not resolve nitric name for topic", *event.Topic)
+	err = wrkr.HandleEvent(span.FromAttributes(context.TODO(), evt.Attributes), evt)
+	if errors.Is(err, worker.ErrSaturated) {
+		return 503, err
+	} else if err != nil {
+		return 500, err
+	}
+
+	return 200, nil
+}
+
+// deliveryKey - returns the key identifying an event across deliveries, event IDs are unique within a topic
+func deliveryKey(event eventgrid.Event) string {
+	if event.ID == nil || *event.ID == "" {
+		return ""
+	}
+
+	return stringValue(event.Topic) + "/" + *event.ID
+}
+
+// handleNotifications - handles a batch of events, responding with the status of the most significant failure.
+// Event Grid applies the status to every event in the batch, so failed batches of several events are always retried rather than dead-lettered.
+// Events in a retried batch that were already handled successfully are skipped rather than delivered again.
+func (a *azMiddleware) handleNotifications(ctx *fasthttp.RequestCtx, events []eventgrid.Event, pool worker.WorkerPool) {
+	results := make([]eventResult, 0, len(events))
+	status := 200
+
+	for _, event := range events {
+		id := stringValue(event.ID)
+		key := deliveryKey(event)
+
+		if key != "" && a.delivered.contains(key) {
+			results = append(results, eventResult{ID: id, Status: 200})
 			continue
 		}
 
-		// Just extract the payload from the event type (payload from nitric event is directly mapped)
-		evt = &triggers.Event{
-			ID:         *event.ID,
-			Topic:      topicName,
-			Payload:    payloadBytes,
-			Attributes: map[string]string{},
+		eventStatus, err := a.handleNotification(event, pool)
+		if eventStatus == 200 && key != "" {
+			a.delivered.add(key)
 		}
 
-		wrkr, err := pool.GetWorker(&worker.GetWorkerOptions{
-			Event: evt,
-		})
+		result := eventResult{ID: id, Status: eventStatus}
 		if err != nil {
-			log.Default().Println("could not get worker for topic: ", topicName)
-			// TODO: Handle error
-			continue
+			log.Default().Printf("could not handle event %s: %v", id, err)
+			result.Error = err.Error()
 		}
+		results = append(results, result)
 
-		err = wrkr.HandleEvent(context.TODO(), evt)
-		if err != nil {
-			log.Default().Println("could not handle event: ", evt)
-			// TODO: Handle error
-			continue
-		}
+		status = batchStatus(status, eventStatus)
+	}
+
+	// Rejecting the batch would dead-letter the other events in it along with the failed event
+	if len(events) > 1 && statusRank(status) == 1 {
+		status = 500
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"results": results,
+	})
+
+	ctx.SetStatusCode(status)
+	ctx.SetContentType("application/json")
+	ctx.SetBody(body)
+}
+
+// statusRank - orders event statuses by precedence when responding to a batch,
+// retryable failures take precedence so failed events are redelivered
+func statusRank(status int) int {
+	switch {
+	case status == 200:
+		return 0
+	case status == 503:
+		// Event Grid delays redelivery the longest while workers are at capacity
+		return 3
+	case status >= 500 || status == 404:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// batchStatus - returns the status of a batch given the status of one of its events
+func batchStatus(current int, event int) int {
+	if statusRank(event) > statusRank(current) {
+		return event
+	}
+
+	return current
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
 	}
 
-	// Return 200 OK (TODO: Determine how we could mark individual events for failure)
-	// Or potentially requeue them here internally...
-	ctx.SuccessString("text/plain", "success")
+	return *s
 }
 
 func (a *azMiddleware) middleware(ctx *fasthttp.RequestCtx, pool worker.WorkerPool) bool {
@@ -148,7 +270,8 @@ func (a *azMiddleware) middleware(ctx *fasthttp.RequestCtx, pool worker.WorkerPo
 // Create a new HTTP Gateway plugin
 func New(provider core.AzProvider) (gateway.GatewayService, error) {
 	mw := &azMiddleware{
-		provider: provider,
+		provider:  provider,
+		delivered: newDeliveredEvents(),
 	}
 
 	return base_http.New(mw.middleware)
@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/eventgrid/eventgrid"
@@ -31,6 +32,7 @@ import (
 
 	mock_provider "github.com/nitrictech/nitric/cloud/azure/mocks/provider"
 	"github.com/nitrictech/nitric/cloud/azure/runtime/core"
+	eventgrid_service "github.com/nitrictech/nitric/cloud/azure/runtime/events"
 	http_service "github.com/nitrictech/nitric/cloud/azure/runtime/gateway"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
@@ -54,6 +56,12 @@ var _ = Describe("Http", func() {
 			Body:       []byte("Testing Response"),
 			StatusCode: 200,
 		},
+		EventError: func(trigger *triggers.Event) error {
+			if strings.HasPrefix(trigger.ID, "fail") {
+				return fmt.Errorf("mock error")
+			}
+			return nil
+		},
 	})
 	err := pool.AddWorker(mockHandler)
 	Expect(err).To(BeNil())
@@ -181,5 +189,172 @@ var _ = Describe("Http", func() {
 				Expect(event.Payload).To(BeEquivalentTo(payloadBytes))
 			})
 		})
+
+		When("With a Notification event carrying a trace context", func() {
+			It("Should unwrap the payload and restore the trace context", func() {
+				payload := map[string]interface{}{
+					"testing": "test",
+				}
+				payloadBytes, _ := json.Marshal(payload)
+				testTopic := "test"
+				testID := "traced"
+				dataVersion := eventgrid_service.TracedDataVersion
+				traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+				evt := []eventgrid.Event{
+					{
+						ID:          &testID,
+						Topic:       &testTopic,
+						DataVersion: &dataVersion,
+						Data: eventgrid_service.TracedEventData{
+							Payload:      payload,
+							TraceContext: map[string]string{"traceparent": traceparent},
+						},
+					},
+				}
+
+				requestBody, err := json.Marshal(evt)
+				Expect(err).To(BeNil())
+				request, err := http.NewRequest("POST", gatewayUrl, bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+				_, _ = http.DefaultClient.Do(request)
+
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(1))
+
+				event := mockHandler.ReceivedEvents[0]
+				By("Having the unwrapped payload")
+				Expect(event.Payload).To(BeEquivalentTo(payloadBytes))
+
+				By("Having the trace context as attributes")
+				Expect(event.Attributes).To(HaveKeyWithValue("traceparent", traceparent))
+			})
+		})
+
+		When("With a Notification batch containing a failed event", func() {
+			topic := "test"
+			okID := "batch-ok"
+			failID := "fail-batch"
+			evts := []eventgrid.Event{
+				{ID: &okID, Topic: &topic, Data: map[string]string{"testing": "ok"}},
+				{ID: &failID, Topic: &topic, Data: map[string]string{"testing": "fail"}},
+			}
+
+			send := func() (*http.Response, map[string][]map[string]interface{}) {
+				requestBody, err := json.Marshal(evts)
+				Expect(err).To(BeNil())
+				request, err := http.NewRequest("POST", gatewayUrl, bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+
+				resp, err := http.DefaultClient.Do(request)
+				Expect(err).To(BeNil())
+				defer resp.Body.Close()
+
+				body := map[string][]map[string]interface{}{}
+				Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+
+				return resp, body
+			}
+
+			It("Should report the failure so the batch is redelivered", func() {
+				resp, body := send()
+
+				By("Returning a retryable status")
+				Expect(resp.StatusCode).To(Equal(500))
+
+				By("Returning the status of each event")
+				Expect(body["results"]).To(HaveLen(2))
+				Expect(body["results"][0]["status"]).To(BeEquivalentTo(200))
+				Expect(body["results"][1]["status"]).To(BeEquivalentTo(500))
+
+				By("Handling every event in the batch")
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(2))
+			})
+		})
+
+		When("With a redelivered Notification batch", func() {
+			It("Should only handle the events that failed", func() {
+				topic := "test"
+				okID := "redelivered-ok"
+				failID := "fail-redelivered"
+				requestBody, err := json.Marshal([]eventgrid.Event{
+					{ID: &okID, Topic: &topic, Data: map[string]string{"testing": "ok"}},
+					{ID: &failID, Topic: &topic, Data: map[string]string{"testing": "fail"}},
+				})
+				Expect(err).To(BeNil())
+
+				send := func() *http.Response {
+					request, err := http.NewRequest("POST", gatewayUrl, bytes.NewReader(requestBody))
+					Expect(err).To(BeNil())
+					request.Header.Add("aeg-event-type", "Notification")
+
+					resp, err := http.DefaultClient.Do(request)
+					Expect(err).To(BeNil())
+					resp.Body.Close()
+
+					return resp
+				}
+
+				By("Handling every event in the first delivery")
+				Expect(send().StatusCode).To(Equal(500))
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(2))
+
+				mockHandler.Reset()
+
+				By("Skipping the event that succeeded when the batch is redelivered")
+				Expect(send().StatusCode).To(Equal(500))
+				Expect(mockHandler.ReceivedEvents).To(HaveLen(1))
+				Expect(mockHandler.ReceivedEvents[0].ID).To(Equal(failID))
+			})
+		})
+
+		When("With a Notification batch containing an event for an unknown topic", func() {
+			It("Should retry the batch rather than dead-letter the other events", func() {
+				topic := "test"
+				unknown := "unknown"
+				okID := "unknown-batch-ok"
+				unknownID := "unknown-batch"
+				requestBody, err := json.Marshal([]eventgrid.Event{
+					{ID: &okID, Topic: &topic, Data: map[string]string{"testing": "ok"}},
+					{ID: &unknownID, Topic: &unknown, Data: "test"},
+				})
+				Expect(err).To(BeNil())
+				request, err := http.NewRequest("POST", gatewayUrl, bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+
+				resp, err := http.DefaultClient.Do(request)
+				Expect(err).To(BeNil())
+				defer resp.Body.Close()
+
+				body := map[string][]map[string]interface{}{}
+				Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+
+				By("Returning a retryable status")
+				Expect(resp.StatusCode).To(Equal(500))
+
+				By("Returning the status of each event")
+				Expect(body["results"]).To(HaveLen(2))
+				Expect(body["results"][0]["status"]).To(BeEquivalentTo(200))
+				Expect(body["results"][1]["status"]).To(BeEquivalentTo(400))
+			})
+		})
+
+		When("With a Notification event for an unknown topic", func() {
+			It("Should reject the event so it is dead-lettered", func() {
+				topic := "unknown"
+				id := "unknown-topic"
+				requestBody, err := json.Marshal([]eventgrid.Event{{ID: &id, Topic: &topic, Data: "test"}})
+				Expect(err).To(BeNil())
+				request, err := http.NewRequest("POST", gatewayUrl, bytes.NewReader(requestBody))
+				Expect(err).To(BeNil())
+				request.Header.Add("aeg-event-type", "Notification")
+
+				resp, err := http.DefaultClient.Do(request)
+				Expect(err).To(BeNil())
+				Expect(resp.StatusCode).To(Equal(400))
+				Expect(mockHandler.ReceivedEvents).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -31,6 +31,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -66,6 +67,9 @@ func (s *AzqueueQueueService) Send(ctx context.Context, queue string, task queue
 
 	messages := s.getMessagesUrl(queue)
 
+	// Azure Storage Queue messages have no attributes, so the trace context is carried in the message itself
+	task.TraceContext = span.TraceContextAttributes(span.ToAttributes(ctx))
+
 	// Send the tasks to the queue
 	if taskBytes, err := json.Marshal(task); err == nil {
 		if _, err := messages.Enqueue(ctx, string(taskBytes), 0, 0); err != nil {
@@ -190,10 +194,11 @@ func (s *AzqueueQueueService) Receive(ctx context.Context, options queue.Receive
 		}
 
 		tasks = append(tasks, queue.NitricTask{
-			ID:          nitricTask.ID,
-			Payload:     nitricTask.Payload,
-			PayloadType: nitricTask.PayloadType,
-			LeaseID:     leaseID,
+			ID:           nitricTask.ID,
+			Payload:      nitricTask.Payload,
+			PayloadType:  nitricTask.PayloadType,
+			LeaseID:      leaseID,
+			TraceContext: nitricTask.TraceContext,
 		})
 	}
 
@@ -31,13 +31,28 @@ import (
 	"github.com/nitrictech/pulumi-docker-buildkit/sdk/v0.1.21/dockerbuildkit"
 )
 
+// DefaultPlatform - the platform images are wrapped for when no platforms are provided
+const DefaultPlatform = "linux/amd64"
+
 type ImageArgs struct {
-	SourceImage   string
-	Runtime       []byte
+	SourceImage string
+	// SourceImageInput - a source image only known during deployment (e.g. built from source), requires Daemonless
+	SourceImageInput pulumi.StringInput
+	// Runtime binaries for each target platform, keyed by platform (e.g. linux/amd64)
+	Runtimes map[string][]byte
+	// Platforms the image is wrapped for, a multi-arch manifest list is produced when more than one is provided
+	Platforms     []string
 	RepositoryUrl pulumi.StringInput
 	Server        pulumi.StringInput
 	Username      pulumi.StringInput
 	Password      pulumi.StringInput
+	// SourceUsername and SourcePassword - credentials for the registry of a Daemonless source image,
+	// the local docker config is used when they aren't provided
+	SourceUsername pulumi.StringInput
+	SourcePassword pulumi.StringInput
+	// Daemonless - wrap the image without a docker daemon,
+	// the SourceImage must be available in a registry rather than locally
+	Daemonless bool
 }
 
 type Image struct {
@@ -45,6 +60,33 @@ type Image struct {
 
 	Name        string
 	DockerImage *dockerbuildkit.Image
+	uri         pulumi.StringOutput
+}
+
+// platforms - returns the target platforms, ensuring a runtime is available for each of them
+func (a *ImageArgs) platforms() ([]string, error) {
+	platforms := a.Platforms
+	if len(platforms) == 0 {
+		platforms = []string{DefaultPlatform}
+	}
+
+	for _, platform := range platforms {
+		if _, ok := a.Runtimes[platform]; !ok {
+			return nil, fmt.Errorf("no runtime available for platform: %s", platform)
+		}
+	}
+
+	return platforms, nil
+}
+
+// platformArch - returns the architecture of a platform, matching the TARGETARCH docker build arg
+func platformArch(platform string) (string, error) {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 || parts[1] == "" {
+		return "", fmt.Errorf("invalid platform: %s", platform)
+	}
+
+	return parts[1], nil
 }
 
 //go:embed wrapper.dockerfile
@@ -79,6 +121,17 @@ func NewImage(ctx *pulumi.Context, name string, args *ImageArgs, opts ...pulumi.
 		return nil, err
 	}
 
+	platforms, err := args.platforms()
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Daemonless {
+		return newRemoteImage(ctx, res, args, platforms)
+	} else if args.SourceImageInput != nil {
+		return nil, fmt.Errorf("source images only known during deployment can only be wrapped without a docker daemon")
+	}
+
 	// TODO: Need to re-add support for telemetry wrappers as well
 	dockerfileContent, err := wrapDockerImage(imageWrapper, args.SourceImage)
 	if err != nil {
@@ -94,17 +147,26 @@ func NewImage(ctx *pulumi.Context, name string, args *ImageArgs, opts ...pulumi.
 	dockerfile.Write([]byte(dockerfileContent))
 	dockerfile.Close()
 
-	runtimefile, err := os.Create(path.Join(buildContext, "runtime"))
-	if err != nil {
-		return nil, err
+	// Each platform copies its own runtime into the image, e.g. runtime-amd64
+	for _, platform := range platforms {
+		arch, err := platformArch(platform)
+		if err != nil {
+			return nil, err
+		}
+
+		runtimefile, err := os.Create(path.Join(buildContext, "runtime-"+arch))
+		if err != nil {
+			return nil, err
+		}
+		runtimefile.Write(args.Runtimes[platform])
+		runtimefile.Close()
 	}
-	runtimefile.Write(args.Runtime)
-	runtimefile.Close()
 
 	imageArgs := &dockerbuildkit.ImageArgs{
 		Name:       args.RepositoryUrl,
 		Context:    pulumi.String(buildContext),
 		Dockerfile: pulumi.String("Dockerfile"),
+		Platforms:  pulumi.ToStringArray(platforms),
 		Args: dockerbuildkit.BuildArgArray{
 			dockerbuildkit.BuildArgArgs{
 				Name:  pulumi.String("BASE_IMAGE"),
@@ -127,12 +189,66 @@ func NewImage(ctx *pulumi.Context, name string, args *ImageArgs, opts ...pulumi.
 		return nil, err
 	}
 
+	res.uri = res.DockerImage.RepoDigest
+
 	return res, ctx.RegisterResourceOutputs(res, pulumi.Map{
 		"name":     pulumi.String(res.Name),
 		"imageUri": res.DockerImage.Name,
 	})
 }
 
+// newRemoteImage - wraps the source image directly between registries
+func newRemoteImage(ctx *pulumi.Context, res *Image, args *ImageArgs, platforms []string) (*Image, error) {
+	username, password := args.Username, args.Password
+	if username == nil {
+		username = pulumi.String("")
+	}
+	if password == nil {
+		password = pulumi.String("")
+	}
+
+	sourceUsername, sourcePassword := args.SourceUsername, args.SourcePassword
+	if sourceUsername == nil {
+		sourceUsername = pulumi.String("")
+	}
+	if sourcePassword == nil {
+		sourcePassword = pulumi.String("")
+	}
+
+	sourceImage := args.SourceImageInput
+	if sourceImage == nil {
+		sourceImage = pulumi.String(args.SourceImage)
+	}
+
+	// The wrapped image is tagged with a key derived from the source image digest and runtimes,
+	// so deployments only push it, and services only roll out a new revision, when one of them changes
+	res.uri = pulumi.All(args.RepositoryUrl, username, password, sourceImage, sourceUsername, sourcePassword).ApplyT(func(all []interface{}) (string, error) {
+		wrapped, err := wrapRemoteImage(ctx.Context(), &remoteImageArgs{
+			SourceImage:    all[3].(string),
+			SourceUsername: all[4].(string),
+			SourcePassword: all[5].(string),
+			TargetImage:    all[0].(string),
+			Platforms:      platforms,
+			Runtimes:       args.Runtimes,
+		})
+		if err != nil {
+			return "", err
+		}
+
+		// Previews report the digest the image will be pushed with, without pushing it
+		if ctx.DryRun() {
+			return wrapped.reference()
+		}
+
+		return pushRemoteImage(ctx.Context(), wrapped, all[1].(string), all[2].(string))
+	}).(pulumi.StringOutput)
+
+	return res, ctx.RegisterResourceOutputs(res, pulumi.Map{
+		"name":     pulumi.String(res.Name),
+		"imageUri": res.uri,
+	})
+}
+
 func (d *Image) URI() pulumi.StringOutput {
-	return d.DockerImage.RepoDigest
+	return d.uri
 }
@@ -0,0 +1,291 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+)
+
+const runtimePath = "/bin/runtime"
+
+type remoteImageArgs struct {
+	// SourceImage - pulled using the source credentials when provided, otherwise the local docker config
+	SourceImage string
+	// SourceUsername and SourcePassword - credentials for the source image registry
+	SourceUsername string
+	SourcePassword string
+	TargetImage    string
+	Platforms      []string
+	Runtimes       map[string][]byte
+}
+
+// sourceAuth - returns the option used to authenticate with the source image registry
+func (a *remoteImageArgs) sourceAuth() remote.Option {
+	if a.SourceUsername == "" && a.SourcePassword == "" {
+		return remote.WithAuthFromKeychain(authn.DefaultKeychain)
+	}
+
+	return remote.WithAuth(authn.FromConfig(authn.AuthConfig{
+		Username: a.SourceUsername,
+		Password: a.SourcePassword,
+	}))
+}
+
+// runtimeLayer - creates an image layer containing the runtime binary
+func runtimeLayer(runtime []byte) (v1.Layer, error) {
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     runtimePath[1:],
+		Typeflag: tar.TypeReg,
+		Mode:     0o755,
+		Size:     int64(len(runtime)),
+	}); err != nil {
+		return nil, err
+	}
+
+	if _, err := tw.Write(runtime); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	layerBytes := buf.Bytes()
+
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(layerBytes)), nil
+	})
+}
+
+// wrapPlatformImage - wraps the source image for a single platform with the runtime,
+// the runtime is appended as a new layer and becomes the entrypoint, with the original command passed to it
+func wrapPlatformImage(ctx context.Context, srcRef name.Reference, auth remote.Option, platform *v1.Platform, runtime []byte) (v1.Image, error) {
+	src, err := remote.Image(srcRef, remote.WithContext(ctx), auth, remote.WithPlatform(*platform))
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not pull image: %s", srcRef))
+	}
+
+	srcCfg, err := src.ConfigFile()
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read config for image: %s", srcRef))
+	}
+
+	// single platform images are returned regardless of the requested platform
+	if srcCfg.OS != platform.OS || srcCfg.Architecture != platform.Architecture {
+		return nil, fmt.Errorf("image %s is not available for platform %s", srcRef, platform)
+	}
+
+	layer, err := runtimeLayer(runtime)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create runtime layer")
+	}
+
+	img, err := mutate.AppendLayers(src, layer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inject the original command of the source image into the wrapper
+	cfg := srcCfg.Config.DeepCopy()
+	cfg.Cmd = append(cfg.Entrypoint, cfg.Cmd...)
+	cfg.Entrypoint = []string{runtimePath}
+
+	return mutate.Config(img, *cfg)
+}
+
+// wrappedImage - a source image wrapped with the runtime, ready to be pushed to the target repository
+type wrappedImage struct {
+	// key - identifies the source image digest and runtimes the image was wrapped from
+	key    string
+	target name.Repository
+	// image or index is set, depending on whether the image was wrapped for one platform or several
+	image v1.Image
+	index v1.ImageIndex
+}
+
+// digest - returns the digest of the wrapped image, which only changes when its key changes
+func (w *wrappedImage) digest() (v1.Hash, error) {
+	if w.index != nil {
+		return w.index.Digest()
+	}
+
+	return w.image.Digest()
+}
+
+// reference - returns the reference to the wrapped image in the target repository by its digest
+func (w *wrappedImage) reference() (string, error) {
+	digest, err := w.digest()
+	if err != nil {
+		return "", err
+	}
+
+	return w.target.Digest(digest.String()).String(), nil
+}
+
+// imageKey - returns a key identifying the wrapped image's inputs, the source image digest and the runtime for each platform
+func imageKey(sourceDigest v1.Hash, platforms []string, runtimes map[string][]byte) string {
+	h := sha256.New()
+	h.Write([]byte(sourceDigest.String()))
+
+	for _, p := range platforms {
+		runtimeHash := sha256.Sum256(runtimes[p])
+		fmt.Fprintf(h, "\n%s=%x", p, runtimeHash)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// wrapRemoteImage - wraps an image in a registry with the runtime without a docker daemon or pushing it,
+// a manifest list is produced when wrapping for more than one platform.
+// Only the source manifests and configs are pulled, so the digest of the wrapped image is known before it is pushed.
+func wrapRemoteImage(ctx context.Context, args *remoteImageArgs) (*wrappedImage, error) {
+	if args.SourceImage == "" {
+		return nil, fmt.Errorf("blank sourceImage provided")
+	}
+
+	srcRef, err := name.ParseReference(args.SourceImage)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid source image: %s", args.SourceImage))
+	}
+
+	targetRef, err := name.ParseReference(args.TargetImage)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid target image: %s", args.TargetImage))
+	}
+
+	srcAuth := args.sourceAuth()
+
+	srcDesc, err := remote.Head(srcRef, remote.WithContext(ctx), srcAuth)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not resolve image: %s", args.SourceImage))
+	}
+
+	// Pin the source to the digest the key is derived from
+	srcDigest := srcRef.Context().Digest(srcDesc.Digest.String())
+
+	wrapped := &wrappedImage{
+		key:    imageKey(srcDesc.Digest, args.Platforms, args.Runtimes),
+		target: targetRef.Context(),
+	}
+
+	var idx v1.ImageIndex = empty.Index
+
+	for _, p := range args.Platforms {
+		platform, err := v1.ParsePlatform(p)
+		if err != nil {
+			return nil, err
+		}
+
+		img, err := wrapPlatformImage(ctx, srcDigest, srcAuth, platform, args.Runtimes[p])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(args.Platforms) == 1 {
+			wrapped.image = img
+			return wrapped, nil
+		}
+
+		idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+			Add: img,
+			Descriptor: v1.Descriptor{
+				Platform: platform,
+			},
+		})
+	}
+
+	wrapped.index = idx
+
+	return wrapped, nil
+}
+
+// pushRemoteImage - pushes a wrapped image to its target repository, tagged with its key,
+// the push is skipped when an image with the same key has already been pushed.
+// The username and password are credentials for the target registry.
+// returns the digest reference of the pushed image
+func pushRemoteImage(ctx context.Context, wrapped *wrappedImage, username string, password string) (string, error) {
+	auth := authn.FromConfig(authn.AuthConfig{
+		Username: username,
+		Password: password,
+	})
+
+	tag := wrapped.target.Tag(wrapped.key)
+
+	digest, err := wrapped.digest()
+	if err != nil {
+		return "", err
+	}
+
+	if existing, err := remote.Head(tag, remote.WithContext(ctx), remote.WithAuth(auth)); err == nil && existing.Digest == digest {
+		return wrapped.reference()
+	}
+
+	if wrapped.index != nil {
+		if err := remote.WriteIndex(tag, wrapped.index, remote.WithContext(ctx), remote.WithAuth(auth)); err != nil {
+			return "", errors.WithMessage(err, fmt.Sprintf("could not push image index: %s", tag))
+		}
+	} else {
+		if err := remote.Write(tag, wrapped.image, remote.WithContext(ctx), remote.WithAuth(auth)); err != nil {
+			return "", errors.WithMessage(err, fmt.Sprintf("could not push image: %s", tag))
+		}
+	}
+
+	return wrapped.reference()
+}
+
+// ExistingImage - returns the digest reference of an image in a registry, or an empty string if it doesn't exist
+func ExistingImage(ctx context.Context, image string, username string, password string) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("invalid image: %s", image))
+	}
+
+	auth := authn.FromConfig(authn.AuthConfig{
+		Username: username,
+		Password: password,
+	})
+
+	desc, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuth(auth))
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+
+		return "", errors.WithMessage(err, fmt.Sprintf("could not resolve image: %s", image))
+	}
+
+	return ref.Context().Digest(desc.Digest.String()).String(), nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// platformImage - creates a random image for the given platform, running the given command
+func platformImage(platform string) v1.Image {
+	img, err := random.Image(256, 1)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	p, err := v1.ParsePlatform(platform)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	cfg, err := img.ConfigFile()
+	Expect(err).ShouldNot(HaveOccurred())
+	cfg = cfg.DeepCopy()
+	cfg.OS = p.OS
+	cfg.Architecture = p.Architecture
+	cfg.Config.Entrypoint = []string{"node"}
+	cfg.Config.Cmd = []string{"index.js"}
+
+	img, err = mutate.ConfigFile(img, cfg)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return img
+}
+
+// runtimeFile - reads the runtime from the final layer of a wrapped image
+func runtimeFile(img v1.Image) string {
+	layers, err := img.Layers()
+	Expect(err).ShouldNot(HaveOccurred())
+
+	rc, err := layers[len(layers)-1].Uncompressed()
+	Expect(err).ShouldNot(HaveOccurred())
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	hdr, err := tr.Next()
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(hdr.Name).To(Equal("bin/runtime"))
+
+	contents, err := io.ReadAll(tr)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return string(contents)
+}
+
+var _ = Describe("Remote Image", func() {
+	var srv *httptest.Server
+	var host string
+	var manifestPushes int
+	var lock sync.Mutex
+
+	BeforeEach(func() {
+		manifestPushes = 0
+		reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/manifests/") {
+				lock.Lock()
+				manifestPushes++
+				lock.Unlock()
+			}
+			reg.ServeHTTP(w, r)
+		}))
+		host = strings.TrimPrefix(srv.URL, "http://")
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	push := func(ref string, img v1.Image) {
+		r, err := name.ParseReference(ref)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(remote.Write(r, img)).To(Succeed())
+	}
+
+	wrapAndPush := func(runtime string) (*wrappedImage, string) {
+		wrapped, err := wrapRemoteImage(context.TODO(), &remoteImageArgs{
+			SourceImage: fmt.Sprintf("%s/source:latest", host),
+			TargetImage: fmt.Sprintf("%s/target", host),
+			Platforms:   []string{"linux/amd64"},
+			Runtimes:    map[string][]byte{"linux/amd64": []byte(runtime)},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		ref, err := pushRemoteImage(context.TODO(), wrapped, "", "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		return wrapped, ref
+	}
+
+	When("wrapping an image for a single platform", func() {
+		BeforeEach(func() {
+			push(fmt.Sprintf("%s/source:latest", host), platformImage("linux/amd64"))
+			manifestPushes = 0
+		})
+
+		It("should push the source image with the runtime as its entrypoint", func() {
+			wrapped, ref := wrapAndPush("runtime-v1")
+
+			By("Returning the reference the image was previewed with")
+			previewed, err := wrapped.reference()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ref).To(Equal(previewed))
+
+			digestRef, err := name.ParseReference(ref)
+			Expect(err).ShouldNot(HaveOccurred())
+			img, err := remote.Image(digestRef)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("Running the original command with the runtime")
+			cfg, err := img.ConfigFile()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(cfg.Config.Entrypoint).To(Equal([]string{"/bin/runtime"}))
+			Expect(cfg.Config.Cmd).To(Equal([]string{"node", "index.js"}))
+
+			By("Adding the runtime as a layer")
+			Expect(runtimeFile(img)).To(Equal("runtime-v1"))
+
+			By("Tagging the image with its key")
+			tagged, err := remote.Head(wrapped.target.Tag(wrapped.key))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ref).To(HaveSuffix(tagged.Digest.String()))
+		})
+
+		It("should not push the image again when its key is unchanged", func() {
+			_, first := wrapAndPush("runtime-v1")
+			_, second := wrapAndPush("runtime-v1")
+
+			Expect(second).To(Equal(first))
+			Expect(manifestPushes).To(Equal(1))
+		})
+
+		It("should push a new image when the runtime changes", func() {
+			firstWrapped, first := wrapAndPush("runtime-v1")
+			secondWrapped, second := wrapAndPush("runtime-v2")
+
+			Expect(secondWrapped.key).ToNot(Equal(firstWrapped.key))
+			Expect(second).ToNot(Equal(first))
+			Expect(manifestPushes).To(Equal(2))
+		})
+
+		It("should push a new image when the source image changes", func() {
+			firstWrapped, first := wrapAndPush("runtime-v1")
+
+			push(fmt.Sprintf("%s/source:latest", host), platformImage("linux/amd64"))
+			secondWrapped, second := wrapAndPush("runtime-v1")
+
+			Expect(secondWrapped.key).ToNot(Equal(firstWrapped.key))
+			Expect(second).ToNot(Equal(first))
+		})
+
+		It("should fail for platforms the source image isn't available for", func() {
+			_, err := wrapRemoteImage(context.TODO(), &remoteImageArgs{
+				SourceImage: fmt.Sprintf("%s/source:latest", host),
+				TargetImage: fmt.Sprintf("%s/target", host),
+				Platforms:   []string{"linux/arm64"},
+				Runtimes:    map[string][]byte{"linux/arm64": []byte("runtime")},
+			})
+			Expect(err).Should(HaveOccurred())
+			Expect(manifestPushes).To(Equal(0))
+		})
+	})
+
+	When("wrapping an image for several platforms", func() {
+		BeforeEach(func() {
+			var idx v1.ImageIndex = empty.Index
+			for _, p := range []string{"linux/amd64", "linux/arm64"} {
+				platform, err := v1.ParsePlatform(p)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+					Add:        platformImage(p),
+					Descriptor: v1.Descriptor{Platform: platform},
+				})
+			}
+
+			ref, err := name.ParseReference(fmt.Sprintf("%s/source:latest", host))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(remote.WriteIndex(ref, idx)).To(Succeed())
+		})
+
+		It("should push a manifest list with the runtime for each platform", func() {
+			wrapped, err := wrapRemoteImage(context.TODO(), &remoteImageArgs{
+				SourceImage: fmt.Sprintf("%s/source:latest", host),
+				TargetImage: fmt.Sprintf("%s/target", host),
+				Platforms:   []string{"linux/amd64", "linux/arm64"},
+				Runtimes: map[string][]byte{
+					"linux/amd64": []byte("runtime-amd64"),
+					"linux/arm64": []byte("runtime-arm64"),
+				},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ref, err := pushRemoteImage(context.TODO(), wrapped, "", "")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			digestRef, err := name.ParseReference(ref)
+			Expect(err).ShouldNot(HaveOccurred())
+			idx, err := remote.Index(digestRef)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			manifest, err := idx.IndexManifest()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(manifest.Manifests).To(HaveLen(2))
+
+			for _, desc := range manifest.Manifests {
+				img, err := idx.Image(desc.Digest)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(runtimeFile(img)).To(Equal("runtime-" + desc.Platform.Architecture))
+			}
+		})
+	})
+
+	When("the source registry requires credentials", func() {
+		var privateSrv *httptest.Server
+		var privateHost string
+
+		BeforeEach(func() {
+			reg := registry.New(registry.Logger(log.New(io.Discard, "", 0)))
+			privateSrv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if user, pass, ok := r.BasicAuth(); !ok || user != "oauth2accesstoken" || pass != "token" {
+					w.Header().Set("WWW-Authenticate", `Basic realm="registry"`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				reg.ServeHTTP(w, r)
+			}))
+			privateHost = strings.TrimPrefix(privateSrv.URL, "http://")
+
+			ref, err := name.ParseReference(fmt.Sprintf("%s/source:latest", privateHost))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(remote.Write(ref, platformImage("linux/amd64"), remote.WithAuth(&authn.Basic{
+				Username: "oauth2accesstoken",
+				Password: "token",
+			}))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			privateSrv.Close()
+		})
+
+		It("should pull the source image with the source credentials", func() {
+			wrapped, err := wrapRemoteImage(context.TODO(), &remoteImageArgs{
+				SourceImage:    fmt.Sprintf("%s/source:latest", privateHost),
+				SourceUsername: "oauth2accesstoken",
+				SourcePassword: "token",
+				TargetImage:    fmt.Sprintf("%s/target", host),
+				Platforms:      []string{"linux/amd64"},
+				Runtimes:       map[string][]byte{"linux/amd64": []byte("runtime")},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ref, err := pushRemoteImage(context.TODO(), wrapped, "", "")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			digestRef, err := name.ParseReference(ref)
+			Expect(err).ShouldNot(HaveOccurred())
+			img, err := remote.Image(digestRef)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(runtimeFile(img)).To(Equal("runtime"))
+		})
+
+		It("should fail without the source credentials", func() {
+			_, err := wrapRemoteImage(context.TODO(), &remoteImageArgs{
+				SourceImage: fmt.Sprintf("%s/source:latest", privateHost),
+				TargetImage: fmt.Sprintf("%s/target", host),
+				Platforms:   []string{"linux/amd64"},
+				Runtimes:    map[string][]byte{"linux/amd64": []byte("runtime")},
+			})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("ExistingImage", func() {
+		It("should return the digest reference of an image in the registry", func() {
+			img := platformImage("linux/amd64")
+			push(fmt.Sprintf("%s/built:abc", host), img)
+
+			digest, err := img.Digest()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			ref, err := ExistingImage(context.TODO(), fmt.Sprintf("%s/built:abc", host), "", "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ref).To(Equal(fmt.Sprintf("%s/built@%s", host, digest)))
+		})
+
+		It("should return an empty reference for images that don't exist", func() {
+			ref, err := ExistingImage(context.TODO(), fmt.Sprintf("%s/built:missing", host), "", "")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(ref).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,135 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+const (
+	// LambdaExecWrapper - the path of the exec wrapper in the runtime layer, set as AWS_LAMBDA_EXEC_WRAPPER
+	LambdaExecWrapper = "/opt/nitric/wrapper"
+	// LambdaEntrypointEnv - the variable the exec wrapper reads the command that starts the function's code from
+	LambdaEntrypointEnv = "NITRIC_ENTRYPOINT"
+)
+
+// The exec wrapper replaces the language runtime's bootstrap with the nitric runtime,
+// which handles invocations from the Lambda runtime API and starts the function's code
+const lambdaExecWrapper = `#!/bin/sh
+exec /opt/nitric/runtime $NITRIC_ENTRYPOINT
+`
+
+// lambdaRuntimes - the Lambda managed runtimes for languages that can run without being built
+var lambdaRuntimes = map[string]string{
+	"nodejs": "nodejs18.x",
+	"python": "python3.9",
+	"ruby":   "ruby2.7",
+}
+
+// LambdaPackage - describes how to deploy a zip or directory source to Lambda
+type LambdaPackage struct {
+	// Runtime - the Lambda managed runtime for the source's language (e.g. nodejs18.x)
+	Runtime string
+	// Environment - variables the function must be deployed with for the nitric runtime to handle its invocations
+	Environment map[string]string
+}
+
+// NewLambdaPackage - packages a zip or directory source for Lambda, writing the function's code archive to function
+// and a layer archive containing the nitric runtime to layer
+func NewLambdaPackage(eu *deploy.ExecutionUnit, runtime []byte, function io.Writer, layer io.Writer) (*LambdaPackage, error) {
+	language, err := Language(eu)
+	if err != nil {
+		return nil, err
+	}
+
+	lambdaRuntime, ok := lambdaRuntimes[language]
+	if !ok {
+		return nil, fmt.Errorf("%s sources must be built before deploying to lambda, use an image source", language)
+	}
+
+	cmd := Entrypoint(eu)
+	if cmd == "" {
+		return nil, fmt.Errorf("sources deployed to lambda must provide an entrypoint")
+	}
+
+	switch src := eu.Source.(type) {
+	case *deploy.ExecutionUnit_Zip:
+		f, err := os.Open(src.Zip.Path)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not read source archive: %s", src.Zip.Path))
+		}
+		defer f.Close()
+
+		if _, err := io.Copy(function, f); err != nil {
+			return nil, err
+		}
+	case *deploy.ExecutionUnit_Directory:
+		if err := ZipDirectory(src.Directory.Path, function); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zipLambdaLayer(runtime, layer); err != nil {
+		return nil, err
+	}
+
+	return &LambdaPackage{
+		Runtime: lambdaRuntime,
+		Environment: map[string]string{
+			"AWS_LAMBDA_EXEC_WRAPPER": LambdaExecWrapper,
+			LambdaEntrypointEnv:       cmd,
+		},
+	}, nil
+}
+
+// zipLambdaLayer - writes a layer archive containing the nitric runtime and its exec wrapper,
+// layers are extracted to /opt in the function's environment
+func zipLambdaLayer(runtime []byte, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name     string
+		contents []byte
+	}{
+		{"nitric/runtime", runtime},
+		{"nitric/wrapper", []byte(lambdaExecWrapper)},
+	}
+
+	for _, file := range files {
+		header := &zip.FileHeader{
+			Name:   file.name,
+			Method: zip.Deflate,
+		}
+		header.SetMode(0o755)
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fw.Write(file.contents); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
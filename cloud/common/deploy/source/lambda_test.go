@@ -0,0 +1,109 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+var _ = Describe("Lambda", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "lambda-test-*")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("NewLambdaPackage", func() {
+		It("should package a source directory with the runtime as a layer", func() {
+			writeFiles(dir, map[string]string{"package.json": "{}", "index.js": "main"})
+
+			function := &bytes.Buffer{}
+			layer := &bytes.Buffer{}
+			pkg, err := NewLambdaPackage(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Directory{Directory: &deploy.DirectorySource{Path: dir, Entrypoint: "node index.js"}},
+			}, []byte("runtime"), function, layer)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			By("Using the managed runtime for the language")
+			Expect(pkg.Runtime).To(Equal("nodejs18.x"))
+
+			By("Starting the code with the nitric runtime")
+			Expect(pkg.Environment).To(HaveKeyWithValue("AWS_LAMBDA_EXEC_WRAPPER", LambdaExecWrapper))
+			Expect(pkg.Environment).To(HaveKeyWithValue(LambdaEntrypointEnv, "node index.js"))
+
+			By("Archiving the source as the function's code")
+			Expect(readZip(function.Bytes())).To(Equal(map[string]string{"package.json": "{}", "index.js": "main"}))
+
+			By("Archiving the runtime and its exec wrapper as a layer")
+			layerFiles := readZip(layer.Bytes())
+			Expect(layerFiles).To(HaveKeyWithValue("nitric/runtime", "runtime"))
+			Expect(layerFiles["nitric/wrapper"]).To(ContainSubstring("exec /opt/nitric/runtime $" + LambdaEntrypointEnv))
+
+			zr, err := zip.NewReader(bytes.NewReader(layer.Bytes()), int64(layer.Len()))
+			Expect(err).ShouldNot(HaveOccurred())
+			for _, f := range zr.File {
+				Expect(f.Mode().Perm()).To(Equal(os.FileMode(0o755)))
+			}
+		})
+
+		It("should use a zip archive as the function's code", func() {
+			archive := filepath.Join(dir, "source.zip")
+			writeZip(archive, []string{"main.py", "requirements.txt"})
+
+			function := &bytes.Buffer{}
+			pkg, err := NewLambdaPackage(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Zip{Zip: &deploy.ZipSource{Path: archive, Entrypoint: "python3 main.py"}},
+			}, []byte("runtime"), function, &bytes.Buffer{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(pkg.Runtime).To(Equal("python3.9"))
+
+			original, err := os.ReadFile(archive)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(function.Bytes()).To(Equal(original))
+		})
+
+		It("should fail for languages that must be built", func() {
+			writeFiles(dir, map[string]string{"go.mod": "module"})
+
+			_, err := NewLambdaPackage(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Directory{Directory: &deploy.DirectorySource{Path: dir, Entrypoint: "./main"}},
+			}, []byte("runtime"), &bytes.Buffer{}, &bytes.Buffer{})
+			Expect(err).Should(MatchError(ContainSubstring("image source")))
+		})
+
+		It("should fail without an entrypoint", func() {
+			writeFiles(dir, map[string]string{"package.json": "{}"})
+
+			_, err := NewLambdaPackage(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Directory{Directory: &deploy.DirectorySource{Path: dir}},
+			}, []byte("runtime"), &bytes.Buffer{}, &bytes.Buffer{})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,199 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+// languageMarkers - files identifying the language of a source, in order of precedence
+var languageMarkers = []struct {
+	language string
+	match    func(file string) bool
+}{
+	{"go", exactly("go.mod")},
+	{"nodejs", exactly("package.json")},
+	{"python", exactly("requirements.txt", "pyproject.toml", "Pipfile", "setup.py")},
+	{"java", exactly("pom.xml", "build.gradle", "build.gradle.kts")},
+	{"dotnet", func(file string) bool {
+		return strings.HasSuffix(file, ".csproj") || strings.HasSuffix(file, ".fsproj")
+	}},
+	{"ruby", exactly("Gemfile")},
+	{"php", exactly("composer.json")},
+}
+
+func exactly(names ...string) func(string) bool {
+	return func(file string) bool {
+		for _, name := range names {
+			if file == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// DetectLanguage - detects the language of a source from the files at its root
+func DetectLanguage(files []string) (string, error) {
+	for _, marker := range languageMarkers {
+		for _, file := range files {
+			if marker.match(file) {
+				return marker.language, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unable to detect source language")
+}
+
+// Language - returns the language of a zip or directory execution unit source,
+// detecting it from the source's files when it was not provided
+func Language(eu *deploy.ExecutionUnit) (string, error) {
+	var files []string
+	var err error
+
+	switch src := eu.Source.(type) {
+	case *deploy.ExecutionUnit_Zip:
+		if src.Zip.Language != "" {
+			return src.Zip.Language, nil
+		}
+		files, err = zipFiles(src.Zip.Path)
+	case *deploy.ExecutionUnit_Directory:
+		if src.Directory.Language != "" {
+			return src.Directory.Language, nil
+		}
+		files, err = directoryFiles(src.Directory.Path)
+	default:
+		return "", fmt.Errorf("execution unit does not have a zip or directory source")
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return DetectLanguage(files)
+}
+
+// Entrypoint - returns the command that starts a zip or directory source, empty when not provided
+func Entrypoint(eu *deploy.ExecutionUnit) string {
+	switch src := eu.Source.(type) {
+	case *deploy.ExecutionUnit_Zip:
+		return src.Zip.Entrypoint
+	case *deploy.ExecutionUnit_Directory:
+		return src.Directory.Entrypoint
+	default:
+		return ""
+	}
+}
+
+// directoryFiles - lists the files at the root of a directory
+func directoryFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read source directory: %s", dir))
+	}
+
+	files := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+
+	return files, nil
+}
+
+// zipFiles - lists the files at the root of a zip archive
+func zipFiles(archive string) ([]string, error) {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not read source archive: %s", archive))
+	}
+	defer zr.Close()
+
+	files := []string{}
+	for _, f := range zr.File {
+		if !strings.Contains(strings.TrimSuffix(f.Name, "/"), "/") && !f.FileInfo().IsDir() {
+			files = append(files, f.Name)
+		}
+	}
+
+	return files, nil
+}
+
+// ZipDirectory - writes the contents of a directory to a zip archive, excluding version control files
+func ZipDirectory(dir string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Clean(filepath.ToSlash(rel))
+		header.Method = zip.Deflate
+
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(fw, f)
+		return err
+	})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("could not archive source directory: %s", dir))
+	}
+
+	return zw.Close()
+}
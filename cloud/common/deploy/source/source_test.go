@@ -0,0 +1,232 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+// writeFiles - writes files to a directory, keyed by their slash separated path
+func writeFiles(dir string, files map[string]string) {
+	for name, contents := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		Expect(os.MkdirAll(filepath.Dir(file), 0o755)).To(Succeed())
+		Expect(os.WriteFile(file, []byte(contents), 0o644)).To(Succeed())
+	}
+}
+
+// writeZip - writes a zip archive with the given entries, names ending in / are directories
+func writeZip(archive string, entries []string) {
+	f, err := os.Create(archive)
+	Expect(err).ShouldNot(HaveOccurred())
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, entry := range entries {
+		_, err := zw.Create(entry)
+		Expect(err).ShouldNot(HaveOccurred())
+	}
+	Expect(zw.Close()).To(Succeed())
+}
+
+// readZip - returns the contents of a zip archive keyed by file name
+func readZip(archive []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	Expect(err).ShouldNot(HaveOccurred())
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		Expect(err).ShouldNot(HaveOccurred())
+		contents, err := io.ReadAll(rc)
+		Expect(err).ShouldNot(HaveOccurred())
+		rc.Close()
+
+		files[f.Name] = string(contents)
+	}
+
+	return files
+}
+
+var _ = Describe("Source", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "source-test-*")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("DetectLanguage", func() {
+		cases := []struct {
+			files    []string
+			language string
+		}{
+			{[]string{"go.mod", "main.go"}, "go"},
+			{[]string{"index.js", "package.json"}, "nodejs"},
+			{[]string{"main.py", "requirements.txt"}, "python"},
+			{[]string{"pyproject.toml"}, "python"},
+			{[]string{"pom.xml"}, "java"},
+			{[]string{"build.gradle.kts"}, "java"},
+			{[]string{"app.csproj"}, "dotnet"},
+			{[]string{"Gemfile"}, "ruby"},
+			{[]string{"composer.json"}, "php"},
+			// go takes precedence over the nodejs tooling often found alongside it
+			{[]string{"package.json", "go.mod"}, "go"},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should detect %s from %v", c.language, c.files), func() {
+				language, err := DetectLanguage(c.files)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(language).To(Equal(c.language))
+			})
+		}
+
+		It("should fail when no language marker is present", func() {
+			_, err := DetectLanguage([]string{"README.md", "main.rs"})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("zipFiles", func() {
+		cases := []struct {
+			name    string
+			entries []string
+			files   []string
+		}{
+			{"files at the root", []string{"package.json", "index.js"}, []string{"index.js", "package.json"}},
+			{"nested files", []string{"go.mod", "cmd/main.go", "cmd/sub/file.go"}, []string{"go.mod"}},
+			{"directory entries", []string{"src/", "src/index.js", "package.json"}, []string{"package.json"}},
+			{"an empty archive", []string{}, []string{}},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should list the root files of %s", c.name), func() {
+				archive := filepath.Join(dir, "source.zip")
+				writeZip(archive, c.entries)
+
+				files, err := zipFiles(archive)
+				Expect(err).ShouldNot(HaveOccurred())
+				sort.Strings(files)
+				Expect(files).To(Equal(c.files))
+			})
+		}
+
+		It("should fail for files that aren't zip archives", func() {
+			archive := filepath.Join(dir, "source.zip")
+			Expect(os.WriteFile(archive, []byte("not a zip"), 0o644)).To(Succeed())
+
+			_, err := zipFiles(archive)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("ZipDirectory", func() {
+		cases := []struct {
+			name     string
+			files    map[string]string
+			archived map[string]string
+		}{
+			{
+				"files at the root",
+				map[string]string{"package.json": "{}", "index.js": "main"},
+				map[string]string{"package.json": "{}", "index.js": "main"},
+			},
+			{
+				"nested files",
+				map[string]string{"go.mod": "module", "cmd/app/main.go": "package main"},
+				map[string]string{"go.mod": "module", "cmd/app/main.go": "package main"},
+			},
+			{
+				"version control files",
+				map[string]string{"main.py": "print()", ".git/HEAD": "ref", ".gitignore": "*.pyc"},
+				map[string]string{"main.py": "print()", ".gitignore": "*.pyc"},
+			},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should archive %s", c.name), func() {
+				writeFiles(dir, c.files)
+
+				buf := &bytes.Buffer{}
+				Expect(ZipDirectory(dir, buf)).To(Succeed())
+				Expect(readZip(buf.Bytes())).To(Equal(c.archived))
+			})
+		}
+
+		It("should fail for directories that don't exist", func() {
+			Expect(ZipDirectory(filepath.Join(dir, "missing"), &bytes.Buffer{})).ShouldNot(Succeed())
+		})
+	})
+
+	Context("Language", func() {
+		It("should prefer the language provided with the source", func() {
+			writeFiles(dir, map[string]string{"package.json": "{}"})
+
+			language, err := Language(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Directory{Directory: &deploy.DirectorySource{Path: dir, Language: "python"}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(language).To(Equal("python"))
+		})
+
+		It("should detect the language of a source directory", func() {
+			writeFiles(dir, map[string]string{"package.json": "{}", "src/go.mod": "module"})
+
+			language, err := Language(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Directory{Directory: &deploy.DirectorySource{Path: dir}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(language).To(Equal("nodejs"))
+		})
+
+		It("should detect the language of a zip archive", func() {
+			archive := filepath.Join(dir, "source.zip")
+			writeZip(archive, []string{"requirements.txt", "main.py"})
+
+			language, err := Language(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Zip{Zip: &deploy.ZipSource{Path: archive}},
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(language).To(Equal("python"))
+		})
+
+		It("should fail for image sources", func() {
+			_, err := Language(&deploy.ExecutionUnit{
+				Source: &deploy.ExecutionUnit_Image{Image: &deploy.ImageSource{Uri: "image"}},
+			})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,290 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/valyala/fasthttp"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+// Decoded request bodies larger than this are rejected, bounding the memory used to decode a request
+const maxDecodedBodySize = 16 * 1024 * 1024
+
+var (
+	// errUnsupportedEncoding - the request body has a content encoding the gateway can't decode
+	errUnsupportedEncoding = errors.New("unsupported content encoding")
+	// errDecodedBodyTooLarge - the decoded request body is larger than maxDecodedBodySize
+	errDecodedBodyTooLarge = errors.New("decoded request body is too large")
+)
+
+type decoder func(io.Reader) (io.Reader, error)
+
+var decoders = map[string]decoder{
+	"gzip": func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	},
+	// HTTP deflate content is zlib wrapped
+	"deflate": func(r io.Reader) (io.Reader, error) {
+		return zlib.NewReader(r)
+	},
+	"br": func(r io.Reader) (io.Reader, error) {
+		return brotli.NewReader(r), nil
+	},
+	"zstd": func(r io.Reader) (io.Reader, error) {
+		// Decoding synchronously avoids leaking decoder goroutines when streamed bodies aren't read to completion
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+
+		return d.IOReadCloser(), nil
+	},
+}
+
+type encoder func(io.Writer) (io.WriteCloser, error)
+
+var encoders = map[string]encoder{
+	"gzip": func(w io.Writer) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	},
+	"br": func(w io.Writer) (io.WriteCloser, error) {
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression), nil
+	},
+	"zstd": func(w io.Writer) (io.WriteCloser, error) {
+		return zstd.NewWriter(w)
+	},
+}
+
+func deleteHeader(h map[string][]string, key string) {
+	for k := range h {
+		if strings.EqualFold(k, key) {
+			delete(h, k)
+		}
+	}
+}
+
+// decodeRequest - decodes the body of a request sent with a Content-Encoding, so workers receive the original body.
+// Returns errUnsupportedEncoding for unknown encodings, errDecodedBodyTooLarge if the decoded body is too large,
+// or an error describing why a body that is not validly encoded couldn't be decoded.
+func decodeRequest(rc *fasthttp.RequestCtx, req *triggers.HttpRequest) error {
+	encoding := strings.ToLower(strings.TrimSpace(string(rc.Request.Header.Peek("Content-Encoding"))))
+	if encoding == "" || encoding == "identity" {
+		return nil
+	}
+
+	decode, ok := decoders[encoding]
+	if !ok {
+		return fmt.Errorf("%w %s", errUnsupportedEncoding, encoding)
+	}
+
+	var body io.Reader = bytes.NewReader(req.Body)
+	if req.BodyStream != nil {
+		body = req.BodyStream
+	}
+
+	decoded, err := decode(body)
+	if err != nil {
+		return fmt.Errorf("error decoding %s request body: %w", encoding, err)
+	}
+
+	// Decoded in full before the request is handed to a worker, so oversized bodies can be rejected with a 413
+	buf, err := io.ReadAll(io.LimitReader(decoded, maxDecodedBodySize+1))
+	if closer, ok := decoded.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("error decoding %s request body: %w", encoding, err)
+	}
+
+	if len(buf) > maxDecodedBodySize {
+		return errDecodedBodyTooLarge
+	}
+
+	deleteHeader(req.Header, "Content-Encoding")
+	req.Header["Content-Length"] = []string{strconv.Itoa(len(buf))}
+
+	if len(buf) > triggers.MaxBufferedBodySize {
+		// Too large to send to workers in one message
+		req.Body = nil
+		req.BodyStream = bytes.NewReader(buf)
+		return nil
+	}
+
+	req.Body = buf
+	req.BodyStream = nil
+
+	return nil
+}
+
+// compressionConfig - configures compression of responses
+type compressionConfig struct {
+	// Supported encodings in order of preference, compression is disabled when empty
+	encodings []string
+	// Responses smaller than this are sent uncompressed
+	minSize int
+	// Prefixes of the content types that are compressed
+	contentTypes []string
+}
+
+func splitList(list string) []string {
+	values := make([]string, 0)
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+func newCompressionConfig(encodings string, minSize string, contentTypes string) (*compressionConfig, error) {
+	enc := splitList(strings.ToLower(encodings))
+	for _, e := range enc {
+		if _, ok := encoders[e]; !ok {
+			return nil, fmt.Errorf("unsupported response compression %s, expected one of gzip, br or zstd", e)
+		}
+	}
+
+	size, err := strconv.Atoi(minSize)
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("invalid response compression min size %s, expected positive integer value", minSize)
+	}
+
+	return &compressionConfig{
+		encodings:    enc,
+		minSize:      size,
+		contentTypes: splitList(strings.ToLower(contentTypes)),
+	}, nil
+}
+
+// negotiate - returns the preferred encoding accepted by the client, empty if none are acceptable
+func (c *compressionConfig) negotiate(acceptEncoding string) string {
+	accepted := make(map[string]float64)
+	for _, part := range splitList(strings.ToLower(acceptEncoding)) {
+		name, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			if parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[strings.TrimSpace(name)] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, e := range c.encodings {
+		q, ok := accepted[e]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+
+		if ok && q > bestQ {
+			best, bestQ = e, q
+		}
+	}
+
+	return best
+}
+
+func (c *compressionConfig) compressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, t := range c.contentTypes {
+		if strings.HasPrefix(contentType, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compressResponse - compresses the response body if the client accepts one of the configured encodings
+func (c *compressionConfig) compressResponse(rc *fasthttp.RequestCtx, response *triggers.HttpResponse) error {
+	if len(c.encodings) == 0 {
+		return nil
+	}
+
+	resp := &rc.Response
+	if len(resp.Header.Peek("Content-Encoding")) > 0 || !c.compressible(string(resp.Header.ContentType())) {
+		return nil
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() == 204 || resp.StatusCode() == 304 || rc.IsHead() {
+		return nil
+	}
+
+	if response.BodyStream == nil && len(response.Body) < c.minSize {
+		return nil
+	}
+
+	resp.Header.Add("Vary", "Accept-Encoding")
+
+	encoding := c.negotiate(string(rc.Request.Header.Peek("Accept-Encoding")))
+	if encoding == "" {
+		return nil
+	}
+
+	if response.BodyStream != nil {
+		pr, pw := io.Pipe()
+		enc, err := encoders[encoding](pw)
+		if err != nil {
+			return err
+		}
+
+		go func(stream io.Reader) {
+			_, err := io.Copy(enc, stream)
+			if err == nil {
+				err = enc.Close()
+			}
+			if closer, ok := stream.(io.Closer); ok {
+				_ = closer.Close()
+			}
+			pw.CloseWithError(err)
+		}(response.BodyStream)
+
+		response.BodyStream = pr
+	} else {
+		buf := &bytes.Buffer{}
+		enc, err := encoders[encoding](buf)
+		if err != nil {
+			return err
+		}
+
+		if _, err := enc.Write(response.Body); err != nil {
+			return err
+		}
+
+		if err := enc.Close(); err != nil {
+			return err
+		}
+
+		response.Body = buf.Bytes()
+	}
+
+	resp.Header.Set("Content-Encoding", encoding)
+
+	return nil
+}
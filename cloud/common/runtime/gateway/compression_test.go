@@ -0,0 +1,236 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+	mock_worker "github.com/nitrictech/nitric/core/tests/mocks/worker"
+)
+
+func encode(encoding string, body []byte) []byte {
+	buf := &bytes.Buffer{}
+
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(buf)
+	case "deflate":
+		w = zlib.NewWriter(buf)
+	case "br":
+		w = brotli.NewWriter(buf)
+	case "zstd":
+		w, _ = zstd.NewWriter(buf)
+	}
+
+	_, err := w.Write(body)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(w.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+func decode(encoding string, body []byte) []byte {
+	decoder, err := decoders[encoding](bytes.NewReader(body))
+	Expect(err).ShouldNot(HaveOccurred())
+
+	decoded, err := io.ReadAll(decoder)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return decoded
+}
+
+var _ = Describe("Compression", func() {
+	Context("decoding requests", func() {
+		var (
+			gw      *BaseHttpGateway
+			pool    worker.WorkerPool
+			handler *mock_worker.MockWorker
+		)
+
+		BeforeEach(func() {
+			gw = newTestGateway()
+			handler = mock_worker.NewMockWorker(&mock_worker.MockWorkerOptions{
+				ReturnHttp: &triggers.HttpResponse{StatusCode: 200},
+			})
+			pool = worker.NewProcessPool(&worker.ProcessPoolOptions{})
+			Expect(pool.AddWorker(handler)).To(Succeed())
+		})
+
+		post := func(encoding string, body []byte) *fasthttp.RequestCtx {
+			rc := newRequestCtx("POST", "/test", map[string]string{"Content-Encoding": encoding})
+			rc.Request.SetBody(body)
+
+			gw.httpHandler(pool)(rc)
+
+			return rc
+		}
+
+		for _, encoding := range []string{"gzip", "deflate", "br", "zstd"} {
+			encoding := encoding
+
+			It(fmt.Sprintf("should hand the decoded %s body to the worker", encoding), func() {
+				rc := post(encoding, encode(encoding, []byte("hello world")))
+
+				Expect(rc.Response.StatusCode()).To(Equal(200))
+				Expect(handler.ReceivedRequests).To(HaveLen(1))
+
+				req := handler.ReceivedRequests[0]
+				Expect(string(req.Body)).To(Equal("hello world"))
+				Expect(req.Header).ToNot(HaveKey("Content-Encoding"))
+				Expect(req.Header["Content-Length"]).To(Equal([]string{"11"}))
+			})
+		}
+
+		It("should stream decoded bodies too large to buffer", func() {
+			body := bytes.Repeat([]byte("a"), triggers.MaxBufferedBodySize+1)
+
+			rc := post("gzip", encode("gzip", body))
+
+			Expect(rc.Response.StatusCode()).To(Equal(200))
+			req := handler.ReceivedRequests[0]
+			Expect(req.Body).To(BeNil())
+
+			streamed, err := io.ReadAll(req.BodyStream)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(streamed).To(Equal(body))
+		})
+
+		It("should reject bodies that decode to more than the limit with a 413", func() {
+			rc := post("gzip", encode("gzip", make([]byte, maxDecodedBodySize+1)))
+
+			Expect(rc.Response.StatusCode()).To(Equal(413))
+			Expect(handler.ReceivedRequests).To(BeEmpty())
+		})
+
+		It("should reject corrupt bodies with a 400", func() {
+			rc := post("gzip", []byte("not gzip"))
+
+			Expect(rc.Response.StatusCode()).To(Equal(400))
+			Expect(handler.ReceivedRequests).To(BeEmpty())
+		})
+
+		It("should reject unsupported encodings with a 415", func() {
+			rc := post("compress", []byte("body"))
+
+			Expect(rc.Response.StatusCode()).To(Equal(415))
+			Expect(handler.ReceivedRequests).To(BeEmpty())
+		})
+
+		It("should rate limit requests before decoding them", func() {
+			limiter := ratelimit.NewLimiter()
+			limiter.DeclareApi("", &ratelimit.Limit{RequestsPerSecond: 1})
+			gw.SetRateLimiter(limiter)
+
+			Expect(post("gzip", []byte("not gzip")).Response.StatusCode()).To(Equal(400))
+			Expect(post("gzip", []byte("not gzip")).Response.StatusCode()).To(Equal(429))
+		})
+	})
+
+	Context("compressing responses", func() {
+		var config *compressionConfig
+
+		BeforeEach(func() {
+			var err error
+			config, err = newCompressionConfig("br,gzip", "10", "text/,application/json")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		respond := func(acceptEncoding string, contentType string, body []byte) (*fasthttp.RequestCtx, *triggers.HttpResponse) {
+			rc := newRequestCtx("GET", "/test", map[string]string{"Accept-Encoding": acceptEncoding})
+			rc.Response.Header.SetContentType(contentType)
+			response := &triggers.HttpResponse{StatusCode: 200, Body: body}
+
+			Expect(config.compressResponse(rc, response)).To(Succeed())
+
+			return rc, response
+		}
+
+		It("should compress with the preferred encoding accepted by the client", func() {
+			rc, response := respond("gzip, br", "application/json", []byte(`{"hello": "world"}`))
+
+			Expect(string(rc.Response.Header.Peek("Content-Encoding"))).To(Equal("br"))
+			Expect(string(rc.Response.Header.Peek("Vary"))).To(Equal("Accept-Encoding"))
+			Expect(string(decode("br", response.Body))).To(Equal(`{"hello": "world"}`))
+		})
+
+		It("should respect the quality values of accepted encodings", func() {
+			rc, response := respond("br;q=0.5, gzip", "text/plain", []byte("hello world"))
+
+			Expect(string(rc.Response.Header.Peek("Content-Encoding"))).To(Equal("gzip"))
+			Expect(string(decode("gzip", response.Body))).To(Equal("hello world"))
+		})
+
+		It("should compress streamed bodies", func() {
+			rc := newRequestCtx("GET", "/test", map[string]string{"Accept-Encoding": "gzip"})
+			rc.Response.Header.SetContentType("text/plain")
+			response := &triggers.HttpResponse{StatusCode: 200, BodyStream: io.NopCloser(bytes.NewReader([]byte("streamed")))}
+
+			Expect(config.compressResponse(rc, response)).To(Succeed())
+
+			compressed, err := io.ReadAll(response.BodyStream)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(decode("gzip", compressed))).To(Equal("streamed"))
+		})
+
+		expectUncompressed := func(rc *fasthttp.RequestCtx, response *triggers.HttpResponse, body string) {
+			Expect(rc.Response.Header.Peek("Content-Encoding")).To(BeEmpty())
+			Expect(string(response.Body)).To(Equal(body))
+		}
+
+		It("should not compress when the client accepts none of the encodings", func() {
+			rc, response := respond("deflate", "text/plain", []byte("hello world"))
+
+			expectUncompressed(rc, response, "hello world")
+		})
+
+		It("should not compress when the client refuses the encodings", func() {
+			rc, response := respond("br;q=0, gzip;q=0", "text/plain", []byte("hello world"))
+
+			expectUncompressed(rc, response, "hello world")
+		})
+
+		It("should not compress bodies smaller than the min size", func() {
+			rc, response := respond("gzip", "text/plain", []byte("hello"))
+
+			expectUncompressed(rc, response, "hello")
+		})
+
+		It("should not compress content types that aren't compressible", func() {
+			rc, response := respond("gzip", "image/png", []byte("hello world"))
+
+			expectUncompressed(rc, response, "hello world")
+		})
+
+		It("should reject unsupported encodings", func() {
+			_, err := newCompressionConfig("compress", "10", "text/")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
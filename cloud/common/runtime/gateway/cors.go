@@ -0,0 +1,70 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/nitrictech/nitric/core/pkg/cors"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+)
+
+// corsConfig - the CORS configuration of the API a request is addressed to, nil if it has none
+func (s *BaseHttpGateway) corsConfig(pool worker.WorkerPool, req *triggers.HttpRequest) *cors.Config {
+	if s.cors == nil {
+		return nil
+	}
+
+	api := req.Api
+	if api == "" {
+		// Requests not mapped to an API by host or base path use the API of the route handling them
+		for _, w := range routeWorkers(pool, req) {
+			if api = worker.WorkerApi(w); api != "" {
+				break
+			}
+		}
+	}
+
+	if api == "" {
+		return nil
+	}
+
+	return s.cors.Config(api)
+}
+
+// routeWorkers - the workers handling a request, or for preflight requests the workers handling the request being checked
+func routeWorkers(pool worker.WorkerPool, req *triggers.HttpRequest) []worker.Worker {
+	if cors.IsPreflight(req) {
+		actual := *req
+		actual.Method = cors.RequestedMethod(req)
+		req = &actual
+	}
+
+	return pool.GetWorkers(&worker.GetWorkerOptions{Http: req})
+}
+
+// handlePreflight - answer a preflight request without handing it to a worker
+func handlePreflight(rc *fasthttp.RequestCtx, pool worker.WorkerPool, config *cors.Config, req *triggers.HttpRequest) {
+	routeMethods := []string{}
+	if len(routeWorkers(pool, req)) > 0 {
+		routeMethods = append(routeMethods, cors.RequestedMethod(req))
+	}
+
+	resp := config.Preflight(req, routeMethods)
+
+	resp.Header.CopyTo(&rc.Response.Header)
+	rc.Response.SetStatusCode(resp.StatusCode)
+}
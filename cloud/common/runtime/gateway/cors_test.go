@@ -0,0 +1,153 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/cors"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+	mock_worker "github.com/nitrictech/nitric/core/tests/mocks/worker"
+)
+
+var _ = Describe("CORS", func() {
+	var (
+		gw      *BaseHttpGateway
+		pool    worker.WorkerPool
+		handler *mock_worker.MockWorker
+	)
+
+	preflightHeaders := map[string]string{
+		"Origin":                        "https://example.com",
+		"Access-Control-Request-Method": "GET",
+	}
+
+	newPool := func(opts *mock_worker.MockWorkerOptions) {
+		handler = mock_worker.NewMockWorker(opts)
+		pool = worker.NewProcessPool(&worker.ProcessPoolOptions{})
+
+		Expect(pool.AddWorker(worker.NewRouteWorker(handler, &worker.RouteWorkerOptions{
+			Api:     "test-api",
+			Path:    "/test",
+			Methods: []string{"GET"},
+		}))).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		registry := cors.NewRegistry()
+		registry.DeclareApi("test-api", &cors.Config{
+			AllowOrigins:  []string{"https://example.com"},
+			AllowHeaders:  []string{"Content-Type"},
+			ExposeHeaders: []string{"X-Request-Id"},
+		})
+
+		gw = newTestGateway()
+		gw.SetCors(registry)
+	})
+
+	When("a preflight request is received for a route", func() {
+		It("should respond without handing the request to a worker", func() {
+			newPool(&mock_worker.MockWorkerOptions{})
+			rc := newRequestCtx("OPTIONS", "/test", preflightHeaders)
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.StatusCode()).To(Equal(204))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Allow-Methods"))).To(Equal("GET"))
+			Expect(handler.ReceivedRequests).To(BeEmpty())
+		})
+	})
+
+	When("a preflight request is received for a method the route doesn't accept", func() {
+		It("should omit the CORS headers", func() {
+			newPool(&mock_worker.MockWorkerOptions{})
+			rc := newRequestCtx("OPTIONS", "/test", map[string]string{
+				"Origin":                        "https://example.com",
+				"Access-Control-Request-Method": "DELETE",
+			})
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.Header.Peek("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+	})
+
+	When("a request is handled by a worker", func() {
+		It("should add CORS headers to the response", func() {
+			newPool(&mock_worker.MockWorkerOptions{
+				ReturnHttp: &triggers.HttpResponse{StatusCode: 200, Body: []byte("success")},
+			})
+			rc := newRequestCtx("GET", "/test", map[string]string{"Origin": "https://example.com"})
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.StatusCode()).To(Equal(200))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Expose-Headers"))).To(Equal("X-Request-Id"))
+		})
+	})
+
+	When("a request is rejected as unauthenticated", func() {
+		It("should add CORS headers to the response", func() {
+			newPool(&mock_worker.MockWorkerOptions{HttpError: auth.ErrUnauthenticated})
+			rc := newRequestCtx("GET", "/test", map[string]string{"Origin": "https://example.com"})
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.StatusCode()).To(Equal(401))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+		})
+	})
+
+	When("a request is rate limited", func() {
+		It("should add CORS headers to the response", func() {
+			newPool(&mock_worker.MockWorkerOptions{
+				ReturnHttp: &triggers.HttpResponse{StatusCode: 200},
+			})
+
+			limiter := ratelimit.NewLimiter()
+			limiter.DeclareApi("test-api", &ratelimit.Limit{RequestsPerSecond: 1})
+			Expect(limiter.DeclareRoute("test-api", "/test", []string{"GET"}, nil)).To(Succeed())
+			gw.SetRateLimiter(limiter)
+
+			gw.httpHandler(pool)(newRequestCtx("GET", "/test", map[string]string{"Origin": "https://example.com"}))
+			rc := newRequestCtx("GET", "/test", map[string]string{"Origin": "https://example.com"})
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.StatusCode()).To(Equal(429))
+			Expect(string(rc.Response.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+		})
+	})
+
+	When("the api has no CORS configuration", func() {
+		It("should not add CORS headers to the response", func() {
+			newPool(&mock_worker.MockWorkerOptions{
+				ReturnHttp: &triggers.HttpResponse{StatusCode: 200},
+			})
+			gw.SetCors(cors.NewRegistry())
+			rc := newRequestCtx("GET", "/test", map[string]string{"Origin": "https://example.com"})
+
+			gw.httpHandler(pool)(rc)
+
+			Expect(rc.Response.Header.Peek("Access-Control-Allow-Origin")).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,53 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+)
+
+func TestGateway(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Base HTTP Gateway Suite")
+}
+
+// newTestGateway - a gateway with the default configuration, whose handler is called directly rather than served
+func newTestGateway() *BaseHttpGateway {
+	gw, err := New(nil)
+	Expect(err).ToNot(HaveOccurred())
+
+	return gw.(*BaseHttpGateway)
+}
+
+// newRequestCtx - a request context for a request from a client at 127.0.0.1
+func newRequestCtx(method string, uri string, headers map[string]string) *fasthttp.RequestCtx {
+	req := &fasthttp.Request{}
+	req.Header.SetMethod(method)
+	req.SetRequestURI(uri)
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rc := &fasthttp.RequestCtx{}
+	rc.Init(req, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}, nil)
+
+	return rc
+}
@@ -17,14 +17,24 @@ package base_http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/fasthttp/websocket"
 	"github.com/valyala/fasthttp"
 	"go.opentelemetry.io/otel"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/cors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
 	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/utils"
@@ -42,10 +52,63 @@ type BaseHttpGateway struct {
 	// return bool will indicate whether to continue
 	// to the next (default) behaviour or not...
 	mw HttpMiddleware
+
+	// Resolves the API requests are addressed to
+	router *apiRouter
+
+	// Rate limits requests are counted against before they are handed to a worker, nil if requests aren't limited
+	limiter *ratelimit.Limiter
+
+	// CORS configurations of APIs, applied to every response including those rejected before reaching a worker
+	cors *cors.Registry
+
+	// Compression of response bodies
+	compression *compressionConfig
+
+	// Open websocket connections by connection ID
+	connectionLock sync.RWMutex
+	connections    map[string]*websocketConnection
+}
+
+// trailerStream - a response body stream adding its trailers to the response header once it has been read,
+// before fasthttp writes the trailers that follow a chunked body
+type trailerStream struct {
+	io.ReadCloser
+	trailer func() map[string][]string
+	header  *fasthttp.ResponseHeader
+}
+
+func (t *trailerStream) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if err == io.EOF && t.trailer != nil {
+		for key, values := range t.trailer() {
+			for _, v := range values {
+				t.header.Add(key, v)
+			}
+
+			_ = t.header.AddTrailer(key)
+		}
+
+		t.trailer = nil
+	}
+
+	return n, err
+}
+
+// isHttp2Preface - returns true for the connection preface sent by HTTP/2 clients, which fasthttp parses as a PRI request
+func isHttp2Preface(rc *fasthttp.RequestCtx) bool {
+	return string(rc.Method()) == "PRI" && string(rc.Request.Header.Protocol()) == "HTTP/2.0"
 }
 
 func (s *BaseHttpGateway) httpHandler(pool worker.WorkerPool) func(ctx *fasthttp.RequestCtx) {
 	return func(rc *fasthttp.RequestCtx) {
+		// Only HTTP/1.1 is served, so native gRPC clients must use gRPC-Web instead
+		if isHttp2Preface(rc) {
+			rc.SetConnectionClose()
+			rc.Error("HTTP/2 is not supported, use HTTP/1.1 or gRPC-Web", 505)
+			return
+		}
+
 		if s.mw != nil {
 			if !s.mw(rc, pool) {
 				// middleware has indicated that is has processed the request
@@ -54,18 +117,73 @@ func (s *BaseHttpGateway) httpHandler(pool worker.WorkerPool) func(ctx *fasthttp
 			}
 		}
 
+		if websocket.FastHTTPIsWebSocketUpgrade(rc) {
+			s.handleWebsocket(rc, pool)
+			return
+		}
+
 		httpTrigger := triggers.FromHttpRequest(rc)
+		s.router.Route(string(rc.Host()), httpTrigger)
+
+		if corsConfig := s.corsConfig(pool, httpTrigger); corsConfig != nil {
+			if cors.IsPreflight(httpTrigger) {
+				handlePreflight(rc, pool, corsConfig, httpTrigger)
+				return
+			}
+
+			// Deferred so error responses, e.g. from auth or rate limiting, can be read by browsers too
+			defer corsConfig.ApplyHeaders(httpTrigger, &rc.Response.Header)
+		}
+
+		if s.limiter != nil {
+			if retryAfter, ok := s.limiter.Allow(rc, httpTrigger, rc.RemoteIP().String()); !ok {
+				rc.Response.Header.Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				rc.Error("Too Many Requests", 429)
+				return
+			}
+		}
+
+		// Decoded after rate limiting, so limited clients can't use the gateway to decode bodies
+		if err := decodeRequest(rc, httpTrigger); errors.Is(err, errUnsupportedEncoding) {
+			rc.Error(err.Error(), 415)
+			return
+		} else if errors.Is(err, errDecodedBodyTooLarge) {
+			rc.Error(err.Error(), 413)
+			return
+		} else if err != nil {
+			rc.Error(fmt.Sprintf("Unable to decode request body: %v", err), 400)
+			return
+		}
 
 		wrkr, err := pool.GetWorker(&worker.GetWorkerOptions{
 			Http: httpTrigger,
 		})
-		if err != nil {
+		if errors.Is(err, worker.ErrDraining) {
+			rc.Error("Service is shutting down", 503)
+			return
+		} else if errors.Is(err, worker.ErrSaturated) {
+			rc.Error("Service is at capacity", 503)
+			return
+		} else if err != nil {
 			rc.Error("Unable to get worker to handle request", 500)
 			return
 		}
 
 		response, err := wrkr.HandleHttpRequest(span.FromHeaders(context.TODO(), httpTrigger.Header), httpTrigger)
-		if err != nil {
+		if errors.Is(err, worker.ErrDraining) {
+			rc.Error("Service is shutting down", 503)
+			return
+		} else if errors.Is(err, worker.ErrSaturated) {
+			rc.Error("Service is at capacity", 503)
+			return
+		} else if errors.Is(err, auth.ErrUnauthenticated) {
+			rc.Response.Header.Set("WWW-Authenticate", "Bearer")
+			rc.Error("Unauthorized", 401)
+			return
+		} else if errors.Is(err, auth.ErrForbidden) {
+			rc.Error("Forbidden", 403)
+			return
+		} else if err != nil {
 			rc.Error(fmt.Sprintf("Error handling HTTP Request: %v", err), 500)
 			return
 		}
@@ -77,16 +195,47 @@ func (s *BaseHttpGateway) httpHandler(pool worker.WorkerPool) func(ctx *fasthttp
 		// Avoid content length header duplication
 		rc.Response.Header.Del("Content-Length")
 		rc.Response.SetStatusCode(response.StatusCode)
-		rc.Response.SetBody(response.Body)
+
+		if err := s.compression.compressResponse(rc, response); err != nil {
+			rc.Error(fmt.Sprintf("Error compressing HTTP response: %v", err), 500)
+			return
+		}
+
+		if response.BodyStream != nil {
+			var stream io.ReadCloser = response.BodyStream
+			if response.Trailer != nil {
+				stream = &trailerStream{ReadCloser: stream, trailer: response.Trailer, header: &rc.Response.Header}
+			}
+
+			// Written with chunked transfer encoding as it is read, the stream is closed by fasthttp once written
+			rc.Response.SetBodyStream(stream, -1)
+		} else {
+			rc.Response.SetBody(response.Body)
+		}
 	}
 }
 
+var _ gateway.RateLimitedGatewayService = &BaseHttpGateway{}
+
+func (s *BaseHttpGateway) SetRateLimiter(limiter *ratelimit.Limiter) {
+	s.limiter = limiter
+}
+
+var _ gateway.CorsGatewayService = &BaseHttpGateway{}
+
+func (s *BaseHttpGateway) SetCors(registry *cors.Registry) {
+	s.cors = registry
+}
+
 func (s *BaseHttpGateway) Start(pool worker.WorkerPool) error {
 	s.server = &fasthttp.Server{
 		IdleTimeout:     time.Second * 1,
 		CloseOnShutdown: true,
 		Handler:         s.httpHandler(pool),
 		ReadBufferSize:  8192,
+		// Bodies larger than the max request body size, or of unknown length, are streamed to workers rather than rejected
+		StreamRequestBody:  true,
+		MaxRequestBodySize: triggers.MaxBufferedBodySize,
 	}
 
 	return s.server.ListenAndServe(s.address)
@@ -99,6 +248,9 @@ func (s *BaseHttpGateway) Stop() error {
 		_ = tp.Shutdown(context.TODO())
 	}
 
+	// Hijacked websocket connections aren't closed by the server shutdown
+	s.closeConnections()
+
 	if s.server != nil {
 		return s.server.Shutdown()
 	}
@@ -110,8 +262,37 @@ func (s *BaseHttpGateway) Stop() error {
 func New(mw HttpMiddleware) (gateway.GatewayService, error) {
 	address := utils.GetEnv("GATEWAY_ADDRESS", ":9001")
 
+	defaultUrl := "http://" + address
+	if strings.HasPrefix(address, ":") {
+		defaultUrl = "http://localhost" + address
+	}
+
+	// Several APIs may be served by one gateway, mapped by host e.g. orders=orders.example.com
+	// or base path e.g. orders=/orders
+	router, err := newApiRouter(
+		utils.GetEnv("API_HOSTS", ""),
+		utils.GetEnv("API_BASE_PATHS", ""),
+		utils.GetEnv("GATEWAY_URL", defaultUrl),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses are compressed with the first of these encodings accepted by the client, e.g. br,zstd,gzip
+	compression, err := newCompressionConfig(
+		utils.GetEnv("RESPONSE_COMPRESSION", ""),
+		utils.GetEnv("RESPONSE_COMPRESSION_MIN_SIZE", "1024"),
+		utils.GetEnv("RESPONSE_COMPRESSION_TYPES", "text/,application/json,application/javascript,application/xml,image/svg+xml"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &BaseHttpGateway{
-		address: address,
-		mw:      mw,
+		address:     address,
+		mw:          mw,
+		router:      router,
+		compression: compression,
+		connections: make(map[string]*websocketConnection),
 	}, nil
 }
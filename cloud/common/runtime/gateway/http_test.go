@@ -0,0 +1,126 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+	mock_worker "github.com/nitrictech/nitric/core/tests/mocks/worker"
+)
+
+var _ = Describe("Streamed responses", func() {
+	var (
+		ln     *fasthttputil.InmemoryListener
+		client *http.Client
+		pw     *io.PipeWriter
+	)
+
+	BeforeEach(func() {
+		var pr *io.PipeReader
+		pr, pw = io.Pipe()
+
+		header := &fasthttp.ResponseHeader{}
+		header.SetContentType("application/grpc")
+
+		pool := worker.NewProcessPool(&worker.ProcessPoolOptions{})
+		Expect(pool.AddWorker(mock_worker.NewMockWorker(&mock_worker.MockWorkerOptions{
+			ReturnHttp: &triggers.HttpResponse{
+				Header:     header,
+				StatusCode: 200,
+				BodyStream: pr,
+				Trailer: func() map[string][]string {
+					return map[string][]string{"Grpc-Status": {"0"}}
+				},
+			},
+		}))).To(Succeed())
+
+		ln = fasthttputil.NewInmemoryListener()
+		go func() {
+			_ = (&fasthttp.Server{Handler: newTestGateway().httpHandler(pool)}).Serve(ln)
+		}()
+
+		client = &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+					return ln.Dial()
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		_ = ln.Close()
+	})
+
+	It("should write each read of the stream as it is received, followed by its trailers", func() {
+		go func() {
+			_, _ = pw.Write([]byte("first"))
+		}()
+
+		resp, err := client.Post("http://gateway/test", "application/grpc", nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		defer resp.Body.Close()
+
+		// Received while the stream is still open
+		first := make([]byte, 5)
+		_, err = io.ReadFull(resp.Body, first)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(first)).To(Equal("first"))
+
+		go func() {
+			_, _ = pw.Write([]byte("second"))
+			_ = pw.Close()
+		}()
+
+		rest, err := io.ReadAll(resp.Body)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(rest)).To(Equal("second"))
+		Expect(resp.Trailer.Get("Grpc-Status")).To(Equal("0"))
+	})
+})
+
+var _ = Describe("HTTP/2 connections", func() {
+	It("should reject the connection preface of HTTP/2 clients", func() {
+		ln := fasthttputil.NewInmemoryListener()
+		defer ln.Close()
+
+		pool := worker.NewProcessPool(&worker.ProcessPoolOptions{})
+		go func() {
+			_ = (&fasthttp.Server{Handler: newTestGateway().httpHandler(pool)}).Serve(ln)
+		}()
+
+		conn, err := ln.Dial()
+		Expect(err).ShouldNot(HaveOccurred())
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusHTTPVersionNotSupported))
+	})
+})
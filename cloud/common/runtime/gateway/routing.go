@@ -0,0 +1,162 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+// apiRouter - resolves the API a request is addressed to, allowing several APIs to be served by one gateway
+type apiRouter struct {
+	// API names keyed by lower-cased host
+	hosts map[string]string
+	// Base paths keyed by API name
+	basePaths map[string]string
+	// Public URL of this gateway, used to report the URLs of APIs that aren't mapped to a host
+	publicUrl *url.URL
+}
+
+// parseApiMapping - parses a comma separated list of api=value pairs, e.g. orders=/orders,users=/users
+func parseApiMapping(mapping string) (map[string]string, error) {
+	m := make(map[string]string)
+
+	for _, pair := range strings.Split(mapping, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		api, value, ok := strings.Cut(pair, "=")
+		if !ok || api == "" || value == "" {
+			return nil, fmt.Errorf("invalid api mapping %q, expected <api>=<value>", pair)
+		}
+
+		m[strings.TrimSpace(api)] = strings.TrimSpace(value)
+	}
+
+	return m, nil
+}
+
+func newApiRouter(hostMapping string, basePathMapping string, publicUrl string) (*apiRouter, error) {
+	apiHosts, err := parseApiMapping(hostMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	basePaths, err := parseApiMapping(basePathMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]string, len(apiHosts))
+	for api, host := range apiHosts {
+		host = strings.ToLower(host)
+		if existing, ok := hosts[host]; ok {
+			return nil, fmt.Errorf("apis %s and %s are both mapped to host %s", existing, api, host)
+		}
+		hosts[host] = api
+	}
+
+	for api, basePath := range basePaths {
+		basePaths[api] = "/" + strings.Trim(basePath, "/")
+	}
+
+	u, err := url.Parse(publicUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway url %s: %w", publicUrl, err)
+	}
+
+	return &apiRouter{
+		hosts:     hosts,
+		basePaths: basePaths,
+		publicUrl: u,
+	}, nil
+}
+
+// hasPathPrefix - returns true if path is equal to or nested under the given base path
+func hasPathPrefix(path string, basePath string) bool {
+	return basePath == "/" || path == basePath || strings.HasPrefix(path, basePath+"/")
+}
+
+// Route - sets the API of the given request from its host or base path, stripping the base path of the API from the request path.
+// Requests that don't match a mapped API are left as is and may be handled by the routes of any API.
+func (r *apiRouter) Route(host string, trigger *triggers.HttpRequest) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	api, ok := r.hosts[strings.ToLower(host)]
+	if !ok {
+		// Match on the longest base path
+		longest := ""
+		for a, basePath := range r.basePaths {
+			if hasPathPrefix(trigger.Path, basePath) && len(basePath) > len(longest) {
+				api = a
+				longest = basePath
+			}
+		}
+
+		if api == "" {
+			return
+		}
+	}
+
+	trigger.Api = api
+
+	if basePath, ok := r.basePaths[api]; ok && basePath != "/" && hasPathPrefix(trigger.Path, basePath) {
+		trigger.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(trigger.Path, basePath), "/")
+	}
+}
+
+// Url - returns the URL the given API is served from
+func (r *apiRouter) Url(api string) string {
+	u := *r.publicUrl
+
+	for host, a := range r.hosts {
+		if a == api {
+			u.Host = host
+			break
+		}
+	}
+
+	if basePath, ok := r.basePaths[api]; ok && basePath != "/" {
+		u.Path = strings.TrimSuffix(u.Path, "/") + basePath
+	}
+
+	return u.String()
+}
+
+// Details - returns the URL APIs are served from by this gateway
+func (s *BaseHttpGateway) Details(ctx context.Context, typ common.ResourceType, name string) (*common.DetailsResponse[any], error) {
+	if typ != common.ResourceType_Api {
+		return nil, fmt.Errorf("unsupported resource type: %s", typ)
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       name,
+		Provider: "nitric",
+		Service:  "HttpGateway",
+		Detail: common.ApiDetails{
+			URL: s.router.Url(name),
+		},
+	}, nil
+}
@@ -0,0 +1,216 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package base_http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fasthttp/websocket"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	ws "github.com/nitrictech/nitric/core/pkg/plugins/websocket"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+)
+
+type websocketConnection struct {
+	socket string
+	// Writes to a websocket connection must not be concurrent
+	writeLock sync.Mutex
+	conn      *websocket.Conn
+}
+
+func (c *websocketConnection) write(messageType int, data []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	return c.conn.WriteMessage(messageType, data)
+}
+
+var _ ws.WebsocketService = &BaseHttpGateway{}
+
+var upgrader = websocket.FastHTTPUpgrader{
+	// Any origin may connect, connections can be rejected by the socket's connect handler
+	CheckOrigin: func(ctx *fasthttp.RequestCtx) bool {
+		return true
+	},
+}
+
+func (s *BaseHttpGateway) connection(socket string, connectionId string) (*websocketConnection, error) {
+	s.connectionLock.RLock()
+	defer s.connectionLock.RUnlock()
+
+	conn, ok := s.connections[connectionId]
+	if !ok || conn.socket != socket {
+		return nil, fmt.Errorf("connection %s not found on socket %s", connectionId, socket)
+	}
+
+	return conn, nil
+}
+
+// Send - Sends a message to a websocket connection made through this gateway
+func (s *BaseHttpGateway) Send(ctx context.Context, socket string, connectionId string, message []byte) error {
+	conn, err := s.connection(socket, connectionId)
+	if err != nil {
+		return err
+	}
+
+	messageType := websocket.BinaryMessage
+	if utf8.Valid(message) {
+		messageType = websocket.TextMessage
+	}
+
+	return conn.write(messageType, message)
+}
+
+// Close - Closes a websocket connection made through this gateway, the socket's disconnect handler will be called
+func (s *BaseHttpGateway) Close(ctx context.Context, socket string, connectionId string) error {
+	conn, err := s.connection(socket, connectionId)
+	if err != nil {
+		return err
+	}
+
+	_ = conn.write(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+
+	return conn.conn.Close()
+}
+
+func (s *BaseHttpGateway) closeConnections() {
+	s.connectionLock.RLock()
+	defer s.connectionLock.RUnlock()
+
+	for _, conn := range s.connections {
+		_ = conn.write(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""))
+		_ = conn.conn.Close()
+	}
+}
+
+func (s *BaseHttpGateway) handleWebsocketEvent(pool worker.WorkerPool, evt *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	wrkr, err := pool.GetWorker(&worker.GetWorkerOptions{
+		Websocket: evt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wrkr.HandleWebsocketEvent(context.TODO(), evt)
+}
+
+// handleWebsocket - upgrades a request to a websocket connection on the socket named by the request path
+func (s *BaseHttpGateway) handleWebsocket(rc *fasthttp.RequestCtx, pool worker.WorkerPool) {
+	socket := strings.Trim(string(rc.Path()), "/")
+	connectionId := uuid.New().String()
+
+	query := make(map[string][]string)
+	rc.QueryArgs().VisitAll(func(key []byte, val []byte) {
+		query[string(key)] = append(query[string(key)], string(val))
+	})
+
+	handlesSocket := len(pool.GetWorkers(&worker.GetWorkerOptions{
+		Filter: func(w worker.Worker) bool {
+			for _, evt := range []triggers.WebsocketEventType{triggers.WebsocketEventType_Connect, triggers.WebsocketEventType_Disconnect, triggers.WebsocketEventType_Message} {
+				if w.HandlesWebsocketEvent(&triggers.WebsocketEvent{Socket: socket, Event: evt}) {
+					return true
+				}
+			}
+			return false
+		},
+	})) > 0
+	if !handlesSocket {
+		rc.Error(fmt.Sprintf("Socket %s not found", socket), 404)
+		return
+	}
+
+	connect := &triggers.WebsocketEvent{
+		Socket:       socket,
+		Event:        triggers.WebsocketEventType_Connect,
+		ConnectionID: connectionId,
+		Query:        query,
+	}
+
+	// Connect handlers are optional, but can reject the connection when present
+	if len(pool.GetWorkers(&worker.GetWorkerOptions{Websocket: connect})) > 0 {
+		resp, err := s.handleWebsocketEvent(pool, connect)
+		if errors.Is(err, worker.ErrSaturated) {
+			rc.Error("Service is at capacity", 503)
+			return
+		} else if err != nil {
+			rc.Error(fmt.Sprintf("Error handling websocket connection: %v", err), 500)
+			return
+		} else if !resp.Success {
+			rc.Error("Connection rejected", 403)
+			return
+		}
+	}
+
+	err := upgrader.Upgrade(rc, func(conn *websocket.Conn) {
+		wsConn := &websocketConnection{
+			socket: socket,
+			conn:   conn,
+		}
+
+		s.connectionLock.Lock()
+		s.connections[connectionId] = wsConn
+		s.connectionLock.Unlock()
+
+		defer func() {
+			s.connectionLock.Lock()
+			delete(s.connections, connectionId)
+			s.connectionLock.Unlock()
+
+			_ = conn.Close()
+
+			disconnect := &triggers.WebsocketEvent{
+				Socket:       socket,
+				Event:        triggers.WebsocketEventType_Disconnect,
+				ConnectionID: connectionId,
+			}
+
+			if len(pool.GetWorkers(&worker.GetWorkerOptions{Websocket: disconnect})) > 0 {
+				if _, err := s.handleWebsocketEvent(pool, disconnect); err != nil {
+					log.Default().Printf("error handling websocket disconnect: %v", err)
+				}
+			}
+		}()
+
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				// The connection has been closed
+				return
+			}
+
+			_, err = s.handleWebsocketEvent(pool, &triggers.WebsocketEvent{
+				Socket:       socket,
+				Event:        triggers.WebsocketEventType_Message,
+				ConnectionID: connectionId,
+				Body:         message,
+			})
+			if err != nil {
+				log.Default().Printf("error handling websocket message: %v", err)
+			}
+		}
+	})
+	if err != nil {
+		log.Default().Printf("error upgrading websocket connection: %v", err)
+	}
+}
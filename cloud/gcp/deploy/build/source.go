@@ -0,0 +1,249 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nitrictech/nitric/cloud/common/deploy/image"
+	"github.com/nitrictech/nitric/cloud/common/deploy/source"
+	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-gcp/sdk/v6/go/gcp/storage"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/cloudbuild/v1"
+	"google.golang.org/api/option"
+)
+
+const (
+	// Builds sources the same way as Cloud Run source deployments
+	packImage   = "gcr.io/k8s-skaffold/pack"
+	packBuilder = "gcr.io/buildpacks/builder:v1"
+
+	buildPollInterval = 5 * time.Second
+)
+
+// SourceBuild - builds a zip or directory execution unit source into an image using Cloud Build
+type SourceBuild struct {
+	pulumi.ResourceState
+
+	Name    string
+	Bucket  *storage.Bucket
+	Archive *storage.BucketObject
+	// Digest reference of the built image
+	ImageUri pulumi.StringOutput
+}
+
+type SourceBuildArgs struct {
+	Location  string
+	StackID   pulumi.StringInput
+	ProjectId string
+	Token     *oauth2.Token
+
+	// Repository the built image is pushed to (e.g. gcr.io/project/name)
+	ImageName     string
+	ExecutionUnit *v1.ExecutionUnit
+	// Directory archives of source directories are written to, it must exist until the deployment has finished
+	ArchiveDir string
+}
+
+func NewSourceBuild(ctx *pulumi.Context, name string, args *SourceBuildArgs, opts ...pulumi.ResourceOption) (*SourceBuild, error) {
+	res := &SourceBuild{
+		Name: name,
+	}
+
+	err := ctx.RegisterComponentResource("nitric:build:GCPSourceBuild", name, res, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	language, err := source.Language(args.ExecutionUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := sourceArchive(args.ArchiveDir, name, args.ExecutionUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Bucket, err = storage.NewBucket(ctx, name+"-source", &storage.BucketArgs{
+		Location:     pulumi.String(args.Location),
+		Project:      pulumi.String(args.ProjectId),
+		Labels:       common.Tags(ctx, args.StackID, name),
+		ForceDestroy: pulumi.Bool(true),
+	}, pulumi.Parent(res))
+	if err != nil {
+		return nil, err
+	}
+
+	res.Archive, err = storage.NewBucketObject(ctx, name+"-source", &storage.BucketObjectArgs{
+		Bucket: res.Bucket.Name,
+		Name:   pulumi.String("source.zip"),
+		Source: pulumi.NewFileAsset(archive),
+	}, pulumi.Parent(res))
+	if err != nil {
+		return nil, err
+	}
+
+	// Builds are tagged with the hash of the archive they were built from,
+	// so the source is only built again, and services only roll out a new revision, when it changes
+	res.ImageUri = pulumi.All(res.Archive.Bucket, res.Archive.OutputName, res.Archive.Md5hash).ApplyT(func(all []interface{}) (string, error) {
+		md5hash, err := base64.StdEncoding.DecodeString(all[2].(string))
+		if err != nil {
+			return "", errors.WithMessage(err, "invalid source archive hash")
+		}
+
+		imageName := fmt.Sprintf("%s:%s", args.ImageName, hex.EncodeToString(md5hash))
+
+		existing, err := image.ExistingImage(ctx.Context(), imageName, "oauth2accesstoken", args.Token.AccessToken)
+		if err != nil {
+			return "", err
+		}
+
+		if existing != "" {
+			return existing, nil
+		}
+
+		// Avoid building images during previews
+		if ctx.DryRun() {
+			return imageName, nil
+		}
+
+		return runBuild(ctx.Context(), &buildArgs{
+			ProjectId:  args.ProjectId,
+			Token:      args.Token,
+			Bucket:     all[0].(string),
+			Object:     all[1].(string),
+			ImageName:  imageName,
+			Language:   language,
+			Entrypoint: source.Entrypoint(args.ExecutionUnit),
+		})
+	}).(pulumi.StringOutput)
+
+	return res, ctx.RegisterResourceOutputs(res, pulumi.Map{
+		"name":     pulumi.String(res.Name),
+		"imageUri": res.ImageUri,
+	})
+}
+
+// sourceArchive - returns the path to a zip archive of the execution unit's source,
+// source directories are archived into the given directory
+func sourceArchive(dir string, name string, eu *v1.ExecutionUnit) (string, error) {
+	switch src := eu.Source.(type) {
+	case *v1.ExecutionUnit_Zip:
+		return src.Zip.Path, nil
+	case *v1.ExecutionUnit_Directory:
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("source-%s.zip", name)))
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+
+		if err := source.ZipDirectory(src.Directory.Path, f); err != nil {
+			return "", err
+		}
+
+		return f.Name(), nil
+	default:
+		return "", fmt.Errorf("gcp provider can only build execution units with a zip or directory source")
+	}
+}
+
+type buildArgs struct {
+	ProjectId string
+	Token     *oauth2.Token
+	Bucket    string
+	Object    string
+	ImageName string
+	Language  string
+	// Command that starts the built image, the language's default when empty
+	Entrypoint string
+}
+
+// runBuild - builds the uploaded source with language buildpacks, returning the digest reference of the image
+func runBuild(ctx context.Context, args *buildArgs) (string, error) {
+	svc, err := cloudbuild.NewService(ctx, option.WithTokenSource(oauth2.StaticTokenSource(args.Token)))
+	if err != nil {
+		return "", err
+	}
+
+	packArgs := []string{
+		"build", args.ImageName,
+		"--builder", packBuilder,
+		"--network", "cloudbuild",
+		"--path", ".",
+		"--env", "GOOGLE_RUNTIME=" + args.Language,
+	}
+	if args.Entrypoint != "" {
+		packArgs = append(packArgs, "--env", "GOOGLE_ENTRYPOINT="+args.Entrypoint)
+	}
+
+	op, err := svc.Projects.Builds.Create(args.ProjectId, &cloudbuild.Build{
+		Source: &cloudbuild.Source{
+			StorageSource: &cloudbuild.StorageSource{
+				Bucket: args.Bucket,
+				Object: args.Object,
+			},
+		},
+		Steps: []*cloudbuild.BuildStep{{
+			Name:       packImage,
+			Entrypoint: "pack",
+			Args:       packArgs,
+		}},
+		Images: []string{args.ImageName},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", errors.WithMessage(err, fmt.Sprintf("could not start build for image: %s", args.ImageName))
+	}
+
+	meta := &cloudbuild.BuildOperationMetadata{}
+	if err := json.Unmarshal(op.Metadata, meta); err != nil || meta.Build == nil {
+		return "", fmt.Errorf("could not read build details for image: %s", args.ImageName)
+	}
+
+	for {
+		build, err := svc.Projects.Builds.Get(args.ProjectId, meta.Build.Id).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		switch build.Status {
+		case "STATUS_UNKNOWN", "PENDING", "QUEUED", "WORKING":
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(buildPollInterval):
+			}
+		case "SUCCESS":
+			if build.Results == nil || len(build.Results.Images) == 0 {
+				return "", fmt.Errorf("build did not produce image: %s", args.ImageName)
+			}
+
+			return fmt.Sprintf("%s@%s", args.ImageName, build.Results.Images[0].Digest), nil
+		default:
+			return "", fmt.Errorf("build for image %s finished with status %s, see %s", args.ImageName, build.Status, build.LogUrl)
+		}
+	}
+}
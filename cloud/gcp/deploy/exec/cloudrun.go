@@ -1,7 +1,10 @@
 package exec
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/nitrictech/nitric/cloud/common/deploy/image"
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
@@ -38,6 +41,15 @@ type CloudRunnerArgs struct {
 
 var defaultConcurrency = 300
 
+var defaultMaxScale = 10
+
+// Cloud Run ingress settings for each execution unit ingress
+var ingressSettings = map[v1.Ingress]string{
+	v1.Ingress_IngressAll:                     "all",
+	v1.Ingress_IngressInternal:                "internal",
+	v1.Ingress_IngressInternalAndLoadBalancer: "internal-and-cloud-load-balancing",
+}
+
 func GetPerms() []string {
 	return []string{
 		"storage.buckets.list",
@@ -91,31 +103,60 @@ func NewCloudRunner(ctx *pulumi.Context, name string, args *CloudRunnerArgs, opt
 		})
 	}
 
-	for k, v := range args.EnvMap {
+	for _, k := range sortedKeys(args.EnvMap) {
 		env = append(env, cloudrun.ServiceTemplateSpecContainerEnvArgs{
 			Name:  pulumi.String(k),
-			Value: pulumi.String(v),
+			Value: pulumi.String(args.EnvMap[k]),
 		})
 	}
 
+	// User provided environment variables
+	userEnv, err := getUserEnvs(args.Compute.GetEnv(), args.EnvMap)
+	if err != nil {
+		return nil, errors.WithMessage(err, "env "+name)
+	}
+	env = append(env, userEnv...)
+
 	//Deploy the func
-	maxScale := 10
-	minScale := 0
+	concurrency := defaultConcurrency
+	if args.Compute.GetConcurrency() > 0 {
+		concurrency = int(args.Compute.GetConcurrency())
+	}
+
+	scaleAnnotations, err := getScaleAnnotations(args.Compute)
+	if err != nil {
+		return nil, errors.WithMessage(err, "scale "+name)
+	}
+
+	networkAnnotations, err := getNetworkAnnotations(args.Compute.GetNetwork())
+	if err != nil {
+		return nil, errors.WithMessage(err, "network "+name)
+	}
+
+	templateAnnotations := pulumi.StringMap{}
+	for k, v := range scaleAnnotations {
+		templateAnnotations[k] = pulumi.String(v)
+	}
+	for k, v := range networkAnnotations {
+		templateAnnotations[k] = pulumi.String(v)
+	}
 
 	res.Service, err = cloudrun.NewService(ctx, name, &cloudrun.ServiceArgs{
 		AutogenerateRevisionName: pulumi.BoolPtr(true),
 		Location:                 args.Location,
 		Project:                  pulumi.String(args.ProjectId),
+		Metadata: cloudrun.ServiceMetadataArgs{
+			Annotations: pulumi.StringMap{
+				"run.googleapis.com/ingress": pulumi.String(getIngress(args.Compute)),
+			},
+		},
 		Template: cloudrun.ServiceTemplateArgs{
 			Metadata: cloudrun.ServiceTemplateMetadataArgs{
-				Annotations: pulumi.StringMap{
-					"autoscaling.knative.dev/minScale": pulumi.Sprintf("%d", minScale),
-					"autoscaling.knative.dev/maxScale": pulumi.Sprintf("%d", maxScale),
-				},
+				Annotations: templateAnnotations,
 			},
 			Spec: cloudrun.ServiceTemplateSpecArgs{
 				ServiceAccountName:   args.ServiceAccount.Email,
-				ContainerConcurrency: pulumi.Int(defaultConcurrency),
+				ContainerConcurrency: pulumi.Int(concurrency),
 				Containers: cloudrun.ServiceTemplateSpecContainerArray{
 					cloudrun.ServiceTemplateSpecContainerArgs{
 						Envs:  env,
@@ -126,7 +167,7 @@ func NewCloudRunner(ctx *pulumi.Context, name string, args *CloudRunnerArgs, opt
 							},
 						},
 						Resources: cloudrun.ServiceTemplateSpecContainerResourcesArgs{
-							// Limits: pulumi.StringMap{"memory": pulumi.Sprintf("%dMi", args.Compute.Unit().Memory)},
+							Limits: pulumi.ToStringMap(getResourceLimits(args.Compute)),
 						},
 					},
 				},
@@ -217,3 +258,122 @@ func getCloudRunnerEnvs(args *CloudRunnerArgs) cloudrun.ServiceTemplateSpecConta
 		},
 	}
 }
+
+// Environment variables set by cloud run, or by the deployment in getCloudRunnerEnvs, which user provided variables can't replace
+var reservedEnvNames = map[string]bool{
+	"PORT":                  true,
+	"K_SERVICE":             true,
+	"K_REVISION":            true,
+	"K_CONFIGURATION":       true,
+	"MIN_WORKERS":           true,
+	"SERVICE_ACCOUNT_EMAIL": true,
+	"GCP_REGION":            true,
+	"DELAY_QUEUE_NAME":      true,
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// getUserEnvs - returns the user provided environment variables in a stable order,
+// rejecting variables that would replace those set by cloud run or the deployment
+func getUserEnvs(userEnv map[string]string, deploymentEnv map[string]string) (cloudrun.ServiceTemplateSpecContainerEnvArray, error) {
+	env := cloudrun.ServiceTemplateSpecContainerEnvArray{}
+
+	for _, k := range sortedKeys(userEnv) {
+		_, isDeploymentEnv := deploymentEnv[k]
+		if reservedEnvNames[k] || isDeploymentEnv || strings.HasPrefix(k, "NITRIC_") {
+			return nil, fmt.Errorf("environment variable %s is reserved", k)
+		}
+
+		env = append(env, cloudrun.ServiceTemplateSpecContainerEnvArgs{
+			Name:  pulumi.String(k),
+			Value: pulumi.String(userEnv[k]),
+		})
+	}
+
+	return env, nil
+}
+
+// getScaleAnnotations - returns the revision annotations setting the minimum and maximum number of instances
+func getScaleAnnotations(compute *v1.ExecutionUnit) (map[string]string, error) {
+	maxScale := defaultMaxScale
+	if compute.GetMaxInstances() > 0 {
+		maxScale = int(compute.GetMaxInstances())
+	}
+	minScale := int(compute.GetMinInstances())
+
+	if minScale > maxScale {
+		return nil, fmt.Errorf("min instances (%d) cannot be greater than max instances (%d)", minScale, maxScale)
+	}
+
+	return map[string]string{
+		"autoscaling.knative.dev/minScale": fmt.Sprint(minScale),
+		"autoscaling.knative.dev/maxScale": fmt.Sprint(maxScale),
+	}, nil
+}
+
+// getResourceLimits - returns the container's memory and cpu limits, cloud run's defaults apply to those not provided
+func getResourceLimits(compute *v1.ExecutionUnit) map[string]string {
+	limits := map[string]string{}
+	if compute.GetMemory() > 0 {
+		limits["memory"] = fmt.Sprintf("%dMi", compute.GetMemory())
+	}
+	if compute.GetCpu() > 0 {
+		limits["cpu"] = fmt.Sprintf("%dm", compute.GetCpu())
+	}
+
+	return limits
+}
+
+// getIngress - returns the cloud run ingress setting for the execution unit
+func getIngress(compute *v1.ExecutionUnit) string {
+	return ingressSettings[compute.GetIngress()]
+}
+
+// getNetworkAnnotations - returns the revision annotations attaching a cloud run service to a VPC,
+// using either a serverless VPC access connector or direct VPC egress through a subnet
+func getNetworkAnnotations(network *v1.NetworkAttachment) (map[string]string, error) {
+	annotations := map[string]string{}
+	if network == nil {
+		return annotations, nil
+	}
+
+	if network.Connector != "" && len(network.Subnets) > 0 {
+		return nil, fmt.Errorf("a network connector and subnets cannot both be provided")
+	}
+
+	if len(network.SecurityGroups) > 0 {
+		return nil, fmt.Errorf("cloud run services don't support security groups, use VPC firewall rules instead")
+	}
+
+	if len(network.Subnets) > 1 {
+		return nil, fmt.Errorf("cloud run services can only be attached to a single subnet")
+	}
+
+	if network.Connector != "" {
+		annotations["run.googleapis.com/vpc-access-connector"] = network.Connector
+	} else if len(network.Subnets) == 1 {
+		interfaces, err := json.Marshal([]map[string]string{{"subnetwork": network.Subnets[0]}})
+		if err != nil {
+			return nil, err
+		}
+
+		annotations["run.googleapis.com/network-interfaces"] = string(interfaces)
+	} else {
+		return annotations, nil
+	}
+
+	annotations["run.googleapis.com/vpc-access-egress"] = "private-ranges-only"
+	if network.AllTraffic {
+		annotations["run.googleapis.com/vpc-access-egress"] = "all-traffic"
+	}
+
+	return annotations, nil
+}
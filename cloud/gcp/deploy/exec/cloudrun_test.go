@@ -0,0 +1,191 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exec
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pulumi/pulumi-gcp/sdk/v6/go/gcp/cloudrun"
+	"github.com/pulumi/pulumi-gcp/sdk/v6/go/gcp/serviceaccount"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+// envNames - returns the names of environment variables in order
+func envNames(env cloudrun.ServiceTemplateSpecContainerEnvArray) []string {
+	names := []string{}
+	for _, e := range env {
+		names = append(names, string(e.(cloudrun.ServiceTemplateSpecContainerEnvArgs).Name.(pulumi.String)))
+	}
+
+	return names
+}
+
+var _ = Describe("CloudRun", func() {
+	Context("getUserEnvs", func() {
+		It("should return user provided variables sorted by name", func() {
+			env, err := getUserEnvs(map[string]string{"ZONE": "a", "API_KEY": "b", "LOG_LEVEL": "c"}, map[string]string{})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(envNames(env)).To(Equal([]string{"API_KEY", "LOG_LEVEL", "ZONE"}))
+		})
+
+		reserved := []string{"PORT", "K_SERVICE", "NITRIC_ENVIRONMENT", "NITRIC_CUSTOM", "DELAY_QUEUE_NAME", "DEPLOYMENT_VAR"}
+		for _, name := range reserved {
+			name := name
+			It(fmt.Sprintf("should reject the reserved variable %s", name), func() {
+				_, err := getUserEnvs(map[string]string{name: "value"}, map[string]string{"DEPLOYMENT_VAR": "value"})
+				Expect(err).Should(MatchError(ContainSubstring(name)))
+			})
+		}
+
+		It("should reserve every variable set by the deployment", func() {
+			for _, name := range envNames(getCloudRunnerEnvs(&CloudRunnerArgs{
+				Location:       pulumi.String("us-central1"),
+				StackID:        pulumi.String("stack"),
+				ServiceAccount: &serviceaccount.Account{},
+			})) {
+				_, err := getUserEnvs(map[string]string{name: "value"}, map[string]string{})
+				Expect(err).Should(HaveOccurred(), name)
+			}
+		})
+	})
+
+	Context("getScaleAnnotations", func() {
+		cases := []struct {
+			name     string
+			min, max int32
+			minScale string
+			maxScale string
+		}{
+			{"defaults", 0, 0, "0", fmt.Sprint(defaultMaxScale)},
+			{"a minimum", 2, 0, "2", fmt.Sprint(defaultMaxScale)},
+			{"a maximum", 0, 50, "0", "50"},
+			{"a minimum and maximum", 3, 3, "3", "3"},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should scale with %s", c.name), func() {
+				annotations, err := getScaleAnnotations(&v1.ExecutionUnit{MinInstances: c.min, MaxInstances: c.max})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(annotations).To(Equal(map[string]string{
+					"autoscaling.knative.dev/minScale": c.minScale,
+					"autoscaling.knative.dev/maxScale": c.maxScale,
+				}))
+			})
+		}
+
+		It("should reject a minimum greater than the maximum", func() {
+			_, err := getScaleAnnotations(&v1.ExecutionUnit{MinInstances: 5, MaxInstances: 2})
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should reject a minimum greater than the default maximum", func() {
+			_, err := getScaleAnnotations(&v1.ExecutionUnit{MinInstances: int32(defaultMaxScale + 1)})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("getResourceLimits", func() {
+		cases := []struct {
+			name   string
+			unit   *v1.ExecutionUnit
+			limits map[string]string
+		}{
+			{"no limits", &v1.ExecutionUnit{}, map[string]string{}},
+			{"memory", &v1.ExecutionUnit{Memory: 512}, map[string]string{"memory": "512Mi"}},
+			{"cpu", &v1.ExecutionUnit{Cpu: 2000}, map[string]string{"cpu": "2000m"}},
+			{"memory and cpu", &v1.ExecutionUnit{Memory: 1024, Cpu: 500}, map[string]string{"memory": "1024Mi", "cpu": "500m"}},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should limit %s", c.name), func() {
+				Expect(getResourceLimits(c.unit)).To(Equal(c.limits))
+			})
+		}
+	})
+
+	Context("getIngress", func() {
+		cases := map[v1.Ingress]string{
+			v1.Ingress_IngressAll:                     "all",
+			v1.Ingress_IngressInternal:                "internal",
+			v1.Ingress_IngressInternalAndLoadBalancer: "internal-and-cloud-load-balancing",
+		}
+
+		for ingress, setting := range cases {
+			ingress, setting := ingress, setting
+			It(fmt.Sprintf("should map %s to %s", ingress, setting), func() {
+				Expect(getIngress(&v1.ExecutionUnit{Ingress: ingress})).To(Equal(setting))
+			})
+		}
+	})
+
+	Context("getNetworkAnnotations", func() {
+		cases := []struct {
+			name        string
+			network     *v1.NetworkAttachment
+			annotations map[string]string
+		}{
+			{"no network", nil, map[string]string{}},
+			{"an empty network", &v1.NetworkAttachment{}, map[string]string{}},
+			{
+				"a connector",
+				&v1.NetworkAttachment{Connector: "projects/p/locations/l/connectors/c"},
+				map[string]string{
+					"run.googleapis.com/vpc-access-connector": "projects/p/locations/l/connectors/c",
+					"run.googleapis.com/vpc-access-egress":    "private-ranges-only",
+				},
+			},
+			{
+				"a subnet routing all traffic",
+				&v1.NetworkAttachment{Subnets: []string{"private"}, AllTraffic: true},
+				map[string]string{
+					"run.googleapis.com/network-interfaces": `[{"subnetwork":"private"}]`,
+					"run.googleapis.com/vpc-access-egress":  "all-traffic",
+				},
+			},
+		}
+
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("should attach %s", c.name), func() {
+				annotations, err := getNetworkAnnotations(c.network)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(annotations).To(Equal(c.annotations))
+			})
+		}
+
+		invalid := []struct {
+			name    string
+			network *v1.NetworkAttachment
+		}{
+			{"a connector and subnets", &v1.NetworkAttachment{Connector: "c", Subnets: []string{"s"}}},
+			{"several subnets", &v1.NetworkAttachment{Subnets: []string{"a", "b"}}},
+			{"security groups", &v1.NetworkAttachment{Subnets: []string{"s"}, SecurityGroups: []string{"sg-1234"}}},
+		}
+
+		for _, c := range invalid {
+			c := c
+			It(fmt.Sprintf("should reject %s", c.name), func() {
+				_, err := getNetworkAnnotations(c.network)
+				Expect(err).Should(HaveOccurred())
+			})
+		}
+	})
+})
@@ -36,6 +36,7 @@ import (
 	common "github.com/nitrictech/nitric/cloud/common/deploy/tags"
 	"github.com/nitrictech/nitric/cloud/common/deploy/utils"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/exec"
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
 )
 
@@ -45,6 +46,8 @@ type ApiGatewayArgs struct {
 	OpenAPISpec         *openapi2.T
 	Functions           map[string]*exec.CloudRunner
 	SecurityDefinitions map[string]*v1.ApiSecurityDefinition
+	Cors                *v1.ApiCorsDefinition
+	Domains             []*deploy.DomainMapping
 }
 
 type ApiGateway struct {
@@ -53,6 +56,7 @@ type ApiGateway struct {
 	Name    string
 	Gateway *apigateway.Gateway
 	Api     *apigateway.Api
+	Domains *DomainMappings
 }
 
 type nameUrlPair struct {
@@ -175,12 +179,43 @@ func NewApiGateway(ctx *pulumi.Context, name string, args *ApiGatewayArgs, opts
 		}))
 	}
 
+	res.Api, err = apigateway.NewApi(ctx, name, &apigateway.ApiArgs{
+		ApiId:  pulumi.String(name),
+		Labels: common.Tags(ctx, args.StackID, name),
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "api "+name)
+	}
+
 	// Now we need to create the document provided and interpolate the deployed service targets
 	// i.e. their Urls...
 	// Replace Nitric API Extensions with google api gateway extensions
-	doc := pulumi.All(nameArnPairs...).ApplyT(func(pairs []interface{}) (string, error) {
+	doc := pulumi.All(append([]interface{}{res.Api.ManagedService}, nameArnPairs...)...).ApplyT(func(all []interface{}) (string, error) {
+		managedService := all[0].(string)
+		pairs := all[1:]
 		naps := make(map[string]string)
 
+		if args.Cors != nil {
+			// API Gateway rejects OPTIONS requests unless CORS is allowed for the managed service,
+			// preflight requests are then forwarded to the services which answer them using the api CORS configuration
+			if args.OpenAPISpec.Extensions == nil {
+				args.OpenAPISpec.Extensions = make(map[string]interface{})
+			}
+
+			args.OpenAPISpec.Extensions["x-google-endpoints"] = []map[string]interface{}{
+				{
+					"name":      managedService,
+					"allowCors": true,
+				},
+			}
+
+			for _, p := range args.OpenAPISpec.Paths {
+				if p.Options == nil {
+					p.Options = preflightOperation(p)
+				}
+			}
+		}
+
 		for _, p := range pairs {
 			if pair, ok := p.(nameUrlPair); ok {
 				naps[pair.name] = pair.invokeUrl
@@ -208,14 +243,6 @@ func NewApiGateway(ctx *pulumi.Context, name string, args *ApiGatewayArgs, opts
 		return base64.StdEncoding.EncodeToString(b), nil
 	}).(pulumi.StringOutput)
 
-	res.Api, err = apigateway.NewApi(ctx, name, &apigateway.ApiArgs{
-		ApiId:  pulumi.String(name),
-		Labels: common.Tags(ctx, args.StackID, name),
-	}, opts...)
-	if err != nil {
-		return nil, errors.WithMessage(err, "api "+name)
-	}
-
 	invoker, err := serviceaccount.NewAccount(ctx, name+"-acct", &serviceaccount.AccountArgs{
 		AccountId: pulumi.String(utils.StringTrunc(name, 30-5) + "-acct"),
 	}, opts...)
@@ -277,6 +304,17 @@ func NewApiGateway(ctx *pulumi.Context, name string, args *ApiGatewayArgs, opts
 	url := res.Gateway.DefaultHostname.ApplyT(func(hn string) string { return "https://" + hn })
 	ctx.Export("api:"+name, url)
 
+	if len(args.Domains) > 0 {
+		res.Domains, err = NewDomainMappings(ctx, name+"-domains", &DomainMappingsArgs{
+			ProjectId: args.ProjectId,
+			Gateway:   res,
+			Domains:   args.Domains,
+		}, opts...)
+		if err != nil {
+			return nil, errors.WithMessage(err, "api domains")
+		}
+	}
+
 	return res, nil
 }
 
@@ -301,6 +339,45 @@ func keepOperation(opExt map[string]interface{}) (string, bool) {
 	return name, true
 }
 
+// preflightOperation - an OPTIONS operation targeting the same service as the other operations of the path
+func preflightOperation(p *openapi2.PathItem) *openapi2.Operation {
+	for _, m := range []string{http.MethodGet, http.MethodPatch, http.MethodDelete, http.MethodPost, http.MethodPut} {
+		op := p.GetOperation(m)
+		if op == nil {
+			continue
+		}
+
+		if _, ok := keepOperation(op.Extensions); !ok {
+			continue
+		}
+
+		params := openapi2.Parameters{}
+		for _, param := range op.Parameters {
+			if param.In == "path" {
+				params = append(params, param)
+			}
+		}
+
+		preflight := &openapi2.Operation{
+			Parameters: params,
+			Responses: map[string]*openapi2.Response{
+				"204": {},
+			},
+			Extensions: map[string]interface{}{
+				"x-nitric-target": op.Extensions["x-nitric-target"],
+			},
+		}
+
+		if op.OperationID != "" {
+			preflight.OperationID = op.OperationID + "-preflight"
+		}
+
+		return preflight
+	}
+
+	return nil
+}
+
 func gcpOperation(op *openapi2.Operation, urls map[string]string) *openapi2.Operation {
 	if op == nil {
 		return nil
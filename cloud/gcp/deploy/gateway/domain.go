@@ -0,0 +1,238 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-gcp/sdk/v6/go/gcp/compute"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+)
+
+type DomainMappingsArgs struct {
+	ProjectId pulumi.StringInput
+	Gateway   *ApiGateway
+	Domains   []*deploy.DomainMapping
+}
+
+// DomainMappings - serves an api gateway from custom domains through a global external HTTPS load balancer
+type DomainMappings struct {
+	pulumi.ResourceState
+
+	Name           string
+	Address        *compute.GlobalAddress
+	BackendService *compute.BackendService
+	UrlMap         *compute.URLMap
+	Proxy          *compute.TargetHttpsProxy
+	ForwardingRule *compute.GlobalForwardingRule
+}
+
+// basePath - the normalised base path of a domain mapping, empty when the api is served from the root of the domain
+func basePath(domain *deploy.DomainMapping) string {
+	path := strings.Trim(domain.BasePath, "/")
+	if path == "" {
+		return ""
+	}
+
+	return "/" + path
+}
+
+// DomainUrl - the url an api is served from on a custom domain
+func DomainUrl(domain *deploy.DomainMapping) string {
+	return "https://" + domain.Hostname + basePath(domain)
+}
+
+// ApiUrls - the custom url of each api in the spec with domain mappings, apis are served from the url of their first domain
+func ApiUrls(spec *deploy.Spec) map[string]string {
+	urls := map[string]string{}
+
+	for _, res := range spec.Resources {
+		if api, ok := res.Config.(*deploy.Resource_Api); ok && len(api.Api.Domains) > 0 {
+			urls[res.Name] = DomainUrl(api.Api.Domains[0])
+		}
+	}
+
+	return urls
+}
+
+func NewDomainMappings(ctx *pulumi.Context, name string, args *DomainMappingsArgs, opts ...pulumi.ResourceOption) (*DomainMappings, error) {
+	res := &DomainMappings{Name: name}
+
+	err := ctx.RegisterComponentResource("nitric:api:GcpDomainMappings", name, res, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, pulumi.Parent(res))
+
+	neg, err := compute.NewRegionNetworkEndpointGroup(ctx, name+"-neg", &compute.RegionNetworkEndpointGroupArgs{
+		Project:             args.ProjectId,
+		Region:              args.Gateway.Gateway.Region,
+		NetworkEndpointType: pulumi.String("SERVERLESS"),
+		ServerlessDeployment: compute.RegionNetworkEndpointGroupServerlessDeploymentArgs{
+			Platform: pulumi.String("apigateway.googleapis.com"),
+			Resource: args.Gateway.Gateway.GatewayId,
+		},
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain network endpoint group "+name)
+	}
+
+	res.BackendService, err = compute.NewBackendService(ctx, name+"-backend", &compute.BackendServiceArgs{
+		Project:             args.ProjectId,
+		LoadBalancingScheme: pulumi.String("EXTERNAL_MANAGED"),
+		Backends: compute.BackendServiceBackendArray{
+			compute.BackendServiceBackendArgs{
+				Group: neg.SelfLink,
+			},
+		},
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain backend service "+name)
+	}
+
+	hostRules := compute.URLMapHostRuleArray{}
+	pathMatchers := compute.URLMapPathMatcherArray{}
+	certificates := pulumi.StringArray{}
+
+	for i, domain := range args.Domains {
+		if domain.Hostname == "" {
+			return nil, fmt.Errorf("domain mapping for api %s does not have a hostname", name)
+		}
+
+		matcher := fmt.Sprintf("domain-%d", i)
+
+		hostRules = append(hostRules, compute.URLMapHostRuleArgs{
+			Hosts:       pulumi.StringArray{pulumi.String(domain.Hostname)},
+			PathMatcher: pulumi.String(matcher),
+		})
+
+		pathMatchers = append(pathMatchers, basePathMatcher(matcher, basePath(domain), res.BackendService))
+
+		switch cert := domain.Certificate.(type) {
+		case *deploy.DomainMapping_CertificateRef:
+			certificates = append(certificates, pulumi.String(cert.CertificateRef))
+		case *deploy.DomainMapping_ManagedCertificate:
+			if !cert.ManagedCertificate {
+				return nil, fmt.Errorf("domain %s does not have a certificate", domain.Hostname)
+			}
+
+			managed, err := compute.NewManagedSslCertificate(ctx, fmt.Sprintf("%s-cert-%d", name, i), &compute.ManagedSslCertificateArgs{
+				Project: args.ProjectId,
+				Managed: compute.ManagedSslCertificateManagedArgs{
+					Domains: pulumi.StringArray{pulumi.String(domain.Hostname)},
+				},
+			}, opts...)
+			if err != nil {
+				return nil, errors.WithMessage(err, "domain certificate "+domain.Hostname)
+			}
+
+			certificates = append(certificates, managed.SelfLink)
+		default:
+			return nil, fmt.Errorf("domain %s does not have a certificate", domain.Hostname)
+		}
+	}
+
+	res.UrlMap, err = compute.NewURLMap(ctx, name+"-urlmap", &compute.URLMapArgs{
+		Project:        args.ProjectId,
+		DefaultService: res.BackendService.SelfLink,
+		HostRules:      hostRules,
+		PathMatchers:   pathMatchers,
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain url map "+name)
+	}
+
+	res.Proxy, err = compute.NewTargetHttpsProxy(ctx, name+"-proxy", &compute.TargetHttpsProxyArgs{
+		Project:         args.ProjectId,
+		UrlMap:          res.UrlMap.SelfLink,
+		SslCertificates: certificates,
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain https proxy "+name)
+	}
+
+	res.Address, err = compute.NewGlobalAddress(ctx, name+"-address", &compute.GlobalAddressArgs{
+		Project: args.ProjectId,
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain address "+name)
+	}
+
+	res.ForwardingRule, err = compute.NewGlobalForwardingRule(ctx, name+"-https", &compute.GlobalForwardingRuleArgs{
+		Project:             args.ProjectId,
+		Target:              res.Proxy.SelfLink,
+		IpAddress:           res.Address.Address,
+		PortRange:           pulumi.String("443"),
+		LoadBalancingScheme: pulumi.String("EXTERNAL_MANAGED"),
+	}, opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "domain forwarding rule "+name)
+	}
+
+	// DNS records for the domains need to point to this address
+	ctx.Export("api:"+args.Gateway.Name+":address", res.Address.Address)
+
+	for _, domain := range args.Domains {
+		ctx.Export("api:"+args.Gateway.Name+":"+domain.Hostname, pulumi.String(DomainUrl(domain)))
+	}
+
+	return res, ctx.RegisterResourceOutputs(res, pulumi.Map{
+		"name":    pulumi.String(res.Name),
+		"address": res.Address.Address,
+	})
+}
+
+// basePathMatcher - serves the api from the base path of a domain, requests outside of the base path are redirected to it
+func basePathMatcher(name string, basePath string, backend *compute.BackendService) compute.URLMapPathMatcherArgs {
+	if basePath == "" {
+		return compute.URLMapPathMatcherArgs{
+			Name:           pulumi.String(name),
+			DefaultService: backend.SelfLink,
+		}
+	}
+
+	return compute.URLMapPathMatcherArgs{
+		Name: pulumi.String(name),
+		DefaultUrlRedirect: compute.URLMapPathMatcherDefaultUrlRedirectArgs{
+			PathRedirect:         pulumi.String(basePath),
+			RedirectResponseCode: pulumi.String("FOUND"),
+			StripQuery:           pulumi.Bool(true),
+		},
+		RouteRules: compute.URLMapPathMatcherRouteRuleArray{
+			compute.URLMapPathMatcherRouteRuleArgs{
+				Priority: pulumi.Int(1),
+				MatchRules: compute.URLMapPathMatcherRouteRuleMatchRuleArray{
+					compute.URLMapPathMatcherRouteRuleMatchRuleArgs{
+						FullPathMatch: pulumi.String(basePath),
+					},
+					compute.URLMapPathMatcherRouteRuleMatchRuleArgs{
+						PrefixMatch: pulumi.String(basePath + "/"),
+					},
+				},
+				Service: backend.SelfLink,
+				RouteAction: compute.URLMapPathMatcherRouteRuleRouteActionArgs{
+					UrlRewrite: compute.URLMapPathMatcherRouteRuleRouteActionUrlRewriteArgs{
+						PathPrefixRewrite: pulumi.String("/"),
+					},
+				},
+			},
+		},
+	}
+}
@@ -42,6 +42,8 @@ var requiredServices = []string{
 	"cloudtasks.googleapis.com",
 	// Enable monitoring API
 	"monitoring.googleapis.com",
+	// Enable Cloud Build API (execution unit source builds)
+	"cloudbuild.googleapis.com",
 }
 
 // Creates a new GCP Project
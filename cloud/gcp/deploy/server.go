@@ -17,9 +17,10 @@
 package deploy
 
 import (
+	"embed"
 	"fmt"
-
-	_ "embed"
+	"io/fs"
+	"strings"
 
 	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
 )
@@ -31,14 +32,37 @@ type DeployServer struct {
 // Embeds the runtime directly into the deploytime binary
 // This way the versions will always match as they're always built and versioned together (as a single artifact)
 // This should also help with docker build speeds as the runtime has already been "downloaded"
+// A runtime is embedded for each supported architecture (e.g. runtime-gcp-amd64, runtime-gcp-arm64)
 //
-//go:embed runtime-gcp
-var runtime []byte
+//go:embed runtime-gcp-*
+var runtimeFiles embed.FS
+
+// embeddedRuntimes - returns the embedded runtime binaries keyed by their platform (e.g. linux/amd64)
+func embeddedRuntimes() (map[string][]byte, error) {
+	files, err := fs.Glob(runtimeFiles, "runtime-gcp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	platformRuntimes := map[string][]byte{}
+	for _, file := range files {
+		runtime, err := runtimeFiles.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		platformRuntimes["linux/"+strings.TrimPrefix(file, "runtime-gcp-")] = runtime
+	}
+
+	return platformRuntimes, nil
+}
 
 type StackDetails struct {
 	Project string
 	Stack   string
 	Region  string
+	// Wrap execution unit images without a docker daemon
+	DaemonlessImages bool
 }
 
 // Read nitric attributes from the provided deployment attributes
@@ -62,9 +86,10 @@ func getStackDetailsFromAttributes(attributes map[string]string) (*StackDetails,
 	}
 
 	return &StackDetails{
-		Project: project,
-		Stack:   stack,
-		Region:  region,
+		Project:          project,
+		Stack:            stack,
+		Region:           region,
+		DaemonlessImages: attributes["x-nitric-daemonless-images"] == "true",
 	}, nil
 }
 
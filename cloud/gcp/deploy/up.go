@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -9,10 +10,13 @@ import (
 	"github.com/nitrictech/nitric/cloud/common/deploy/image"
 	"github.com/nitrictech/nitric/cloud/common/deploy/utils"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/bucket"
+	"github.com/nitrictech/nitric/cloud/gcp/deploy/build"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/events"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/exec"
+	"github.com/nitrictech/nitric/cloud/gcp/deploy/gateway"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/policy"
 	"github.com/nitrictech/nitric/cloud/gcp/deploy/queue"
+	"github.com/nitrictech/nitric/cloud/gcp/runtime/core"
 	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
 	"github.com/pkg/errors"
@@ -56,6 +60,13 @@ func (d *DeployServer) Up(request *deploy.DeployUpRequest, stream deploy.DeployS
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, err.Error())
 	}
+
+	// Source directories are archived for upload during the deployment, so the archives are removed once it has finished
+	archiveDir, err := os.MkdirTemp("", "nitric-sources-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(archiveDir)
 	
 	pulumiStack, err := auto.UpsertStackInlineSource(context.TODO(), details.Stack, details.Project, func(ctx *pulumi.Context) error {
 		stackRandId, err := random.NewRandomString(ctx, fmt.Sprintf("%s-stack-name", ctx.Stack()), &random.RandomStringArgs{
@@ -115,6 +126,22 @@ func (d *DeployServer) Up(request *deploy.DeployUpRequest, stream deploy.DeployS
 			return err
 		}
 
+		runtimes, err := embeddedRuntimes()
+		if err != nil {
+			return err
+		}
+
+		// Provide custom api urls to the runtime for resource details
+		envMap := map[string]string{}
+		if apiUrls := gateway.ApiUrls(request.Spec); len(apiUrls) > 0 {
+			apiUrlsJson, err := json.Marshal(apiUrls)
+			if err != nil {
+				return err
+			}
+
+			envMap[core.ApiUrlsEnv] = string(apiUrlsJson)
+		}
+
 		var execs map[string]*exec.CloudRunner
 
 		baseCustomRoleId, err := random.NewRandomString(ctx, fmt.Sprintf("%s-base-role", details.Stack), &random.RandomStringArgs{
@@ -161,26 +188,60 @@ func (d *DeployServer) Up(request *deploy.DeployUpRequest, stream deploy.DeployS
 		for _, res := range request.Spec.Resources {
 			switch eu := res.Config.(type) {
 			case *deploy.Resource_ExecutionUnit:
-				if eu.ExecutionUnit.GetImage() == nil {
-					return fmt.Errorf("gcp provider can only deploy execution with an image source")
-				}
+				imageArgs := &image.ImageArgs{}
+				imageName := res.Name
+
+				switch eu.ExecutionUnit.Source.(type) {
+				case *deploy.ExecutionUnit_Image:
+					if eu.ExecutionUnit.GetImage().GetUri() == "" {
+						return fmt.Errorf("gcp provider can only deploy execution with an image source")
+					}
+
+					// Get the image name:tag from the uri
+					imageUriSplit := strings.Split(eu.ExecutionUnit.GetImage().GetUri(), "/")
+					imageName = imageUriSplit[len(imageUriSplit)-1]
+
+					imageArgs.SourceImage = eu.ExecutionUnit.GetImage().GetUri()
+					imageArgs.Daemonless = details.DaemonlessImages
+				case *deploy.ExecutionUnit_Zip, *deploy.ExecutionUnit_Directory:
+					// Buildpacks only produce linux/amd64 images
+					for _, platform := range eu.ExecutionUnit.Platforms {
+						if platform != image.DefaultPlatform {
+							return fmt.Errorf("gcp provider can only build execution unit sources for %s", image.DefaultPlatform)
+						}
+					}
+
+					sourceBuild, err := build.NewSourceBuild(ctx, res.Name, &build.SourceBuildArgs{
+						Location:      details.Region,
+						StackID:       stackID,
+						ProjectId:     details.Project,
+						Token:         authToken,
+						ImageName:     fmt.Sprintf("gcr.io/%s/%s-source", details.Project, res.Name),
+						ExecutionUnit: eu.ExecutionUnit,
+						ArchiveDir:    archiveDir,
+					})
+					if err != nil {
+						return err
+					}
 
-				if eu.ExecutionUnit.GetImage().GetUri() == "" {
-					return fmt.Errorf("gcp provider can only deploy execution with an image source")
+					// The built image is already in the registry, so it is wrapped directly
+					// using the deployment's credentials, as the deployer may not have a gcr.io credential helper
+					imageArgs.SourceImageInput = sourceBuild.ImageUri
+					imageArgs.SourceUsername = pulumi.String("oauth2accesstoken")
+					imageArgs.SourcePassword = pulumi.String(authToken.AccessToken)
+					imageArgs.Daemonless = true
+				default:
+					return fmt.Errorf("gcp provider can only deploy execution units with an image, zip or directory source")
 				}
 
-				// Get the image name:tag from the uri
-				imageUriSplit := strings.Split(eu.ExecutionUnit.GetImage().GetUri(), "/")
-				imageName := imageUriSplit[len(imageUriSplit) - 1]
-
-				image, err := image.NewImage(ctx, res.Name, &image.ImageArgs{
-					SourceImage: eu.ExecutionUnit.GetImage().GetUri(),
-					RepositoryUrl: pulumi.Sprintf("gcr.io/%s/%s", details.Project, imageName),
-					Username:      pulumi.String("oauth2accesstoken"),
-					Password:      pulumi.String(authToken.AccessToken),
-					Server:        pulumi.String("https://gcr.io"),
-					Runtime: runtime,
-				})
+				imageArgs.RepositoryUrl = pulumi.Sprintf("gcr.io/%s/%s", details.Project, imageName)
+				imageArgs.Username = pulumi.String("oauth2accesstoken")
+				imageArgs.Password = pulumi.String(authToken.AccessToken)
+				imageArgs.Server = pulumi.String("https://gcr.io")
+				imageArgs.Runtimes = runtimes
+				imageArgs.Platforms = eu.ExecutionUnit.Platforms
+
+				image, err := image.NewImage(ctx, res.Name, imageArgs)
 				if err != nil {
 					return err
 				}
@@ -200,7 +261,7 @@ func (d *DeployServer) Up(request *deploy.DeployUpRequest, stream deploy.DeployS
 					Topics:         map[string]*pubsub.Topic{},
 					Compute:        res.GetExecutionUnit(),
 					Image:          image,
-					EnvMap: map[string]string{},
+					EnvMap: envMap,
 					DelayQueue:     topicDelayQueue,
 					ServiceAccount: sa,
 					BaseComputeRole: baseComputeRole,
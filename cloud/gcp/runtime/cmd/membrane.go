@@ -75,6 +75,7 @@ func main() {
 
 	membraneOpts.ResourcesPlugin = provider
 	membraneOpts.CreateTracerProvider = newTraceProvider
+	membraneOpts.CreateMeterProvider = newMeterProvider
 
 	m, err := membrane.New(membraneOpts)
 	if err != nil {
@@ -23,13 +23,16 @@ import (
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 	"github.com/nitrictech/nitric/core/pkg/span"
+	"github.com/nitrictech/nitric/core/pkg/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -37,21 +40,18 @@ func newTraceProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
 	span.FunctionName = os.Getenv("K_SERVICE")
 	span.UseFuncNameAsSpanName = false
 
-	exp, err := otlptracegrpc.New(ctx, otlptracegrpc.WithInsecure())
+	// Export to the local collector unless an OTLP endpoint has been configured
+	opts := []otlptracegrpc.Option{}
+	if !telemetry.EndpointConfigured(telemetry.Signal_Traces) {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exp, err := otlptracegrpc.New(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithDetectors(gcp.NewDetector()),
-		resource.WithAttributes(
-			semconv.CloudProviderGCP,
-			semconv.CloudPlatformGCPCloudRun,
-			attribute.Key("component").String("Nitric membrane"),
-			semconv.ServiceNameKey.String(span.FunctionName),
-			semconv.ServiceNamespaceKey.String(utils.GetEnv("NITRIC_STACK", "")),
-		),
-	)
+	res, err := newResource(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -73,3 +73,38 @@ func newTraceProvider(ctx context.Context) (*sdktrace.TracerProvider, error) {
 		sdktrace.WithBatcher(exp),
 	), nil
 }
+
+func newResource(ctx context.Context) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithDetectors(gcp.NewDetector()),
+		resource.WithAttributes(
+			semconv.CloudProviderGCP,
+			semconv.CloudPlatformGCPCloudRun,
+			attribute.Key("component").String("Nitric membrane"),
+			semconv.ServiceNameKey.String(os.Getenv("K_SERVICE")),
+			semconv.ServiceNamespaceKey.String(utils.GetEnv("NITRIC_STACK", "")),
+		),
+	)
+}
+
+func newMeterProvider(ctx context.Context) (*sdkmetric.MeterProvider, error) {
+	opts := []otlpmetricgrpc.Option{}
+	if !telemetry.EndpointConfigured(telemetry.Signal_Metrics) {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	exp, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := newResource(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+		sdkmetric.WithResource(res),
+	), nil
+}
@@ -16,13 +16,24 @@ package core
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	apigateway "cloud.google.com/go/apigateway/apiv1"
 	"cloud.google.com/go/apigateway/apiv1/apigatewaypb"
-
+	"cloud.google.com/go/firestore"
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+
+	ifaces_gcloud_secret "github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_secret"
+	ifaces_gcloud_storage "github.com/nitrictech/nitric/cloud/gcp/ifaces/gcloud_storage"
+	ifaces_pubsub "github.com/nitrictech/nitric/cloud/gcp/ifaces/pubsub"
 	"github.com/nitrictech/nitric/core/pkg/providers/common"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
@@ -35,11 +46,18 @@ type GcpProvider interface {
 }
 
 type gcpProviderImpl struct {
-	apiClient           *apigateway.Client
+	apiClient *apigateway.Client
+	// clients below are only needed to look up resource details, so they are created on first use
+	storageClient       ifaces_gcloud_storage.StorageClient
+	secretClient        ifaces_gcloud_secret.SecretManagerClient
+	pubsubClient        ifaces_pubsub.PubsubClient
+	firestoreClient     *firestore.Client
 	stackName           string
 	serviceAccountEmail string
 	projectID           string
 	region              string
+	// custom domain urls of apis, keyed by api name
+	apiUrls map[string]string
 }
 
 var _ common.ResourceService = &gcpProviderImpl{}
@@ -53,6 +71,9 @@ const (
 	projectIdUri           = "http://metadata.google.internal/computeMetadata/v1/project/project-id"
 )
 
+// ApiUrlsEnv - environment variable providing the custom urls of apis to the runtime, as a JSON object of api names to urls
+const ApiUrlsEnv = "NITRIC_API_URLS"
+
 func createMetadataRequest(uri string) (*http.Request, error) {
 	req, err := http.NewRequest("GET", projectIdUri, nil)
 	if err != nil {
@@ -73,6 +94,85 @@ func filter(stack string, name string) string {
 	return fmt.Sprintf("labels.x-nitric-stack:%s AND labels.x-nitric-name:%s", stack, name)
 }
 
+func (g *gcpProviderImpl) getStorageClient(ctx context.Context) (ifaces_gcloud_storage.StorageClient, error) {
+	if g.storageClient == nil {
+		client, err := storage.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		g.storageClient = ifaces_gcloud_storage.AdaptStorageClient(client)
+	}
+
+	return g.storageClient, nil
+}
+
+func (g *gcpProviderImpl) getSecretClient(ctx context.Context) (ifaces_gcloud_secret.SecretManagerClient, error) {
+	if g.secretClient == nil {
+		client, err := ifaces_gcloud_secret.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		g.secretClient = client
+	}
+
+	return g.secretClient, nil
+}
+
+func (g *gcpProviderImpl) getPubsubClient(ctx context.Context) (ifaces_pubsub.PubsubClient, error) {
+	if g.pubsubClient == nil {
+		projectName, err := g.GetProjectID()
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := pubsub.NewClient(ctx, projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		g.pubsubClient = ifaces_pubsub.AdaptPubsubClient(client)
+	}
+
+	return g.pubsubClient, nil
+}
+
+func (g *gcpProviderImpl) getFirestoreClient(ctx context.Context) (*firestore.Client, error) {
+	if g.firestoreClient == nil {
+		projectName, err := g.GetProjectID()
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := firestore.NewClient(ctx, projectName)
+		if err != nil {
+			return nil, err
+		}
+
+		g.firestoreClient = client
+	}
+
+	return g.firestoreClient, nil
+}
+
+// topicExists - returns true if a pubsub topic with the given name exists in the project
+func (g *gcpProviderImpl) topicExists(ctx context.Context, name string) (bool, error) {
+	client, err := g.getPubsubClient(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return client.Topic(name).Exists(ctx)
+}
+
+// secretId - returns the secret id from a secret resource name of the form projects/{project}/secrets/{secret}
+func secretId(resourceName string) string {
+	nameParts := strings.Split(resourceName, "/")
+
+	return nameParts[len(nameParts)-1]
+}
+
 func (g *gcpProviderImpl) getApiGatewayDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
 	projectName, err := g.GetProjectID()
 	if err != nil {
@@ -86,12 +186,17 @@ func (g *gcpProviderImpl) getApiGatewayDetails(ctx context.Context, name string)
 
 	// there should only be a single entry in this array, we'll grab the first and then break
 	if gw, err := gws.Next(); gw != nil && err == nil {
+		url := fmt.Sprintf("https://%s", gw.DefaultHostname)
+		if customUrl, ok := g.apiUrls[name]; ok {
+			url = customUrl
+		}
+
 		return &common.DetailsResponse[any]{
 			Id:       gw.Name,
 			Provider: "gcp",
 			Service:  "ApiGateway",
 			Detail: common.ApiDetails{
-				URL: fmt.Sprintf("https://%s", gw.DefaultHostname),
+				URL: url,
 			},
 		}, nil
 	} else {
@@ -99,10 +204,188 @@ func (g *gcpProviderImpl) getApiGatewayDetails(ctx context.Context, name string)
 	}
 }
 
+func (g *gcpProviderImpl) getBucketDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	projectName, err := g.GetProjectID()
+	if err != nil {
+		return nil, err
+	}
+
+	storageClient, err := g.getStorageClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// bucket names are generated at deploy time, so find the bucket by its labels
+	buckets := storageClient.Buckets(ctx, projectName)
+	for {
+		b, err := buckets.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if b.Labels["x-nitric-name"] != name || (g.stackName != "" && b.Labels["x-nitric-stack"] != g.stackName) {
+			continue
+		}
+
+		return &common.DetailsResponse[any]{
+			Id:       fmt.Sprintf("projects/_/buckets/%s", b.Name),
+			Provider: "gcp",
+			Service:  "CloudStorage",
+			Detail: common.BucketDetails{
+				Name: b.Name,
+				URL:  fmt.Sprintf("https://storage.googleapis.com/%s", b.Name),
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("bucket resource %s not found", name)
+}
+
+func (g *gcpProviderImpl) getTopicDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	projectName, err := g.GetProjectID()
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := g.topicExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("topic resource %s not found", name)
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       fmt.Sprintf("projects/%s/topics/%s", projectName, name),
+		Provider: "gcp",
+		Service:  "PubSub",
+		Detail: common.TopicDetails{
+			Name: name,
+		},
+	}, nil
+}
+
+func (g *gcpProviderImpl) getQueueDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	projectName, err := g.GetProjectID()
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := g.topicExists(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("queue resource %s not found", name)
+	}
+
+	// queues are pubsub topics, pulled from by a subscription of the same name with a -nitricqueue suffix
+	topic := fmt.Sprintf("projects/%s/topics/%s", projectName, name)
+
+	return &common.DetailsResponse[any]{
+		Id:       topic,
+		Provider: "gcp",
+		Service:  "PubSub",
+		Detail: common.QueueDetails{
+			Name: name,
+			URL:  fmt.Sprintf("https://pubsub.googleapis.com/v1/%s", topic),
+		},
+	}, nil
+}
+
+func (g *gcpProviderImpl) getCollectionDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	projectName, err := g.GetProjectID()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := g.getFirestoreClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := client.Collection(name)
+	if collection == nil {
+		return nil, fmt.Errorf("invalid collection name %s", name)
+	}
+
+	// firestore collections only exist while they contain documents
+	_, err = collection.Limit(1).Documents(ctx).Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, fmt.Errorf("collection resource %s not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       fmt.Sprintf("projects/%s/databases/(default)/documents/%s", projectName, name),
+		Provider: "gcp",
+		Service:  "Firestore",
+		Detail: common.CollectionDetails{
+			Name: name,
+		},
+	}, nil
+}
+
+func (g *gcpProviderImpl) getSecretDetails(ctx context.Context, name string) (*common.DetailsResponse[any], error) {
+	projectName, err := g.GetProjectID()
+	if err != nil {
+		return nil, err
+	}
+
+	secretClient, err := g.getSecretClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	secretFilter := "labels.x-nitric-name=" + name
+	if g.stackName != "" {
+		secretFilter = secretFilter + " AND labels.x-nitric-stack=" + g.stackName
+	}
+
+	secrets := secretClient.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", projectName),
+		Filter: secretFilter,
+	})
+
+	sec, err := secrets.Next()
+	if errors.Is(err, iterator.Done) {
+		return nil, fmt.Errorf("secret resource %s not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.DetailsResponse[any]{
+		Id:       sec.Name,
+		Provider: "gcp",
+		Service:  "SecretManager",
+		Detail: common.SecretDetails{
+			Name: secretId(sec.Name),
+		},
+	}, nil
+}
+
 func (g *gcpProviderImpl) Details(ctx context.Context, typ common.ResourceType, name string) (*common.DetailsResponse[any], error) {
 	switch typ {
 	case common.ResourceType_Api:
 		return g.getApiGatewayDetails(ctx, name)
+	case common.ResourceType_Bucket:
+		return g.getBucketDetails(ctx, name)
+	case common.ResourceType_Topic:
+		return g.getTopicDetails(ctx, name)
+	case common.ResourceType_Queue:
+		return g.getQueueDetails(ctx, name)
+	case common.ResourceType_Collection:
+		return g.getCollectionDetails(ctx, name)
+	case common.ResourceType_Secret:
+		return g.getSecretDetails(ctx, name)
 	default:
 		return nil, fmt.Errorf("unsupported resource type: %s", typ)
 	}
@@ -173,9 +456,17 @@ func New() (GcpProvider, error) {
 		return nil, err
 	}
 
+	apiUrls := map[string]string{}
+	if env := utils.GetEnv(ApiUrlsEnv, ""); env != "" {
+		if err := json.Unmarshal([]byte(env), &apiUrls); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", ApiUrlsEnv, err)
+		}
+	}
+
 	return &gcpProviderImpl{
 		stackName: stack,
 		apiClient: apiClient,
 		region:    region,
+		apiUrls:   apiUrls,
 	}, nil
 }
@@ -0,0 +1,300 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"cloud.google.com/go/storage"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/iterator"
+
+	mock_gcloud_secret "github.com/nitrictech/nitric/cloud/gcp/mocks/gcp_secret"
+	mock_gcloud_storage "github.com/nitrictech/nitric/cloud/gcp/mocks/gcp_storage"
+	mock_pubsub "github.com/nitrictech/nitric/cloud/gcp/mocks/pubsub"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+var _ = Describe("GcpProvider", func() {
+	When("Parsing secret ids", func() {
+		cases := []struct {
+			resourceName string
+			expected     string
+		}{
+			{resourceName: "projects/test-project/secrets/test-secret", expected: "test-secret"},
+			{resourceName: "projects/123456789/secrets/test", expected: "test"},
+			{resourceName: "test", expected: "test"},
+		}
+
+		for _, c := range cases {
+			c := c
+
+			It(fmt.Sprintf("should return %s for %s", c.expected, c.resourceName), func() {
+				Expect(secretId(c.resourceName)).To(Equal(c.expected))
+			})
+		}
+	})
+
+	When("Calling details for a bucket", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockStorage := mock_gcloud_storage.NewMockStorageClient(ctrl)
+		provider := &gcpProviderImpl{
+			projectID:     "test-project",
+			stackName:     "test-stack",
+			storageClient: mockStorage,
+		}
+
+		It("should return the bucket of this stack", func() {
+			defer ctrl.Finish()
+
+			mockIter := mock_gcloud_storage.NewMockBucketIterator(ctrl)
+			mockStorage.EXPECT().Buckets(gomock.Any(), "test-project").Return(mockIter)
+
+			By("skipping buckets of other stacks")
+			mockIter.EXPECT().Next().Return(&storage.BucketAttrs{
+				Name:   "test-other",
+				Labels: map[string]string{"x-nitric-name": "test", "x-nitric-stack": "other-stack"},
+			}, nil)
+			mockIter.EXPECT().Next().Return(&storage.BucketAttrs{
+				Name:   "test-abc123",
+				Labels: map[string]string{"x-nitric-name": "test", "x-nitric-stack": "test-stack"},
+			}, nil)
+
+			details, err := provider.Details(context.TODO(), common.ResourceType_Bucket, "test")
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(details.Id).To(Equal("projects/_/buckets/test-abc123"))
+			Expect(details.Detail).To(Equal(common.BucketDetails{
+				Name: "test-abc123",
+				URL:  "https://storage.googleapis.com/test-abc123",
+			}))
+		})
+	})
+
+	When("Calling details for a bucket that does not exist", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		mockStorage := mock_gcloud_storage.NewMockStorageClient(ctrl)
+		provider := &gcpProviderImpl{
+			projectID:     "test-project",
+			storageClient: mockStorage,
+		}
+
+		It("should return an error", func() {
+			defer ctrl.Finish()
+
+			mockIter := mock_gcloud_storage.NewMockBucketIterator(ctrl)
+			mockStorage.EXPECT().Buckets(gomock.Any(), "test-project").Return(mockIter)
+			mockIter.EXPECT().Next().Return(nil, iterator.Done)
+
+			_, err := provider.Details(context.TODO(), common.ResourceType_Bucket, "test")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	When("Calling details for a topic", func() {
+		When("The topic exists", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockPubsub := mock_pubsub.NewMockPubsubClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				pubsubClient: mockPubsub,
+			}
+
+			It("should return the topic path", func() {
+				defer ctrl.Finish()
+
+				mockTopic := mock_pubsub.NewMockTopic(ctrl)
+				mockPubsub.EXPECT().Topic("test").Return(mockTopic)
+				mockTopic.EXPECT().Exists(gomock.Any()).Return(true, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Topic, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Id).To(Equal("projects/test-project/topics/test"))
+				Expect(details.Detail).To(Equal(common.TopicDetails{
+					Name: "test",
+				}))
+			})
+		})
+
+		When("The topic does not exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockPubsub := mock_pubsub.NewMockPubsubClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				pubsubClient: mockPubsub,
+			}
+
+			It("should return an error", func() {
+				defer ctrl.Finish()
+
+				mockTopic := mock_pubsub.NewMockTopic(ctrl)
+				mockPubsub.EXPECT().Topic("test").Return(mockTopic)
+				mockTopic.EXPECT().Exists(gomock.Any()).Return(false, nil)
+
+				_, err := provider.Details(context.TODO(), common.ResourceType_Topic, "test")
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	When("Calling details for a queue", func() {
+		When("The queue exists", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockPubsub := mock_pubsub.NewMockPubsubClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				pubsubClient: mockPubsub,
+			}
+
+			It("should return the queue topic path and url", func() {
+				defer ctrl.Finish()
+
+				mockTopic := mock_pubsub.NewMockTopic(ctrl)
+				mockPubsub.EXPECT().Topic("test").Return(mockTopic)
+				mockTopic.EXPECT().Exists(gomock.Any()).Return(true, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Queue, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Id).To(Equal("projects/test-project/topics/test"))
+				Expect(details.Detail).To(Equal(common.QueueDetails{
+					Name: "test",
+					URL:  "https://pubsub.googleapis.com/v1/projects/test-project/topics/test",
+				}))
+			})
+		})
+
+		When("Checking the queue fails", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockPubsub := mock_pubsub.NewMockPubsubClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				pubsubClient: mockPubsub,
+			}
+
+			It("should return an error", func() {
+				defer ctrl.Finish()
+
+				mockTopic := mock_pubsub.NewMockTopic(ctrl)
+				mockPubsub.EXPECT().Topic("test").Return(mockTopic)
+				mockTopic.EXPECT().Exists(gomock.Any()).Return(false, fmt.Errorf("mock-error"))
+
+				_, err := provider.Details(context.TODO(), common.ResourceType_Queue, "test")
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	When("Calling details for a secret", func() {
+		When("The stack name is set", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockSecrets := mock_gcloud_secret.NewMockSecretManagerClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				stackName:    "test-stack",
+				secretClient: mockSecrets,
+			}
+
+			It("should filter secrets by name and stack", func() {
+				defer ctrl.Finish()
+
+				mockIter := mock_gcloud_secret.NewMockSecretIterator(ctrl)
+				mockSecrets.EXPECT().ListSecrets(gomock.Any(), &secretmanagerpb.ListSecretsRequest{
+					Parent: "projects/test-project",
+					Filter: "labels.x-nitric-name=test AND labels.x-nitric-stack=test-stack",
+				}).Return(mockIter)
+				mockIter.EXPECT().Next().Return(&secretmanagerpb.Secret{
+					Name: "projects/test-project/secrets/test-abc123",
+				}, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Secret, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Id).To(Equal("projects/test-project/secrets/test-abc123"))
+				Expect(details.Detail).To(Equal(common.SecretDetails{
+					Name: "test-abc123",
+				}))
+			})
+		})
+
+		When("The stack name is not set", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockSecrets := mock_gcloud_secret.NewMockSecretManagerClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				secretClient: mockSecrets,
+			}
+
+			It("should filter secrets by name only", func() {
+				defer ctrl.Finish()
+
+				mockIter := mock_gcloud_secret.NewMockSecretIterator(ctrl)
+				mockSecrets.EXPECT().ListSecrets(gomock.Any(), &secretmanagerpb.ListSecretsRequest{
+					Parent: "projects/test-project",
+					Filter: "labels.x-nitric-name=test",
+				}).Return(mockIter)
+				mockIter.EXPECT().Next().Return(&secretmanagerpb.Secret{
+					Name: "projects/test-project/secrets/test",
+				}, nil)
+
+				details, err := provider.Details(context.TODO(), common.ResourceType_Secret, "test")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(details.Detail).To(Equal(common.SecretDetails{
+					Name: "test",
+				}))
+			})
+		})
+
+		When("The secret does not exist", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			mockSecrets := mock_gcloud_secret.NewMockSecretManagerClient(ctrl)
+			provider := &gcpProviderImpl{
+				projectID:    "test-project",
+				secretClient: mockSecrets,
+			}
+
+			It("should return an error", func() {
+				defer ctrl.Finish()
+
+				mockIter := mock_gcloud_secret.NewMockSecretIterator(ctrl)
+				mockSecrets.EXPECT().ListSecrets(gomock.Any(), gomock.Any()).Return(mockIter)
+				mockIter.EXPECT().Next().Return(nil, iterator.Done)
+
+				_, err := provider.Details(context.TODO(), common.ResourceType_Secret, "test")
+
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	When("Calling details for an unsupported resource type", func() {
+		provider := &gcpProviderImpl{}
+
+		It("should return an error", func() {
+			_, err := provider.Details(context.TODO(), "unknown", "test")
+
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
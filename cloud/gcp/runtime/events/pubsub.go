@@ -35,6 +35,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
@@ -154,6 +155,11 @@ func (s *PubsubEventService) Publish(ctx context.Context, topic string, delay in
 
 	propagator.CloudTraceFormatPropagator{}.Inject(ctx, attributes)
 
+	// W3C trace context is also included, restoring the trace on delivery regardless of the subscriber's propagator
+	for k, v := range span.ToAttributes(ctx) {
+		attributes[k] = v
+	}
+
 	pubsubMsg := &pubsub.Message{
 		Attributes: attributes,
 		Data:       eventBytes,
@@ -18,7 +18,9 @@ package gateway
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/GoogleCloudPlatform/opentelemetry-operations-go/propagator"
 	"github.com/valyala/fasthttp"
@@ -27,15 +29,20 @@ import (
 	base_http "github.com/nitrictech/nitric/cloud/common/runtime/gateway"
 	ep "github.com/nitrictech/nitric/core/pkg/plugins/events"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
+	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
 
+// The header API Gateway forwards the caller's original Authorization header in
+const forwardedAuthorizationHeader = "X-Forwarded-Authorization"
+
 type PubSubMessage struct {
 	Message struct {
-		Attributes map[string]string `json:"attributes"`
-		Data       []byte            `json:"data,omitempty"`
-		ID         string            `json:"id"`
+		Attributes  map[string]string `json:"attributes"`
+		Data        []byte            `json:"data,omitempty"`
+		ID          string            `json:"id"`
+		PublishTime time.Time         `json:"publishTime"`
 	} `json:"message"`
 	Subscription string `json:"subscription"`
 }
@@ -65,6 +72,7 @@ func middleware(rc *fasthttp.RequestCtx, pool worker.WorkerPool) bool {
 				Topic:      topic,
 				Payload:    payload,
 				Attributes: pubsubEvent.Message.Attributes,
+				Time:       pubsubEvent.Message.PublishTime,
 			}
 		} else {
 			event = &triggers.Event{
@@ -74,13 +82,18 @@ func middleware(rc *fasthttp.RequestCtx, pool worker.WorkerPool) bool {
 				// Set the original full payload payload
 				Payload:    pubsubEvent.Message.Data,
 				Attributes: pubsubEvent.Message.Attributes,
+				Time:       pubsubEvent.Message.PublishTime,
 			}
 		}
 
 		wrkr, err := pool.GetWorker(&worker.GetWorkerOptions{
 			Event: event,
 		})
-		if err != nil {
+		if errors.Is(err, worker.ErrSaturated) {
+			// Nacks the event, so it is redelivered once workers have capacity
+			rc.Error("Service is at capacity", 503)
+			return false
+		} else if err != nil {
 			rc.Error("Could not find handle for event", 500)
 			return false
 		}
@@ -96,6 +109,9 @@ func middleware(rc *fasthttp.RequestCtx, pool worker.WorkerPool) bool {
 			ctx = propagator.CloudTraceFormatPropagator{}.Extract(ctx, hc)
 		}
 
+		// W3C trace context takes precedence over Cloud Trace headers when both are present
+		ctx = span.FromAttributes(ctx, pubsubEvent.Message.Attributes)
+
 		if err := wrkr.HandleEvent(ctx, event); err == nil {
 			// return a successful response
 			rc.SuccessString("text/plain", "success")
@@ -108,6 +124,13 @@ func middleware(rc *fasthttp.RequestCtx, pool worker.WorkerPool) bool {
 		return false
 	}
 
+	// API Gateway replaces the Authorization header with its own identity token when invoking Cloud Run,
+	// the caller's original header is restored so the membrane can validate the caller's token
+	if forwarded := rc.Request.Header.Peek(forwardedAuthorizationHeader); len(forwarded) > 0 {
+		rc.Request.Header.SetBytesV(fasthttp.HeaderAuthorization, forwarded)
+		rc.Request.Header.Del(forwardedAuthorizationHeader)
+	}
+
 	// Let the base plugin handle the request
 	return true
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
 	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
 	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
+	"github.com/nitrictech/nitric/core/pkg/span"
 )
 
 type PubsubQueueService struct {
@@ -71,6 +72,10 @@ func (s *PubsubQueueService) Send(ctx context.Context, queue string, task queue.
 
 		propagator.CloudTraceFormatPropagator{}.Inject(ctx, attributes)
 
+		for k, v := range span.ToAttributes(ctx) {
+			attributes[k] = v
+		}
+
 		msg := ifaces_pubsub.AdaptPubsubMessage(&pubsub.Message{
 			Attributes: attributes,
 			Data:       taskBytes,
@@ -126,6 +131,10 @@ func (s *PubsubQueueService) SendBatch(ctx context.Context, q string, tasks []qu
 
 	propagator.CloudTraceFormatPropagator{}.Inject(ctx, attributes)
 
+	for k, v := range span.ToAttributes(ctx) {
+		attributes[k] = v
+	}
+
 	for _, task := range tasks {
 		if taskBytes, err := json.Marshal(task); err == nil {
 			msg := ifaces_pubsub.AdaptPubsubMessage(&pubsub.Message{
@@ -258,10 +267,11 @@ func (s *PubsubQueueService) Receive(ctx context.Context, options queue.ReceiveO
 		}
 
 		tasks = append(tasks, queue.NitricTask{
-			ID:          nitricTask.ID,
-			Payload:     nitricTask.Payload,
-			PayloadType: nitricTask.PayloadType,
-			LeaseID:     m.AckId,
+			ID:           nitricTask.ID,
+			Payload:      nitricTask.Payload,
+			PayloadType:  nitricTask.PayloadType,
+			LeaseID:      m.AckId,
+			TraceContext: span.TraceContextAttributes(m.Message.Attributes),
 		})
 	}
 
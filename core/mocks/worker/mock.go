@@ -35,6 +35,20 @@ func (m *MockWorker) EXPECT() *MockWorkerMockRecorder {
 	return m.recorder
 }
 
+// Drain mocks base method.
+func (m *MockWorker) Drain(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockWorkerMockRecorder) Drain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockWorker)(nil).Drain), arg0)
+}
+
 // HandleEvent mocks base method.
 func (m *MockWorker) HandleEvent(arg0 context.Context, arg1 *triggers.Event) error {
 	m.ctrl.T.Helper()
@@ -64,6 +78,21 @@ func (mr *MockWorkerMockRecorder) HandleHttpRequest(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleHttpRequest", reflect.TypeOf((*MockWorker)(nil).HandleHttpRequest), arg0, arg1)
 }
 
+// HandleWebsocketEvent mocks base method.
+func (m *MockWorker) HandleWebsocketEvent(arg0 context.Context, arg1 *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebsocketEvent", arg0, arg1)
+	ret0, _ := ret[0].(*triggers.WebsocketResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebsocketEvent indicates an expected call of HandleWebsocketEvent.
+func (mr *MockWorkerMockRecorder) HandleWebsocketEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebsocketEvent", reflect.TypeOf((*MockWorker)(nil).HandleWebsocketEvent), arg0, arg1)
+}
+
 // HandlesEvent mocks base method.
 func (m *MockWorker) HandlesEvent(arg0 *triggers.Event) bool {
 	m.ctrl.T.Helper()
@@ -92,6 +121,34 @@ func (mr *MockWorkerMockRecorder) HandlesHttpRequest(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlesHttpRequest", reflect.TypeOf((*MockWorker)(nil).HandlesHttpRequest), arg0)
 }
 
+// HandlesWebsocketEvent mocks base method.
+func (m *MockWorker) HandlesWebsocketEvent(arg0 *triggers.WebsocketEvent) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlesWebsocketEvent", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HandlesWebsocketEvent indicates an expected call of HandlesWebsocketEvent.
+func (mr *MockWorkerMockRecorder) HandlesWebsocketEvent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlesWebsocketEvent", reflect.TypeOf((*MockWorker)(nil).HandlesWebsocketEvent), arg0)
+}
+
+// Saturated mocks base method.
+func (m *MockWorker) Saturated() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Saturated")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Saturated indicates an expected call of Saturated.
+func (mr *MockWorkerMockRecorder) Saturated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Saturated", reflect.TypeOf((*MockWorker)(nil).Saturated))
+}
+
 // MockAdapter is a mock of Adapter interface.
 type MockAdapter struct {
 	ctrl     *gomock.Controller
@@ -115,6 +172,20 @@ func (m *MockAdapter) EXPECT() *MockAdapterMockRecorder {
 	return m.recorder
 }
 
+// Drain mocks base method.
+func (m *MockAdapter) Drain(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockAdapterMockRecorder) Drain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockAdapter)(nil).Drain), arg0)
+}
+
 // HandleEvent mocks base method.
 func (m *MockAdapter) HandleEvent(arg0 context.Context, arg1 *triggers.Event) error {
 	m.ctrl.T.Helper()
@@ -143,3 +214,32 @@ func (mr *MockAdapterMockRecorder) HandleHttpRequest(arg0, arg1 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleHttpRequest", reflect.TypeOf((*MockAdapter)(nil).HandleHttpRequest), arg0, arg1)
 }
+
+// HandleWebsocketEvent mocks base method.
+func (m *MockAdapter) HandleWebsocketEvent(arg0 context.Context, arg1 *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleWebsocketEvent", arg0, arg1)
+	ret0, _ := ret[0].(*triggers.WebsocketResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HandleWebsocketEvent indicates an expected call of HandleWebsocketEvent.
+func (mr *MockAdapterMockRecorder) HandleWebsocketEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleWebsocketEvent", reflect.TypeOf((*MockAdapter)(nil).HandleWebsocketEvent), arg0, arg1)
+}
+
+// Saturated mocks base method.
+func (m *MockAdapter) Saturated() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Saturated")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Saturated indicates an expected call of Saturated.
+func (mr *MockAdapterMockRecorder) Saturated() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Saturated", reflect.TypeOf((*MockAdapter)(nil).Saturated))
+}
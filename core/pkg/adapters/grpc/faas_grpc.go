@@ -16,18 +16,29 @@ package grpc
 
 import (
 	"log"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	pb "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/collector"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
+	"github.com/nitrictech/nitric/core/pkg/routes"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
 
 type FaasServer struct {
 	pb.UnimplementedFaasServiceServer
-	pool worker.WorkerPool
+	pool       worker.WorkerPool
+	authorizer *auth.Authorizer
+	limiter    *ratelimit.Limiter
+	collector  *collector.Collector
+	// How long triggers wait for a worker at its maximum concurrency to have capacity
+	queueTimeout time.Duration
 }
 
 // Starts a new stream
@@ -48,14 +59,35 @@ func (s *FaasServer) TriggerStream(stream pb.FaasService_TriggerStreamServer) er
 	}
 
 	var wrkr worker.Worker
-	adapter := worker.NewGrpcAdapter(stream)
+	if ir.GetMaxConcurrency() < 0 {
+		return status.Error(codes.InvalidArgument, "max concurrency must not be negative")
+	}
+
+	adapter := worker.NewGrpcAdapter(stream,
+		worker.WithBodyStreaming(ir.GetBodyStreaming()),
+		worker.WithMaxConcurrency(int(ir.GetMaxConcurrency())),
+		worker.WithQueueTimeout(s.queueTimeout),
+	)
 
 	if api := ir.GetApi(); api != nil {
+		if _, err := routes.Parse(api.Path); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid route path: %v", err)
+		}
+
+		if s.limiter != nil {
+			if err := s.limiter.DeclareRoute(api.Api, api.Path, api.Methods, rateLimit(api.GetOptions().GetRateLimit())); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid route rate limit: %v", err)
+			}
+		}
+
 		// Create a new route worker
 		wrkr = worker.NewRouteWorker(adapter, &worker.RouteWorkerOptions{
-			Api:     api.Api,
-			Path:    api.Path,
-			Methods: api.Methods,
+			Api:              api.Api,
+			Path:             api.Path,
+			Methods:          api.Methods,
+			Security:         routeSecurity(api.GetOptions()),
+			SecurityDisabled: api.GetOptions().GetSecurityDisabled(),
+			Authorizer:       s.authorizer,
 		})
 	} else if subscription := ir.GetSubscription(); subscription != nil {
 		wrkr = worker.NewSubscriptionWorker(adapter, &worker.SubscriptionWorkerOptions{
@@ -65,11 +97,20 @@ func (s *FaasServer) TriggerStream(stream pb.FaasService_TriggerStreamServer) er
 		wrkr = worker.NewScheduleWorker(adapter, &worker.ScheduleWorkerOptions{
 			Key: schedule.Key,
 		})
+	} else if websocket := ir.GetWebsocket(); websocket != nil {
+		wrkr = worker.NewWebsocketWorker(adapter, &worker.WebsocketWorkerOptions{
+			Socket: websocket.Socket,
+			Event:  websocketEvents[websocket.Event],
+		})
 	} else {
 		// XXX: Catch all worker type
 		wrkr = worker.NewFaasWorker(adapter)
 	}
 
+	if s.collector != nil {
+		s.collector.RegisterWorker(ir)
+	}
+
 	// Add it to our new pool
 	if err := s.pool.AddWorker(wrkr); err != nil {
 		// Worker could not be added
@@ -100,8 +141,63 @@ func (s *FaasServer) TriggerStream(stream pb.FaasService_TriggerStreamServer) er
 	return err
 }
 
-func NewFaasServer(workerPool worker.WorkerPool) *FaasServer {
-	return &FaasServer{
+var websocketEvents = map[pb.WebsocketEvent]triggers.WebsocketEventType{
+	pb.WebsocketEvent_Connect:    triggers.WebsocketEventType_Connect,
+	pb.WebsocketEvent_Disconnect: triggers.WebsocketEventType_Disconnect,
+	pb.WebsocketEvent_Message:    triggers.WebsocketEventType_Message,
+}
+
+func routeSecurity(opts *pb.ApiWorkerOptions) auth.Requirements {
+	if len(opts.GetSecurity()) == 0 {
+		return nil
+	}
+
+	security := make(auth.Requirements)
+	for name, scopes := range opts.GetSecurity() {
+		security[name] = scopes.GetScopes()
+	}
+
+	return security
+}
+
+type FaasServerOption = func(*FaasServer)
+
+// WithAuthorizer - validate the tokens of requests to API routes against their declared security
+func WithAuthorizer(authorizer *auth.Authorizer) FaasServerOption {
+	return func(srv *FaasServer) {
+		srv.authorizer = authorizer
+	}
+}
+
+// WithRateLimiter - register API routes and their rate limits, so requests can be limited before they are handed to a worker
+func WithRateLimiter(limiter *ratelimit.Limiter) FaasServerOption {
+	return func(srv *FaasServer) {
+		srv.limiter = limiter
+	}
+}
+
+// WithCollector - record the InitRequests of workers to describe the application as a deployment spec
+func WithCollector(c *collector.Collector) FaasServerOption {
+	return func(srv *FaasServer) {
+		srv.collector = c
+	}
+}
+
+// WithQueueTimeout - sets how long triggers wait for workers at their maximum concurrency before being rejected
+func WithQueueTimeout(timeout time.Duration) FaasServerOption {
+	return func(srv *FaasServer) {
+		srv.queueTimeout = timeout
+	}
+}
+
+func NewFaasServer(workerPool worker.WorkerPool, opts ...FaasServerOption) *FaasServer {
+	srv := &FaasServer{
 		pool: workerPool,
 	}
+
+	for _, o := range opts {
+		o(srv)
+	}
+
+	return srv
 }
@@ -147,6 +147,9 @@ func (s *QueueServiceServer) Receive(ctx context.Context, req *pb.QueueReceiveRe
 			Payload:     st,
 			LeaseId:     task.LeaseID,
 			PayloadType: task.PayloadType,
+			TraceContext: &pb.TraceContext{
+				Values: task.TraceContext,
+			},
 		})
 	}
 
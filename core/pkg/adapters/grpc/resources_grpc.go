@@ -18,17 +18,65 @@ import (
 	"context"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/collector"
+	"github.com/nitrictech/nitric/core/pkg/cors"
+	"github.com/nitrictech/nitric/core/pkg/policy"
 	"github.com/nitrictech/nitric/core/pkg/providers/common"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
 )
 
 type ResourcesServiceServer struct {
 	v1.UnimplementedResourceServiceServer
-	plugin common.ResourceService
+	plugin     common.ResourceService
+	authorizer *auth.Authorizer
+	cors       *cors.Registry
+	limiter    *ratelimit.Limiter
+	enforcer   *policy.Enforcer
+	collector  *collector.Collector
 }
 
 type ResourceServiceOption = func(*ResourcesServiceServer)
 
+// WithApiAuthorizer - register the security of declared APIs with the authorizer used by their routes
+func WithApiAuthorizer(authorizer *auth.Authorizer) ResourceServiceOption {
+	return func(srv *ResourcesServiceServer) {
+		srv.authorizer = authorizer
+	}
+}
+
+// WithApiCors - register the CORS configuration of declared APIs
+func WithApiCors(registry *cors.Registry) ResourceServiceOption {
+	return func(srv *ResourcesServiceServer) {
+		srv.cors = registry
+	}
+}
+
+// WithApiRateLimiter - register the rate limits of declared APIs
+func WithApiRateLimiter(limiter *ratelimit.Limiter) ResourceServiceOption {
+	return func(srv *ResourcesServiceServer) {
+		srv.limiter = limiter
+	}
+}
+
+// WithPolicyEnforcer - record declared resources and policies with the enforcer used by plugin servers in strict mode
+func WithPolicyEnforcer(enforcer *policy.Enforcer) ResourceServiceOption {
+	return func(srv *ResourcesServiceServer) {
+		srv.enforcer = enforcer
+	}
+}
+
+// WithResourceCollector - record declared resources and policies to describe the application as a deployment spec
+func WithResourceCollector(c *collector.Collector) ResourceServiceOption {
+	return func(srv *ResourcesServiceServer) {
+		srv.collector = c
+	}
+}
+
 func WithResourcePlugin(plugin common.ResourceService) ResourceServiceOption {
 	return func(srv *ResourcesServiceServer) {
 		if plugin != nil {
@@ -38,31 +86,172 @@ func WithResourcePlugin(plugin common.ResourceService) ResourceServiceOption {
 }
 
 func (rs *ResourcesServiceServer) Declare(ctx context.Context, req *v1.ResourceDeclareRequest) (*v1.ResourceDeclareResponse, error) {
-	// Currently a no-op at runtime
+	if api := req.GetApi(); api != nil && rs.authorizer != nil {
+		if err := rs.declareApiSecurity(req.GetResource().GetName(), api); err != nil {
+			return nil, err
+		}
+	}
+
+	if api := req.GetApi(); api != nil && rs.cors != nil {
+		rs.cors.DeclareApi(req.GetResource().GetName(), corsConfig(api.GetCors()))
+	}
+
+	if api := req.GetApi(); api != nil && rs.limiter != nil {
+		rs.limiter.DeclareApi(req.GetResource().GetName(), rateLimit(api.GetRateLimit()))
+	}
+
+	if rs.enforcer != nil {
+		rs.declarePolicy(req)
+	}
+
+	if rs.collector != nil {
+		rs.collector.Declare(req)
+	}
+
+	// Otherwise currently a no-op at runtime
 	// TODO: Implement a strategy pattern for resolving resources, by their declared resource name in nitric
 	return &v1.ResourceDeclareResponse{}, nil
 }
 
-type resourceDetailsType interface {
-	// Add union type here to exhaust possible ResourceDetailTypes
-	*v1.ResourceDetailsResponse_Api
+// declarePolicy - record a declared resource, or the actions granted by a declared policy, with the enforcer
+func (rs *ResourcesServiceServer) declarePolicy(req *v1.ResourceDeclareRequest) {
+	if pol := req.GetPolicy(); pol != nil {
+		resources := make([]policy.Resource, 0, len(pol.GetResources()))
+		for _, r := range pol.GetResources() {
+			if typ, ok := resourceTypeMap[r.GetType()]; ok {
+				resources = append(resources, policy.Resource{Type: typ, Name: r.GetName()})
+			}
+		}
+
+		rs.enforcer.DeclarePolicy(resources, pol.GetActions())
+		return
+	}
+
+	if typ, ok := resourceTypeMap[req.GetResource().GetType()]; ok {
+		rs.enforcer.DeclareResource(policy.Resource{Type: typ, Name: req.GetResource().GetName()})
+	}
 }
 
-func convertDetails[T resourceDetailsType](details *common.DetailsResponse[any]) (T, error) {
+func (rs *ResourcesServiceServer) declareApiSecurity(name string, api *v1.ApiResource) error {
+	definitions := make(map[string]*auth.JwtDefinition)
+	for n, sd := range api.GetSecurityDefinitions() {
+		jwt := sd.GetJwt()
+		if jwt == nil {
+			return status.Errorf(codes.InvalidArgument, "unsupported security definition %s for api %s", n, name)
+		}
+
+		definitions[n] = &auth.JwtDefinition{
+			Issuer:    jwt.GetIssuer(),
+			Audiences: jwt.GetAudiences(),
+		}
+	}
+
+	security := make(auth.Requirements)
+	for n, scopes := range api.GetSecurity() {
+		security[n] = scopes.GetScopes()
+	}
+
+	rs.authorizer.DeclareApi(name, definitions, security)
+
+	return nil
+}
+
+func corsConfig(def *v1.ApiCorsDefinition) *cors.Config {
+	if def == nil {
+		return nil
+	}
+
+	return &cors.Config{
+		AllowOrigins:     def.GetAllowOrigins(),
+		AllowMethods:     def.GetAllowMethods(),
+		AllowHeaders:     def.GetAllowHeaders(),
+		ExposeHeaders:    def.GetExposeHeaders(),
+		AllowCredentials: def.GetAllowCredentials(),
+		MaxAge:           int(def.GetMaxAge()),
+	}
+}
+
+var rateLimitKeys = map[v1.ApiRateLimitKey]ratelimit.KeyType{
+	v1.ApiRateLimitKey_RateLimitGlobal:  ratelimit.KeyGlobal,
+	v1.ApiRateLimitKey_RateLimitIp:      ratelimit.KeyIp,
+	v1.ApiRateLimitKey_RateLimitSubject: ratelimit.KeySubject,
+	v1.ApiRateLimitKey_RateLimitHeader:  ratelimit.KeyHeader,
+}
+
+func rateLimit(def *v1.ApiRateLimit) *ratelimit.Limit {
+	if def == nil {
+		return nil
+	}
+
+	return &ratelimit.Limit{
+		RequestsPerSecond: def.GetRequestsPerSecond(),
+		Burst:             int(def.GetBurst()),
+		Key:               rateLimitKeys[def.GetKey()],
+		Header:            def.GetHeader(),
+	}
+}
+
+// detailsResponse - converts provider details to their typed response
+func detailsResponse(details *common.DetailsResponse[any]) (*v1.ResourceDetailsResponse, error) {
+	res := &v1.ResourceDetailsResponse{
+		Id:       details.Id,
+		Provider: details.Provider,
+		Service:  details.Service,
+	}
+
 	switch det := details.Detail.(type) {
 	case common.ApiDetails:
-		return &v1.ResourceDetailsResponse_Api{
+		res.Details = &v1.ResourceDetailsResponse_Api{
 			Api: &v1.ApiResourceDetails{
 				Url: det.URL,
 			},
-		}, nil
+		}
+	case common.BucketDetails:
+		res.Details = &v1.ResourceDetailsResponse_Bucket{
+			Bucket: &v1.BucketResourceDetails{
+				Name: det.Name,
+				Url:  det.URL,
+			},
+		}
+	case common.TopicDetails:
+		res.Details = &v1.ResourceDetailsResponse_Topic{
+			Topic: &v1.TopicResourceDetails{
+				Name: det.Name,
+			},
+		}
+	case common.QueueDetails:
+		res.Details = &v1.ResourceDetailsResponse_Queue{
+			Queue: &v1.QueueResourceDetails{
+				Name: det.Name,
+				Url:  det.URL,
+			},
+		}
+	case common.CollectionDetails:
+		res.Details = &v1.ResourceDetailsResponse_Collection{
+			Collection: &v1.CollectionResourceDetails{
+				Name: det.Name,
+			},
+		}
+	case common.SecretDetails:
+		res.Details = &v1.ResourceDetailsResponse_Secret{
+			Secret: &v1.SecretResourceDetails{
+				Name: det.Name,
+			},
+		}
 	default:
 		return nil, fmt.Errorf("unsupported details type")
 	}
+
+	return res, nil
 }
 
 var resourceTypeMap = map[v1.ResourceType]common.ResourceType{
-	v1.ResourceType_Api: common.ResourceType_Api,
+	v1.ResourceType_Api:        common.ResourceType_Api,
+	v1.ResourceType_Bucket:     common.ResourceType_Bucket,
+	v1.ResourceType_Topic:      common.ResourceType_Topic,
+	v1.ResourceType_Queue:      common.ResourceType_Queue,
+	v1.ResourceType_Collection: common.ResourceType_Collection,
+	v1.ResourceType_Secret:     common.ResourceType_Secret,
 }
 
 func (rs *ResourcesServiceServer) Details(ctx context.Context, req *v1.ResourceDetailsRequest) (*v1.ResourceDetailsResponse, error) {
@@ -76,17 +265,7 @@ func (rs *ResourcesServiceServer) Details(ctx context.Context, req *v1.ResourceD
 		return nil, err
 	}
 
-	details, err := convertDetails(d)
-	if err != nil {
-		return nil, err
-	}
-
-	return &v1.ResourceDetailsResponse{
-		Id:       d.Id,
-		Provider: d.Provider,
-		Service:  d.Service,
-		Details:  details,
-	}, nil
+	return detailsResponse(d)
 }
 
 func NewResourcesServiceServer(opts ...ResourceServiceOption) v1.ResourceServiceServer {
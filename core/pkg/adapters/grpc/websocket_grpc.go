@@ -0,0 +1,76 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/plugins/websocket"
+)
+
+// GRPC Interface for registered Nitric Websocket Plugins
+type WebsocketServer struct {
+	pb.UnimplementedWebsocketServiceServer
+	websocketPlugin websocket.WebsocketService
+}
+
+func (s *WebsocketServer) checkPluginRegistered() error {
+	if s.websocketPlugin == nil {
+		return NewPluginNotRegisteredError("Websocket")
+	}
+
+	return nil
+}
+
+func (s *WebsocketServer) Send(ctx context.Context, req *pb.WebsocketSendRequest) (*pb.WebsocketSendResponse, error) {
+	if err := s.checkPluginRegistered(); err != nil {
+		return nil, err
+	}
+
+	if err := req.ValidateAll(); err != nil {
+		return nil, newGrpcErrorWithCode(codes.InvalidArgument, "WebsocketService.Send", err)
+	}
+
+	if err := s.websocketPlugin.Send(ctx, req.GetSocket(), req.GetConnectionId(), req.GetData()); err != nil {
+		return nil, NewGrpcError("WebsocketService.Send", err)
+	}
+
+	return &pb.WebsocketSendResponse{}, nil
+}
+
+func (s *WebsocketServer) Close(ctx context.Context, req *pb.WebsocketCloseRequest) (*pb.WebsocketCloseResponse, error) {
+	if err := s.checkPluginRegistered(); err != nil {
+		return nil, err
+	}
+
+	if err := req.ValidateAll(); err != nil {
+		return nil, newGrpcErrorWithCode(codes.InvalidArgument, "WebsocketService.Close", err)
+	}
+
+	if err := s.websocketPlugin.Close(ctx, req.GetSocket(), req.GetConnectionId()); err != nil {
+		return nil, NewGrpcError("WebsocketService.Close", err)
+	}
+
+	return &pb.WebsocketCloseResponse{}, nil
+}
+
+func NewWebsocketServiceServer(websocketPlugin websocket.WebsocketService) pb.WebsocketServiceServer {
+	return &WebsocketServer{
+		websocketPlugin: websocketPlugin,
+	}
+}
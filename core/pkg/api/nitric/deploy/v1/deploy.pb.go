@@ -21,6 +21,59 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Sources an execution unit accepts traffic from
+type Ingress int32
+
+const (
+	// Traffic from the internet and internal sources
+	Ingress_IngressAll Ingress = 0
+	// Only traffic from within the stack's network/project
+	Ingress_IngressInternal Ingress = 1
+	// Internal traffic and traffic through the stack's load balancers/gateways
+	Ingress_IngressInternalAndLoadBalancer Ingress = 2
+)
+
+// Enum value maps for Ingress.
+var (
+	Ingress_name = map[int32]string{
+		0: "IngressAll",
+		1: "IngressInternal",
+		2: "IngressInternalAndLoadBalancer",
+	}
+	Ingress_value = map[string]int32{
+		"IngressAll":                     0,
+		"IngressInternal":                1,
+		"IngressInternalAndLoadBalancer": 2,
+	}
+)
+
+func (x Ingress) Enum() *Ingress {
+	p := new(Ingress)
+	*p = x
+	return p
+}
+
+func (x Ingress) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Ingress) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_deploy_v1_deploy_proto_enumTypes[0].Descriptor()
+}
+
+func (Ingress) Type() protoreflect.EnumType {
+	return &file_proto_deploy_v1_deploy_proto_enumTypes[0]
+}
+
+func (x Ingress) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Ingress.Descriptor instead.
+func (Ingress) EnumDescriptor() ([]byte, []int) {
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{0}
+}
+
 type DeployUpRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -476,6 +529,220 @@ func (x *ImageSource) GetUri() string {
 	return ""
 }
 
+// A zip archive source to be used for execution unit deployment
+type ZipSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path to the zip archive containing the execution unit's code
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Language of the code (e.g. go, nodejs, python, java)
+	// detected from the contents of the archive when not provided
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// Command that starts the code (e.g. node index.js)
+	// the language's default is used when not provided, where it has one
+	Entrypoint string `protobuf:"bytes,3,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+}
+
+func (x *ZipSource) Reset() {
+	*x = ZipSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZipSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZipSource) ProtoMessage() {}
+
+func (x *ZipSource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZipSource.ProtoReflect.Descriptor instead.
+func (*ZipSource) Descriptor() ([]byte, []int) {
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ZipSource) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ZipSource) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ZipSource) GetEntrypoint() string {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return ""
+}
+
+// A source directory to be built for execution unit deployment
+type DirectorySource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Path to the directory containing the execution unit's code
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Language of the code (e.g. go, nodejs, python, java)
+	// detected from the contents of the directory when not provided
+	Language string `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	// Command that starts the code (e.g. node index.js)
+	// the language's default is used when not provided, where it has one
+	Entrypoint string `protobuf:"bytes,3,opt,name=entrypoint,proto3" json:"entrypoint,omitempty"`
+}
+
+func (x *DirectorySource) Reset() {
+	*x = DirectorySource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DirectorySource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DirectorySource) ProtoMessage() {}
+
+func (x *DirectorySource) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DirectorySource.ProtoReflect.Descriptor instead.
+func (*DirectorySource) Descriptor() ([]byte, []int) {
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DirectorySource) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *DirectorySource) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *DirectorySource) GetEntrypoint() string {
+	if x != nil {
+		return x.Entrypoint
+	}
+	return ""
+}
+
+// Attaches an execution unit to a private network (e.g. for access to private databases)
+type NetworkAttachment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Connector used to attach to the network (e.g. a GCP Serverless VPC Access connector)
+	Connector string `protobuf:"bytes,1,opt,name=connector,proto3" json:"connector,omitempty"`
+	// Subnets the execution unit is attached to
+	Subnets []string `protobuf:"bytes,2,rep,name=subnets,proto3" json:"subnets,omitempty"`
+	// Security groups applied to the execution unit's network interfaces (e.g. AWS security group IDs)
+	SecurityGroups []string `protobuf:"bytes,3,rep,name=security_groups,json=securityGroups,proto3" json:"security_groups,omitempty"`
+	// Route all outbound traffic through the network, rather than only traffic to private ranges
+	AllTraffic bool `protobuf:"varint,4,opt,name=all_traffic,json=allTraffic,proto3" json:"all_traffic,omitempty"`
+}
+
+func (x *NetworkAttachment) Reset() {
+	*x = NetworkAttachment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NetworkAttachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetworkAttachment) ProtoMessage() {}
+
+func (x *NetworkAttachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetworkAttachment.ProtoReflect.Descriptor instead.
+func (*NetworkAttachment) Descriptor() ([]byte, []int) {
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NetworkAttachment) GetConnector() string {
+	if x != nil {
+		return x.Connector
+	}
+	return ""
+}
+
+func (x *NetworkAttachment) GetSubnets() []string {
+	if x != nil {
+		return x.Subnets
+	}
+	return nil
+}
+
+func (x *NetworkAttachment) GetSecurityGroups() []string {
+	if x != nil {
+		return x.SecurityGroups
+	}
+	return nil
+}
+
+func (x *NetworkAttachment) GetAllTraffic() bool {
+	if x != nil {
+		return x.AllTraffic
+	}
+	return false
+}
+
 // A unit of execution (i.e. function/container)
 type ExecutionUnit struct {
 	state         protoimpl.MessageState
@@ -487,6 +754,8 @@ type ExecutionUnit struct {
 	// Types that are assignable to Source:
 	//
 	//	*ExecutionUnit_Image
+	//	*ExecutionUnit_Zip
+	//	*ExecutionUnit_Directory
 	Source isExecutionUnit_Source `protobuf_oneof:"source"`
 	// Expected worker count for this execution unit
 	Workers int32 `protobuf:"varint,10,opt,name=workers,proto3" json:"workers,omitempty"`
@@ -494,12 +763,29 @@ type ExecutionUnit struct {
 	Timeout int32 `protobuf:"varint,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	// Configurable memory size for this instance
 	Memory int32 `protobuf:"varint,12,opt,name=memory,proto3" json:"memory,omitempty"`
+	// Target platforms for this execution unit (e.g. linux/amd64, linux/arm64)
+	// defaults to linux/amd64 when not provided
+	Platforms []string `protobuf:"bytes,13,rep,name=platforms,proto3" json:"platforms,omitempty"`
+	// User provided environment variables
+	Env map[string]string `protobuf:"bytes,14,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// CPU allocated to each instance in millicores (e.g. 1000 for 1 vCPU)
+	Cpu int32 `protobuf:"varint,15,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// Minimum number of instances kept running, avoiding cold starts
+	MinInstances int32 `protobuf:"varint,16,opt,name=min_instances,json=minInstances,proto3" json:"min_instances,omitempty"`
+	// Maximum number of instances
+	MaxInstances int32 `protobuf:"varint,17,opt,name=max_instances,json=maxInstances,proto3" json:"max_instances,omitempty"`
+	// Maximum number of concurrent requests handled by each instance
+	Concurrency int32 `protobuf:"varint,18,opt,name=concurrency,proto3" json:"concurrency,omitempty"`
+	// Sources this execution unit accepts traffic from
+	Ingress Ingress `protobuf:"varint,19,opt,name=ingress,proto3,enum=nitric.deploy.v1.Ingress" json:"ingress,omitempty"`
+	// Private network this execution unit is attached to
+	Network *NetworkAttachment `protobuf:"bytes,20,opt,name=network,proto3" json:"network,omitempty"`
 }
 
 func (x *ExecutionUnit) Reset() {
 	*x = ExecutionUnit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[8]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -512,7 +798,7 @@ func (x *ExecutionUnit) String() string {
 func (*ExecutionUnit) ProtoMessage() {}
 
 func (x *ExecutionUnit) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[8]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +811,7 @@ func (x *ExecutionUnit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionUnit.ProtoReflect.Descriptor instead.
 func (*ExecutionUnit) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{8}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{11}
 }
 
 func (m *ExecutionUnit) GetSource() isExecutionUnit_Source {
@@ -542,6 +828,20 @@ func (x *ExecutionUnit) GetImage() *ImageSource {
 	return nil
 }
 
+func (x *ExecutionUnit) GetZip() *ZipSource {
+	if x, ok := x.GetSource().(*ExecutionUnit_Zip); ok {
+		return x.Zip
+	}
+	return nil
+}
+
+func (x *ExecutionUnit) GetDirectory() *DirectorySource {
+	if x, ok := x.GetSource().(*ExecutionUnit_Directory); ok {
+		return x.Directory
+	}
+	return nil
+}
+
 func (x *ExecutionUnit) GetWorkers() int32 {
 	if x != nil {
 		return x.Workers
@@ -563,6 +863,62 @@ func (x *ExecutionUnit) GetMemory() int32 {
 	return 0
 }
 
+func (x *ExecutionUnit) GetPlatforms() []string {
+	if x != nil {
+		return x.Platforms
+	}
+	return nil
+}
+
+func (x *ExecutionUnit) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ExecutionUnit) GetCpu() int32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *ExecutionUnit) GetMinInstances() int32 {
+	if x != nil {
+		return x.MinInstances
+	}
+	return 0
+}
+
+func (x *ExecutionUnit) GetMaxInstances() int32 {
+	if x != nil {
+		return x.MaxInstances
+	}
+	return 0
+}
+
+func (x *ExecutionUnit) GetConcurrency() int32 {
+	if x != nil {
+		return x.Concurrency
+	}
+	return 0
+}
+
+func (x *ExecutionUnit) GetIngress() Ingress {
+	if x != nil {
+		return x.Ingress
+	}
+	return Ingress_IngressAll
+}
+
+func (x *ExecutionUnit) GetNetwork() *NetworkAttachment {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
 type isExecutionUnit_Source interface {
 	isExecutionUnit_Source()
 }
@@ -572,8 +928,22 @@ type ExecutionUnit_Image struct {
 	Image *ImageSource `protobuf:"bytes,1,opt,name=image,proto3,oneof"`
 }
 
+type ExecutionUnit_Zip struct {
+	// Zipped code as a execution unit
+	Zip *ZipSource `protobuf:"bytes,2,opt,name=zip,proto3,oneof"`
+}
+
+type ExecutionUnit_Directory struct {
+	// Source directory as a execution unit, built using language buildpacks
+	Directory *DirectorySource `protobuf:"bytes,3,opt,name=directory,proto3,oneof"`
+}
+
 func (*ExecutionUnit_Image) isExecutionUnit_Source() {}
 
+func (*ExecutionUnit_Zip) isExecutionUnit_Source() {}
+
+func (*ExecutionUnit_Directory) isExecutionUnit_Source() {}
+
 type Bucket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -583,7 +953,7 @@ type Bucket struct {
 func (x *Bucket) Reset() {
 	*x = Bucket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[9]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -596,7 +966,7 @@ func (x *Bucket) String() string {
 func (*Bucket) ProtoMessage() {}
 
 func (x *Bucket) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[9]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +979,7 @@ func (x *Bucket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Bucket.ProtoReflect.Descriptor instead.
 func (*Bucket) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{9}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{12}
 }
 
 type Topic struct {
@@ -624,7 +994,7 @@ type Topic struct {
 func (x *Topic) Reset() {
 	*x = Topic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[10]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -637,7 +1007,7 @@ func (x *Topic) String() string {
 func (*Topic) ProtoMessage() {}
 
 func (x *Topic) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[10]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,7 +1020,7 @@ func (x *Topic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Topic.ProtoReflect.Descriptor instead.
 func (*Topic) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{10}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Topic) GetSubscriptions() []*SubscriptionTarget {
@@ -669,7 +1039,7 @@ type Queue struct {
 func (x *Queue) Reset() {
 	*x = Queue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[11]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -682,7 +1052,7 @@ func (x *Queue) String() string {
 func (*Queue) ProtoMessage() {}
 
 func (x *Queue) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[11]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,7 +1065,7 @@ func (x *Queue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Queue.ProtoReflect.Descriptor instead.
 func (*Queue) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{11}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{14}
 }
 
 type Collection struct {
@@ -707,7 +1077,7 @@ type Collection struct {
 func (x *Collection) Reset() {
 	*x = Collection{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[12]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -720,7 +1090,7 @@ func (x *Collection) String() string {
 func (*Collection) ProtoMessage() {}
 
 func (x *Collection) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[12]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -733,7 +1103,7 @@ func (x *Collection) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Collection.ProtoReflect.Descriptor instead.
 func (*Collection) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{12}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{15}
 }
 
 type SubscriptionTarget struct {
@@ -752,7 +1122,7 @@ type SubscriptionTarget struct {
 func (x *SubscriptionTarget) Reset() {
 	*x = SubscriptionTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[13]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -765,7 +1135,7 @@ func (x *SubscriptionTarget) String() string {
 func (*SubscriptionTarget) ProtoMessage() {}
 
 func (x *SubscriptionTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[13]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -778,7 +1148,7 @@ func (x *SubscriptionTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionTarget.ProtoReflect.Descriptor instead.
 func (*SubscriptionTarget) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{13}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{16}
 }
 
 func (m *SubscriptionTarget) GetTarget() isSubscriptionTarget_Target {
@@ -817,7 +1187,7 @@ type TopicSubscription struct {
 func (x *TopicSubscription) Reset() {
 	*x = TopicSubscription{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[14]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -830,7 +1200,7 @@ func (x *TopicSubscription) String() string {
 func (*TopicSubscription) ProtoMessage() {}
 
 func (x *TopicSubscription) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[14]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -843,7 +1213,7 @@ func (x *TopicSubscription) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicSubscription.ProtoReflect.Descriptor instead.
 func (*TopicSubscription) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{14}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *TopicSubscription) GetTarget() *SubscriptionTarget {
@@ -864,12 +1234,16 @@ type Api struct {
 	//
 	//	*Api_Openapi
 	Document isApi_Document `protobuf_oneof:"document"`
+	// CORS configuration for the deployed api gateway
+	Cors *v1.ApiCorsDefinition `protobuf:"bytes,2,opt,name=cors,proto3" json:"cors,omitempty"`
+	// Custom domains the deployed api gateway is served from
+	Domains []*DomainMapping `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
 }
 
 func (x *Api) Reset() {
 	*x = Api{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[15]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -882,7 +1256,7 @@ func (x *Api) String() string {
 func (*Api) ProtoMessage() {}
 
 func (x *Api) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[15]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -895,7 +1269,7 @@ func (x *Api) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Api.ProtoReflect.Descriptor instead.
 func (*Api) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{15}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{18}
 }
 
 func (m *Api) GetDocument() isApi_Document {
@@ -912,6 +1286,20 @@ func (x *Api) GetOpenapi() string {
 	return ""
 }
 
+func (x *Api) GetCors() *v1.ApiCorsDefinition {
+	if x != nil {
+		return x.Cors
+	}
+	return nil
+}
+
+func (x *Api) GetDomains() []*DomainMapping {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
 type isApi_Document interface {
 	isApi_Document()
 }
@@ -924,6 +1312,111 @@ type Api_Openapi struct {
 
 func (*Api_Openapi) isApi_Document() {}
 
+// Maps a custom domain to a deployed api
+type DomainMapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fully qualified hostname (e.g. api.example.com)
+	Hostname string `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	// Base path the api is served from on the domain (e.g. /v1), defaults to the root of the domain
+	BasePath string `protobuf:"bytes,2,opt,name=base_path,json=basePath,proto3" json:"base_path,omitempty"`
+	// Certificate used for TLS on the domain
+	//
+	// Types that are assignable to Certificate:
+	//
+	//	*DomainMapping_CertificateRef
+	//	*DomainMapping_ManagedCertificate
+	Certificate isDomainMapping_Certificate `protobuf_oneof:"certificate"`
+}
+
+func (x *DomainMapping) Reset() {
+	*x = DomainMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainMapping) ProtoMessage() {}
+
+func (x *DomainMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainMapping.ProtoReflect.Descriptor instead.
+func (*DomainMapping) Descriptor() ([]byte, []int) {
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DomainMapping) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *DomainMapping) GetBasePath() string {
+	if x != nil {
+		return x.BasePath
+	}
+	return ""
+}
+
+func (m *DomainMapping) GetCertificate() isDomainMapping_Certificate {
+	if m != nil {
+		return m.Certificate
+	}
+	return nil
+}
+
+func (x *DomainMapping) GetCertificateRef() string {
+	if x, ok := x.GetCertificate().(*DomainMapping_CertificateRef); ok {
+		return x.CertificateRef
+	}
+	return ""
+}
+
+func (x *DomainMapping) GetManagedCertificate() bool {
+	if x, ok := x.GetCertificate().(*DomainMapping_ManagedCertificate); ok {
+		return x.ManagedCertificate
+	}
+	return false
+}
+
+type isDomainMapping_Certificate interface {
+	isDomainMapping_Certificate()
+}
+
+type DomainMapping_CertificateRef struct {
+	// Reference to an existing certificate
+	// (e.g. an ACM certificate ARN, a Key Vault secret ID or a GCP SSL certificate name)
+	CertificateRef string `protobuf:"bytes,3,opt,name=certificate_ref,json=certificateRef,proto3,oneof"`
+}
+
+type DomainMapping_ManagedCertificate struct {
+	// Provision a certificate for the hostname managed by the provider
+	ManagedCertificate bool `protobuf:"varint,4,opt,name=managed_certificate,json=managedCertificate,proto3,oneof"`
+}
+
+func (*DomainMapping_CertificateRef) isDomainMapping_Certificate() {}
+
+func (*DomainMapping_ManagedCertificate) isDomainMapping_Certificate() {}
+
 type ScheduleTarget struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -938,7 +1431,7 @@ type ScheduleTarget struct {
 func (x *ScheduleTarget) Reset() {
 	*x = ScheduleTarget{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[16]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -951,7 +1444,7 @@ func (x *ScheduleTarget) String() string {
 func (*ScheduleTarget) ProtoMessage() {}
 
 func (x *ScheduleTarget) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[16]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -964,7 +1457,7 @@ func (x *ScheduleTarget) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleTarget.ProtoReflect.Descriptor instead.
 func (*ScheduleTarget) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{16}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{20}
 }
 
 func (m *ScheduleTarget) GetTarget() isScheduleTarget_Target {
@@ -1004,7 +1497,7 @@ type Schedule struct {
 func (x *Schedule) Reset() {
 	*x = Schedule{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[17]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1017,7 +1510,7 @@ func (x *Schedule) String() string {
 func (*Schedule) ProtoMessage() {}
 
 func (x *Schedule) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[17]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1030,7 +1523,7 @@ func (x *Schedule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
 func (*Schedule) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{17}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Schedule) GetCron() string {
@@ -1070,7 +1563,7 @@ type Resource struct {
 func (x *Resource) Reset() {
 	*x = Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[18]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1083,7 +1576,7 @@ func (x *Resource) String() string {
 func (*Resource) ProtoMessage() {}
 
 func (x *Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[18]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1096,7 +1589,7 @@ func (x *Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Resource.ProtoReflect.Descriptor instead.
 func (*Resource) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{18}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *Resource) GetName() string {
@@ -1247,7 +1740,7 @@ type Policy struct {
 func (x *Policy) Reset() {
 	*x = Policy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[19]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1260,7 +1753,7 @@ func (x *Policy) String() string {
 func (*Policy) ProtoMessage() {}
 
 func (x *Policy) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[19]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1273,7 +1766,7 @@ func (x *Policy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Policy.ProtoReflect.Descriptor instead.
 func (*Policy) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{19}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Policy) GetPrincipals() []*Resource {
@@ -1309,7 +1802,7 @@ type Spec struct {
 func (x *Spec) Reset() {
 	*x = Spec{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[20]
+		mi := &file_proto_deploy_v1_deploy_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1322,7 +1815,7 @@ func (x *Spec) String() string {
 func (*Spec) ProtoMessage() {}
 
 func (x *Spec) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[20]
+	mi := &file_proto_deploy_v1_deploy_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,7 +1828,7 @@ func (x *Spec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Spec.ProtoReflect.Descriptor instead.
 func (*Spec) Descriptor() ([]byte, []int) {
-	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{20}
+	return file_proto_deploy_v1_deploy_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *Spec) GetResources() []*Resource {
@@ -1406,17 +1899,70 @@ var file_proto_deploy_v1_deploy_proto_rawDesc = []byte{
 	0x65, 0x6e, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x44, 0x6f, 0x77,
 	0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x1f, 0x0a, 0x0b,
 	0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75,
-	0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0x9c, 0x01,
-	0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x12,
-	0x35, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52,
-	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
-	0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73,
-	0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x08, 0x0a, 0x06,
+	0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x69, 0x22, 0x5b, 0x0a,
+	0x09, 0x5a, 0x69, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x6e,
+	0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x61, 0x0a, 0x0f, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0x95, 0x01,
+	0x0a, 0x11, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x66,
+	0x66, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x54, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x22, 0x94, 0x05, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x35, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2f,
+	0x0a, 0x03, 0x7a, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x5a,
+	0x69, 0x70, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x03, 0x7a, 0x69, 0x70, 0x12,
+	0x41, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x73, 0x12, 0x3a, 0x0a, 0x03,
+	0x65, 0x6e, 0x76, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69,
+	0x6e, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x33, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x08, 0x0a, 0x06,
 	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x53, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x12,
 	0x4a, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
@@ -1433,90 +1979,115 @@ var file_proto_deploy_v1_deploy_proto_rawDesc = []byte{
 	0x12, 0x3c, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
 	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x2d,
-	0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x1a, 0x0a, 0x07, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
-	0x69, 0x42, 0x0a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x43, 0x0a,
-	0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12,
-	0x27, 0x0a, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x78, 0x65, 0x63, 0x75,
-	0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x22, 0x58, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12,
-	0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72,
-	0x6f, 0x6e, 0x12, 0x38, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x97, 0x04, 0x0a,
-	0x08, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x12, 0x48, 0x0a, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0d,
-	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x32, 0x0a,
-	0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
-	0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x12, 0x2f, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x70,
-	0x69, 0x63, 0x12, 0x2f, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75,
-	0x65, 0x75, 0x65, 0x12, 0x29, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x48, 0x00, 0x52, 0x03, 0x61, 0x70, 0x69, 0x12, 0x32,
-	0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0xa3,
+	0x01, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x12, 0x1a, 0x0a, 0x07, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70,
+	0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x07, 0x6f, 0x70, 0x65, 0x6e, 0x61,
+	0x70, 0x69, 0x12, 0x39, 0x0a, 0x04, 0x63, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x43, 0x6f, 0x72, 0x73, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x63, 0x6f, 0x72, 0x73, 0x12, 0x39, 0x0a,
+	0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f,
 	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x48, 0x00, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x12, 0x38, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x10,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65,
-	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x48, 0x00, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x0a,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
-	0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xb4, 0x01, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63,
-	0x79, 0x12, 0x3a, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64,
+	0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x52,
+	0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0xb5, 0x01, 0x0a, 0x0d, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x4d,
+	0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61, 0x73, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x29, 0x0a, 0x0f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x72,
+	0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x63, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x13, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x64, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x12, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x64, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x42, 0x0d, 0x0a,
+	0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x22, 0x43, 0x0a, 0x0e,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x27,
+	0x0a, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x6e, 0x69, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0d, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x22, 0x58, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f,
+	0x6e, 0x12, 0x38, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x97, 0x04, 0x0a, 0x08,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x34, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x48, 0x0a, 0x0e, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x32, 0x0a, 0x06,
+	0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x48, 0x00, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x2f, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x12, 0x2f, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75, 0x65, 0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x12, 0x29, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x48, 0x00, 0x52, 0x03, 0x61, 0x70, 0x69, 0x12, 0x32, 0x0a,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x48, 0x00, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x38, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xb4, 0x01, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x3a, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x0a, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x73, 0x12, 0x34, 0x0a, 0x07,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64,
 	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6e, 0x63, 0x69, 0x70, 0x61, 0x6c, 0x73, 0x12, 0x34, 0x0a,
-	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x40, 0x0a,
-	0x04, 0x53, 0x70, 0x65, 0x63, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x32,
-	0xad, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x4a, 0x0a, 0x02, 0x55, 0x70, 0x12, 0x21, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6e, 0x69, 0x74,
+	0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x40, 0x0a, 0x04,
+	0x53, 0x70, 0x65, 0x63, 0x12, 0x38, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2a, 0x52,
+	0x0a, 0x07, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x6e, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x41, 0x6c, 0x6c, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x49, 0x6e, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x10, 0x01, 0x12, 0x22,
+	0x0a, 0x1e, 0x49, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61,
+	0x6c, 0x41, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72,
+	0x10, 0x02, 0x32, 0xad, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x02, 0x55, 0x70, 0x12, 0x21, 0x2e, 0x6e, 0x69, 0x74,
 	0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x70, 0x6c, 0x6f, 0x79, 0x55, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x50, 0x0a,
-	0x04, 0x44, 0x6f, 0x77, 0x6e, 0x12, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64,
-	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x44,
-	0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x70, 0x6c, 0x6f, 0x79, 0x44, 0x6f, 0x77, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42,
-	0x94, 0x01, 0x0a, 0x19, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x42, 0x07, 0x44,
-	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x73, 0x50, 0x01, 0x5a, 0x3a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f,
-	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x64, 0x65, 0x70, 0x6c, 0x6f,
-	0x79, 0x2f, 0x76, 0x31, 0xaa, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x16,
-	0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x44, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x70, 0x6c, 0x6f, 0x79, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x55, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01,
+	0x12, 0x50, 0x0a, 0x04, 0x44, 0x6f, 0x77, 0x6e, 0x12, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x44, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x44, 0x6f, 0x77, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x30, 0x01, 0x42, 0x94, 0x01, 0x0a, 0x19, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0x42, 0x07, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x73, 0x50, 0x01, 0x5a, 0x3a, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65,
+	0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x2f, 0x76, 0x31, 0xaa, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x2e, 0x76, 0x31,
+	0xca, 0x02, 0x16, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -1531,68 +2102,83 @@ func file_proto_deploy_v1_deploy_proto_rawDescGZIP() []byte {
 	return file_proto_deploy_v1_deploy_proto_rawDescData
 }
 
-var file_proto_deploy_v1_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_deploy_v1_deploy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_deploy_v1_deploy_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_proto_deploy_v1_deploy_proto_goTypes = []interface{}{
-	(*DeployUpRequest)(nil),       // 0: nitric.deploy.v1.DeployUpRequest
-	(*DeployUpEvent)(nil),         // 1: nitric.deploy.v1.DeployUpEvent
-	(*DeployEventMessage)(nil),    // 2: nitric.deploy.v1.DeployEventMessage
-	(*DeployUpEventResult)(nil),   // 3: nitric.deploy.v1.DeployUpEventResult
-	(*DeployDownRequest)(nil),     // 4: nitric.deploy.v1.DeployDownRequest
-	(*DeployDownEvent)(nil),       // 5: nitric.deploy.v1.DeployDownEvent
-	(*DeployDownEventResult)(nil), // 6: nitric.deploy.v1.DeployDownEventResult
-	(*ImageSource)(nil),           // 7: nitric.deploy.v1.ImageSource
-	(*ExecutionUnit)(nil),         // 8: nitric.deploy.v1.ExecutionUnit
-	(*Bucket)(nil),                // 9: nitric.deploy.v1.Bucket
-	(*Topic)(nil),                 // 10: nitric.deploy.v1.Topic
-	(*Queue)(nil),                 // 11: nitric.deploy.v1.Queue
-	(*Collection)(nil),            // 12: nitric.deploy.v1.Collection
-	(*SubscriptionTarget)(nil),    // 13: nitric.deploy.v1.SubscriptionTarget
-	(*TopicSubscription)(nil),     // 14: nitric.deploy.v1.TopicSubscription
-	(*Api)(nil),                   // 15: nitric.deploy.v1.Api
-	(*ScheduleTarget)(nil),        // 16: nitric.deploy.v1.ScheduleTarget
-	(*Schedule)(nil),              // 17: nitric.deploy.v1.Schedule
-	(*Resource)(nil),              // 18: nitric.deploy.v1.Resource
-	(*Policy)(nil),                // 19: nitric.deploy.v1.Policy
-	(*Spec)(nil),                  // 20: nitric.deploy.v1.Spec
-	nil,                           // 21: nitric.deploy.v1.DeployUpRequest.AttributesEntry
-	nil,                           // 22: nitric.deploy.v1.DeployDownRequest.AttributesEntry
-	(v1.ResourceType)(0),          // 23: nitric.resource.v1.ResourceType
-	(v1.Action)(0),                // 24: nitric.resource.v1.Action
+	(Ingress)(0),                  // 0: nitric.deploy.v1.Ingress
+	(*DeployUpRequest)(nil),       // 1: nitric.deploy.v1.DeployUpRequest
+	(*DeployUpEvent)(nil),         // 2: nitric.deploy.v1.DeployUpEvent
+	(*DeployEventMessage)(nil),    // 3: nitric.deploy.v1.DeployEventMessage
+	(*DeployUpEventResult)(nil),   // 4: nitric.deploy.v1.DeployUpEventResult
+	(*DeployDownRequest)(nil),     // 5: nitric.deploy.v1.DeployDownRequest
+	(*DeployDownEvent)(nil),       // 6: nitric.deploy.v1.DeployDownEvent
+	(*DeployDownEventResult)(nil), // 7: nitric.deploy.v1.DeployDownEventResult
+	(*ImageSource)(nil),           // 8: nitric.deploy.v1.ImageSource
+	(*ZipSource)(nil),             // 9: nitric.deploy.v1.ZipSource
+	(*DirectorySource)(nil),       // 10: nitric.deploy.v1.DirectorySource
+	(*NetworkAttachment)(nil),     // 11: nitric.deploy.v1.NetworkAttachment
+	(*ExecutionUnit)(nil),         // 12: nitric.deploy.v1.ExecutionUnit
+	(*Bucket)(nil),                // 13: nitric.deploy.v1.Bucket
+	(*Topic)(nil),                 // 14: nitric.deploy.v1.Topic
+	(*Queue)(nil),                 // 15: nitric.deploy.v1.Queue
+	(*Collection)(nil),            // 16: nitric.deploy.v1.Collection
+	(*SubscriptionTarget)(nil),    // 17: nitric.deploy.v1.SubscriptionTarget
+	(*TopicSubscription)(nil),     // 18: nitric.deploy.v1.TopicSubscription
+	(*Api)(nil),                   // 19: nitric.deploy.v1.Api
+	(*DomainMapping)(nil),         // 20: nitric.deploy.v1.DomainMapping
+	(*ScheduleTarget)(nil),        // 21: nitric.deploy.v1.ScheduleTarget
+	(*Schedule)(nil),              // 22: nitric.deploy.v1.Schedule
+	(*Resource)(nil),              // 23: nitric.deploy.v1.Resource
+	(*Policy)(nil),                // 24: nitric.deploy.v1.Policy
+	(*Spec)(nil),                  // 25: nitric.deploy.v1.Spec
+	nil,                           // 26: nitric.deploy.v1.DeployUpRequest.AttributesEntry
+	nil,                           // 27: nitric.deploy.v1.DeployDownRequest.AttributesEntry
+	nil,                           // 28: nitric.deploy.v1.ExecutionUnit.EnvEntry
+	(*v1.ApiCorsDefinition)(nil),  // 29: nitric.resource.v1.ApiCorsDefinition
+	(v1.ResourceType)(0),          // 30: nitric.resource.v1.ResourceType
+	(v1.Action)(0),                // 31: nitric.resource.v1.Action
 }
 var file_proto_deploy_v1_deploy_proto_depIdxs = []int32{
-	20, // 0: nitric.deploy.v1.DeployUpRequest.spec:type_name -> nitric.deploy.v1.Spec
-	21, // 1: nitric.deploy.v1.DeployUpRequest.attributes:type_name -> nitric.deploy.v1.DeployUpRequest.AttributesEntry
-	2,  // 2: nitric.deploy.v1.DeployUpEvent.message:type_name -> nitric.deploy.v1.DeployEventMessage
-	3,  // 3: nitric.deploy.v1.DeployUpEvent.result:type_name -> nitric.deploy.v1.DeployUpEventResult
-	22, // 4: nitric.deploy.v1.DeployDownRequest.attributes:type_name -> nitric.deploy.v1.DeployDownRequest.AttributesEntry
-	2,  // 5: nitric.deploy.v1.DeployDownEvent.message:type_name -> nitric.deploy.v1.DeployEventMessage
-	6,  // 6: nitric.deploy.v1.DeployDownEvent.result:type_name -> nitric.deploy.v1.DeployDownEventResult
-	7,  // 7: nitric.deploy.v1.ExecutionUnit.image:type_name -> nitric.deploy.v1.ImageSource
-	13, // 8: nitric.deploy.v1.Topic.subscriptions:type_name -> nitric.deploy.v1.SubscriptionTarget
-	13, // 9: nitric.deploy.v1.TopicSubscription.target:type_name -> nitric.deploy.v1.SubscriptionTarget
-	16, // 10: nitric.deploy.v1.Schedule.target:type_name -> nitric.deploy.v1.ScheduleTarget
-	23, // 11: nitric.deploy.v1.Resource.type:type_name -> nitric.resource.v1.ResourceType
-	8,  // 12: nitric.deploy.v1.Resource.execution_unit:type_name -> nitric.deploy.v1.ExecutionUnit
-	9,  // 13: nitric.deploy.v1.Resource.bucket:type_name -> nitric.deploy.v1.Bucket
-	10, // 14: nitric.deploy.v1.Resource.topic:type_name -> nitric.deploy.v1.Topic
-	11, // 15: nitric.deploy.v1.Resource.queue:type_name -> nitric.deploy.v1.Queue
-	15, // 16: nitric.deploy.v1.Resource.api:type_name -> nitric.deploy.v1.Api
-	19, // 17: nitric.deploy.v1.Resource.policy:type_name -> nitric.deploy.v1.Policy
-	17, // 18: nitric.deploy.v1.Resource.schedule:type_name -> nitric.deploy.v1.Schedule
-	12, // 19: nitric.deploy.v1.Resource.collection:type_name -> nitric.deploy.v1.Collection
-	18, // 20: nitric.deploy.v1.Policy.principals:type_name -> nitric.deploy.v1.Resource
-	24, // 21: nitric.deploy.v1.Policy.actions:type_name -> nitric.resource.v1.Action
-	18, // 22: nitric.deploy.v1.Policy.resources:type_name -> nitric.deploy.v1.Resource
-	18, // 23: nitric.deploy.v1.Spec.resources:type_name -> nitric.deploy.v1.Resource
-	0,  // 24: nitric.deploy.v1.DeployService.Up:input_type -> nitric.deploy.v1.DeployUpRequest
-	4,  // 25: nitric.deploy.v1.DeployService.Down:input_type -> nitric.deploy.v1.DeployDownRequest
-	1,  // 26: nitric.deploy.v1.DeployService.Up:output_type -> nitric.deploy.v1.DeployUpEvent
-	5,  // 27: nitric.deploy.v1.DeployService.Down:output_type -> nitric.deploy.v1.DeployDownEvent
-	26, // [26:28] is the sub-list for method output_type
-	24, // [24:26] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	25, // 0: nitric.deploy.v1.DeployUpRequest.spec:type_name -> nitric.deploy.v1.Spec
+	26, // 1: nitric.deploy.v1.DeployUpRequest.attributes:type_name -> nitric.deploy.v1.DeployUpRequest.AttributesEntry
+	3,  // 2: nitric.deploy.v1.DeployUpEvent.message:type_name -> nitric.deploy.v1.DeployEventMessage
+	4,  // 3: nitric.deploy.v1.DeployUpEvent.result:type_name -> nitric.deploy.v1.DeployUpEventResult
+	27, // 4: nitric.deploy.v1.DeployDownRequest.attributes:type_name -> nitric.deploy.v1.DeployDownRequest.AttributesEntry
+	3,  // 5: nitric.deploy.v1.DeployDownEvent.message:type_name -> nitric.deploy.v1.DeployEventMessage
+	7,  // 6: nitric.deploy.v1.DeployDownEvent.result:type_name -> nitric.deploy.v1.DeployDownEventResult
+	8,  // 7: nitric.deploy.v1.ExecutionUnit.image:type_name -> nitric.deploy.v1.ImageSource
+	9,  // 8: nitric.deploy.v1.ExecutionUnit.zip:type_name -> nitric.deploy.v1.ZipSource
+	10, // 9: nitric.deploy.v1.ExecutionUnit.directory:type_name -> nitric.deploy.v1.DirectorySource
+	28, // 10: nitric.deploy.v1.ExecutionUnit.env:type_name -> nitric.deploy.v1.ExecutionUnit.EnvEntry
+	0,  // 11: nitric.deploy.v1.ExecutionUnit.ingress:type_name -> nitric.deploy.v1.Ingress
+	11, // 12: nitric.deploy.v1.ExecutionUnit.network:type_name -> nitric.deploy.v1.NetworkAttachment
+	17, // 13: nitric.deploy.v1.Topic.subscriptions:type_name -> nitric.deploy.v1.SubscriptionTarget
+	17, // 14: nitric.deploy.v1.TopicSubscription.target:type_name -> nitric.deploy.v1.SubscriptionTarget
+	29, // 15: nitric.deploy.v1.Api.cors:type_name -> nitric.resource.v1.ApiCorsDefinition
+	20, // 16: nitric.deploy.v1.Api.domains:type_name -> nitric.deploy.v1.DomainMapping
+	21, // 17: nitric.deploy.v1.Schedule.target:type_name -> nitric.deploy.v1.ScheduleTarget
+	30, // 18: nitric.deploy.v1.Resource.type:type_name -> nitric.resource.v1.ResourceType
+	12, // 19: nitric.deploy.v1.Resource.execution_unit:type_name -> nitric.deploy.v1.ExecutionUnit
+	13, // 20: nitric.deploy.v1.Resource.bucket:type_name -> nitric.deploy.v1.Bucket
+	14, // 21: nitric.deploy.v1.Resource.topic:type_name -> nitric.deploy.v1.Topic
+	15, // 22: nitric.deploy.v1.Resource.queue:type_name -> nitric.deploy.v1.Queue
+	19, // 23: nitric.deploy.v1.Resource.api:type_name -> nitric.deploy.v1.Api
+	24, // 24: nitric.deploy.v1.Resource.policy:type_name -> nitric.deploy.v1.Policy
+	22, // 25: nitric.deploy.v1.Resource.schedule:type_name -> nitric.deploy.v1.Schedule
+	16, // 26: nitric.deploy.v1.Resource.collection:type_name -> nitric.deploy.v1.Collection
+	23, // 27: nitric.deploy.v1.Policy.principals:type_name -> nitric.deploy.v1.Resource
+	31, // 28: nitric.deploy.v1.Policy.actions:type_name -> nitric.resource.v1.Action
+	23, // 29: nitric.deploy.v1.Policy.resources:type_name -> nitric.deploy.v1.Resource
+	23, // 30: nitric.deploy.v1.Spec.resources:type_name -> nitric.deploy.v1.Resource
+	1,  // 31: nitric.deploy.v1.DeployService.Up:input_type -> nitric.deploy.v1.DeployUpRequest
+	5,  // 32: nitric.deploy.v1.DeployService.Down:input_type -> nitric.deploy.v1.DeployDownRequest
+	2,  // 33: nitric.deploy.v1.DeployService.Up:output_type -> nitric.deploy.v1.DeployUpEvent
+	6,  // 34: nitric.deploy.v1.DeployService.Down:output_type -> nitric.deploy.v1.DeployDownEvent
+	33, // [33:35] is the sub-list for method output_type
+	31, // [31:33] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_proto_deploy_v1_deploy_proto_init() }
@@ -1698,7 +2284,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecutionUnit); i {
+			switch v := v.(*ZipSource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1710,7 +2296,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Bucket); i {
+			switch v := v.(*DirectorySource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1722,7 +2308,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Topic); i {
+			switch v := v.(*NetworkAttachment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1734,7 +2320,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Queue); i {
+			switch v := v.(*ExecutionUnit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1746,7 +2332,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Collection); i {
+			switch v := v.(*Bucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1758,7 +2344,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriptionTarget); i {
+			switch v := v.(*Topic); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1770,7 +2356,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopicSubscription); i {
+			switch v := v.(*Queue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1782,7 +2368,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Api); i {
+			switch v := v.(*Collection); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1794,7 +2380,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleTarget); i {
+			switch v := v.(*SubscriptionTarget); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1806,7 +2392,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Schedule); i {
+			switch v := v.(*TopicSubscription); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1818,7 +2404,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Resource); i {
+			switch v := v.(*Api); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1830,7 +2416,7 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Policy); i {
+			switch v := v.(*DomainMapping); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1842,6 +2428,54 @@ func file_proto_deploy_v1_deploy_proto_init() {
 			}
 		}
 		file_proto_deploy_v1_deploy_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleTarget); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_deploy_v1_deploy_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_deploy_v1_deploy_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Resource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_deploy_v1_deploy_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Policy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_deploy_v1_deploy_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Spec); i {
 			case 0:
 				return &v.state
@@ -1862,19 +2496,25 @@ func file_proto_deploy_v1_deploy_proto_init() {
 		(*DeployDownEvent_Message)(nil),
 		(*DeployDownEvent_Result)(nil),
 	}
-	file_proto_deploy_v1_deploy_proto_msgTypes[8].OneofWrappers = []interface{}{
+	file_proto_deploy_v1_deploy_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*ExecutionUnit_Image)(nil),
+		(*ExecutionUnit_Zip)(nil),
+		(*ExecutionUnit_Directory)(nil),
 	}
-	file_proto_deploy_v1_deploy_proto_msgTypes[13].OneofWrappers = []interface{}{
+	file_proto_deploy_v1_deploy_proto_msgTypes[16].OneofWrappers = []interface{}{
 		(*SubscriptionTarget_ExecutionUnit)(nil),
 	}
-	file_proto_deploy_v1_deploy_proto_msgTypes[15].OneofWrappers = []interface{}{
+	file_proto_deploy_v1_deploy_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*Api_Openapi)(nil),
 	}
-	file_proto_deploy_v1_deploy_proto_msgTypes[16].OneofWrappers = []interface{}{
+	file_proto_deploy_v1_deploy_proto_msgTypes[19].OneofWrappers = []interface{}{
+		(*DomainMapping_CertificateRef)(nil),
+		(*DomainMapping_ManagedCertificate)(nil),
+	}
+	file_proto_deploy_v1_deploy_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*ScheduleTarget_ExecutionUnit)(nil),
 	}
-	file_proto_deploy_v1_deploy_proto_msgTypes[18].OneofWrappers = []interface{}{
+	file_proto_deploy_v1_deploy_proto_msgTypes[22].OneofWrappers = []interface{}{
 		(*Resource_ExecutionUnit)(nil),
 		(*Resource_Bucket)(nil),
 		(*Resource_Topic)(nil),
@@ -1889,13 +2529,14 @@ func file_proto_deploy_v1_deploy_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_deploy_v1_deploy_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      1,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_deploy_v1_deploy_proto_goTypes,
 		DependencyIndexes: file_proto_deploy_v1_deploy_proto_depIdxs,
+		EnumInfos:         file_proto_deploy_v1_deploy_proto_enumTypes,
 		MessageInfos:      file_proto_deploy_v1_deploy_proto_msgTypes,
 	}.Build()
 	File_proto_deploy_v1_deploy_proto = out.File
@@ -1013,6 +1013,323 @@ var _ interface {
 	ErrorName() string
 } = ImageSourceValidationError{}
 
+// Validate checks the field values on ZipSource with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ZipSource) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ZipSource with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ZipSourceMultiError, or nil
+// if none found.
+func (m *ZipSource) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ZipSource) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Path
+
+	// no validation rules for Language
+
+	// no validation rules for Entrypoint
+
+	if len(errors) > 0 {
+		return ZipSourceMultiError(errors)
+	}
+
+	return nil
+}
+
+// ZipSourceMultiError is an error wrapping multiple validation errors returned
+// by ZipSource.ValidateAll() if the designated constraints aren't met.
+type ZipSourceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ZipSourceMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ZipSourceMultiError) AllErrors() []error { return m }
+
+// ZipSourceValidationError is the validation error returned by
+// ZipSource.Validate if the designated constraints aren't met.
+type ZipSourceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ZipSourceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ZipSourceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ZipSourceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ZipSourceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ZipSourceValidationError) ErrorName() string { return "ZipSourceValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ZipSourceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sZipSource.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ZipSourceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ZipSourceValidationError{}
+
+// Validate checks the field values on DirectorySource with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *DirectorySource) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DirectorySource with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DirectorySourceMultiError, or nil if none found.
+func (m *DirectorySource) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DirectorySource) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Path
+
+	// no validation rules for Language
+
+	// no validation rules for Entrypoint
+
+	if len(errors) > 0 {
+		return DirectorySourceMultiError(errors)
+	}
+
+	return nil
+}
+
+// DirectorySourceMultiError is an error wrapping multiple validation errors
+// returned by DirectorySource.ValidateAll() if the designated constraints
+// aren't met.
+type DirectorySourceMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DirectorySourceMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DirectorySourceMultiError) AllErrors() []error { return m }
+
+// DirectorySourceValidationError is the validation error returned by
+// DirectorySource.Validate if the designated constraints aren't met.
+type DirectorySourceValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DirectorySourceValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DirectorySourceValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DirectorySourceValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DirectorySourceValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DirectorySourceValidationError) ErrorName() string { return "DirectorySourceValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DirectorySourceValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDirectorySource.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DirectorySourceValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DirectorySourceValidationError{}
+
+// Validate checks the field values on NetworkAttachment with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *NetworkAttachment) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on NetworkAttachment with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// NetworkAttachmentMultiError, or nil if none found.
+func (m *NetworkAttachment) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *NetworkAttachment) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Connector
+
+	// no validation rules for AllTraffic
+
+	if len(errors) > 0 {
+		return NetworkAttachmentMultiError(errors)
+	}
+
+	return nil
+}
+
+// NetworkAttachmentMultiError is an error wrapping multiple validation errors
+// returned by NetworkAttachment.ValidateAll() if the designated constraints
+// aren't met.
+type NetworkAttachmentMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m NetworkAttachmentMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m NetworkAttachmentMultiError) AllErrors() []error { return m }
+
+// NetworkAttachmentValidationError is the validation error returned by
+// NetworkAttachment.Validate if the designated constraints aren't met.
+type NetworkAttachmentValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e NetworkAttachmentValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e NetworkAttachmentValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e NetworkAttachmentValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e NetworkAttachmentValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e NetworkAttachmentValidationError) ErrorName() string {
+	return "NetworkAttachmentValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e NetworkAttachmentValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sNetworkAttachment.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = NetworkAttachmentValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = NetworkAttachmentValidationError{}
+
 // Validate checks the field values on ExecutionUnit with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -1041,6 +1358,47 @@ func (m *ExecutionUnit) validate(all bool) error {
 
 	// no validation rules for Memory
 
+	// no validation rules for Env
+
+	// no validation rules for Cpu
+
+	// no validation rules for MinInstances
+
+	// no validation rules for MaxInstances
+
+	// no validation rules for Concurrency
+
+	// no validation rules for Ingress
+
+	if all {
+		switch v := interface{}(m.GetNetwork()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ExecutionUnitValidationError{
+					field:  "Network",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ExecutionUnitValidationError{
+					field:  "Network",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetNetwork()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ExecutionUnitValidationError{
+				field:  "Network",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	switch m.Source.(type) {
 
 	case *ExecutionUnit_Image:
@@ -1074,6 +1432,68 @@ func (m *ExecutionUnit) validate(all bool) error {
 			}
 		}
 
+	case *ExecutionUnit_Zip:
+
+		if all {
+			switch v := interface{}(m.GetZip()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExecutionUnitValidationError{
+						field:  "Zip",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExecutionUnitValidationError{
+						field:  "Zip",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetZip()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExecutionUnitValidationError{
+					field:  "Zip",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ExecutionUnit_Directory:
+
+		if all {
+			switch v := interface{}(m.GetDirectory()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExecutionUnitValidationError{
+						field:  "Directory",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExecutionUnitValidationError{
+						field:  "Directory",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDirectory()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExecutionUnitValidationError{
+					field:  "Directory",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -1842,6 +2262,69 @@ func (m *Api) validate(all bool) error {
 
 	var errors []error
 
+	if all {
+		switch v := interface{}(m.GetCors()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ApiValidationError{
+					field:  "Cors",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ApiValidationError{
+					field:  "Cors",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCors()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApiValidationError{
+				field:  "Cors",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetDomains() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ApiValidationError{
+						field:  fmt.Sprintf("Domains[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ApiValidationError{
+						field:  fmt.Sprintf("Domains[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ApiValidationError{
+					field:  fmt.Sprintf("Domains[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	switch m.Document.(type) {
 
 	case *Api_Openapi:
@@ -1926,6 +2409,120 @@ var _ interface {
 	ErrorName() string
 } = ApiValidationError{}
 
+// Validate checks the field values on DomainMapping with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DomainMapping) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DomainMapping with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DomainMappingMultiError, or
+// nil if none found.
+func (m *DomainMapping) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DomainMapping) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Hostname
+
+	// no validation rules for BasePath
+
+	switch m.Certificate.(type) {
+
+	case *DomainMapping_CertificateRef:
+		// no validation rules for CertificateRef
+
+	case *DomainMapping_ManagedCertificate:
+		// no validation rules for ManagedCertificate
+
+	}
+
+	if len(errors) > 0 {
+		return DomainMappingMultiError(errors)
+	}
+
+	return nil
+}
+
+// DomainMappingMultiError is an error wrapping multiple validation errors
+// returned by DomainMapping.ValidateAll() if the designated constraints
+// aren't met.
+type DomainMappingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DomainMappingMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DomainMappingMultiError) AllErrors() []error { return m }
+
+// DomainMappingValidationError is the validation error returned by
+// DomainMapping.Validate if the designated constraints aren't met.
+type DomainMappingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DomainMappingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DomainMappingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DomainMappingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DomainMappingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DomainMappingValidationError) ErrorName() string { return "DomainMappingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DomainMappingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDomainMapping.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DomainMappingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DomainMappingValidationError{}
+
 // Validate checks the field values on ScheduleTarget with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
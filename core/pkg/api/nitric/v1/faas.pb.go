@@ -9,6 +9,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type WebsocketEvent int32
+
+const (
+	// Opening a new connection on the websocket
+	WebsocketEvent_Connect WebsocketEvent = 0
+	// Closing a connection on the websocket
+	WebsocketEvent_Disconnect WebsocketEvent = 1
+	// Sending a message to the websocket
+	WebsocketEvent_Message WebsocketEvent = 2
+)
+
+// Enum value maps for WebsocketEvent.
+var (
+	WebsocketEvent_name = map[int32]string{
+		0: "Connect",
+		1: "Disconnect",
+		2: "Message",
+	}
+	WebsocketEvent_value = map[string]int32{
+		"Connect":    0,
+		"Disconnect": 1,
+		"Message":    2,
+	}
+)
+
+func (x WebsocketEvent) Enum() *WebsocketEvent {
+	p := new(WebsocketEvent)
+	*p = x
+	return p
+}
+
+func (x WebsocketEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebsocketEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_faas_v1_faas_proto_enumTypes[0].Descriptor()
+}
+
+func (WebsocketEvent) Type() protoreflect.EnumType {
+	return &file_proto_faas_v1_faas_proto_enumTypes[0]
+}
+
+func (x WebsocketEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebsocketEvent.Descriptor instead.
+func (WebsocketEvent) EnumDescriptor() ([]byte, []int) {
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{0}
+}
+
 // Messages the client is able to send to the server
 type ClientMessage struct {
 	state         protoimpl.MessageState
@@ -32,6 +85,7 @@ type ClientMessage struct {
 	//
 	//	*ClientMessage_InitRequest
 	//	*ClientMessage_TriggerResponse
+	//	*ClientMessage_BodyChunk
 	Content isClientMessage_Content `protobuf_oneof:"content"`
 }
 
@@ -95,6 +149,13 @@ func (x *ClientMessage) GetTriggerResponse() *TriggerResponse {
 	return nil
 }
 
+func (x *ClientMessage) GetBodyChunk() *BodyChunk {
+	if x, ok := x.GetContent().(*ClientMessage_BodyChunk); ok {
+		return x.BodyChunk
+	}
+	return nil
+}
+
 type isClientMessage_Content interface {
 	isClientMessage_Content()
 }
@@ -112,10 +173,18 @@ type ClientMessage_TriggerResponse struct {
 	TriggerResponse *TriggerResponse `protobuf:"bytes,3,opt,name=trigger_response,json=triggerResponse,proto3,oneof"`
 }
 
+type ClientMessage_BodyChunk struct {
+	// Client streaming a chunk of a response body
+	// for a trigger response with streamed_body set
+	BodyChunk *BodyChunk `protobuf:"bytes,4,opt,name=body_chunk,json=bodyChunk,proto3,oneof"`
+}
+
 func (*ClientMessage_InitRequest) isClientMessage_Content() {}
 
 func (*ClientMessage_TriggerResponse) isClientMessage_Content() {}
 
+func (*ClientMessage_BodyChunk) isClientMessage_Content() {}
+
 // Messages the server is able to send to the client
 type ServerMessage struct {
 	state         protoimpl.MessageState
@@ -128,6 +197,7 @@ type ServerMessage struct {
 	//
 	//	*ServerMessage_InitResponse
 	//	*ServerMessage_TriggerRequest
+	//	*ServerMessage_BodyChunk
 	Content isServerMessage_Content `protobuf_oneof:"content"`
 }
 
@@ -191,6 +261,13 @@ func (x *ServerMessage) GetTriggerRequest() *TriggerRequest {
 	return nil
 }
 
+func (x *ServerMessage) GetBodyChunk() *BodyChunk {
+	if x, ok := x.GetContent().(*ServerMessage_BodyChunk); ok {
+		return x.BodyChunk
+	}
+	return nil
+}
+
 type isServerMessage_Content interface {
 	isServerMessage_Content()
 }
@@ -208,10 +285,77 @@ type ServerMessage_TriggerRequest struct {
 	TriggerRequest *TriggerRequest `protobuf:"bytes,3,opt,name=trigger_request,json=triggerRequest,proto3,oneof"`
 }
 
+type ServerMessage_BodyChunk struct {
+	// Server streaming a chunk of a request body
+	// for a trigger request with streamed_body set
+	BodyChunk *BodyChunk `protobuf:"bytes,4,opt,name=body_chunk,json=bodyChunk,proto3,oneof"`
+}
+
 func (*ServerMessage_InitResponse) isServerMessage_Content() {}
 
 func (*ServerMessage_TriggerRequest) isServerMessage_Content() {}
 
+func (*ServerMessage_BodyChunk) isServerMessage_Content() {}
+
+// A chunk of a streamed HTTP request or response body
+// Chunks share the id of the trigger request/response they belong to
+type BodyChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunk data
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// Set on the final chunk of the body
+	Last bool `protobuf:"varint,2,opt,name=last,proto3" json:"last,omitempty"`
+}
+
+func (x *BodyChunk) Reset() {
+	*x = BodyChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyChunk) ProtoMessage() {}
+
+func (x *BodyChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyChunk.ProtoReflect.Descriptor instead.
+func (*BodyChunk) Descriptor() ([]byte, []int) {
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BodyChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BodyChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
 type ApiWorkerScopes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -223,7 +367,7 @@ type ApiWorkerScopes struct {
 func (x *ApiWorkerScopes) Reset() {
 	*x = ApiWorkerScopes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[2]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -236,7 +380,7 @@ func (x *ApiWorkerScopes) String() string {
 func (*ApiWorkerScopes) ProtoMessage() {}
 
 func (x *ApiWorkerScopes) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[2]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +393,7 @@ func (x *ApiWorkerScopes) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiWorkerScopes.ProtoReflect.Descriptor instead.
 func (*ApiWorkerScopes) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{2}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ApiWorkerScopes) GetScopes() []string {
@@ -270,12 +414,14 @@ type ApiWorkerOptions struct {
 	// We need to do this as the default value of a repeated field
 	// is always empty so there is no way of knowing if security is explicitly disabled
 	SecurityDisabled bool `protobuf:"varint,2,opt,name=security_disabled,json=securityDisabled,proto3" json:"security_disabled,omitempty"`
+	// Rate limit applied to requests to this route, in addition to any limit of the api
+	RateLimit *ApiRateLimit `protobuf:"bytes,3,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
 }
 
 func (x *ApiWorkerOptions) Reset() {
 	*x = ApiWorkerOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[3]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -288,7 +434,7 @@ func (x *ApiWorkerOptions) String() string {
 func (*ApiWorkerOptions) ProtoMessage() {}
 
 func (x *ApiWorkerOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[3]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +447,7 @@ func (x *ApiWorkerOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiWorkerOptions.ProtoReflect.Descriptor instead.
 func (*ApiWorkerOptions) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{3}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ApiWorkerOptions) GetSecurity() map[string]*ApiWorkerScopes {
@@ -318,6 +464,13 @@ func (x *ApiWorkerOptions) GetSecurityDisabled() bool {
 	return false
 }
 
+func (x *ApiWorkerOptions) GetRateLimit() *ApiRateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
 type ApiWorker struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -332,7 +485,7 @@ type ApiWorker struct {
 func (x *ApiWorker) Reset() {
 	*x = ApiWorker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[4]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -345,7 +498,7 @@ func (x *ApiWorker) String() string {
 func (*ApiWorker) ProtoMessage() {}
 
 func (x *ApiWorker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[4]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +511,7 @@ func (x *ApiWorker) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiWorker.ProtoReflect.Descriptor instead.
 func (*ApiWorker) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{4}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ApiWorker) GetApi() string {
@@ -400,7 +553,7 @@ type SubscriptionWorker struct {
 func (x *SubscriptionWorker) Reset() {
 	*x = SubscriptionWorker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[5]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -413,7 +566,7 @@ func (x *SubscriptionWorker) String() string {
 func (*SubscriptionWorker) ProtoMessage() {}
 
 func (x *SubscriptionWorker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[5]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -426,7 +579,7 @@ func (x *SubscriptionWorker) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionWorker.ProtoReflect.Descriptor instead.
 func (*SubscriptionWorker) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{5}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SubscriptionWorker) GetTopic() string {
@@ -452,7 +605,7 @@ type ScheduleWorker struct {
 func (x *ScheduleWorker) Reset() {
 	*x = ScheduleWorker{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[6]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -465,7 +618,7 @@ func (x *ScheduleWorker) String() string {
 func (*ScheduleWorker) ProtoMessage() {}
 
 func (x *ScheduleWorker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[6]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -478,7 +631,7 @@ func (x *ScheduleWorker) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleWorker.ProtoReflect.Descriptor instead.
 func (*ScheduleWorker) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{6}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ScheduleWorker) GetKey() string {
@@ -525,6 +678,63 @@ func (*ScheduleWorker_Rate) isScheduleWorker_Cadence() {}
 
 func (*ScheduleWorker_Cron) isScheduleWorker_Cadence() {}
 
+type WebsocketWorker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the socket this worker handles events for
+	Socket string `protobuf:"bytes,1,opt,name=socket,proto3" json:"socket,omitempty"`
+	// The websocket event this worker handles
+	Event WebsocketEvent `protobuf:"varint,2,opt,name=event,proto3,enum=nitric.faas.v1.WebsocketEvent" json:"event,omitempty"`
+}
+
+func (x *WebsocketWorker) Reset() {
+	*x = WebsocketWorker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebsocketWorker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebsocketWorker) ProtoMessage() {}
+
+func (x *WebsocketWorker) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebsocketWorker.ProtoReflect.Descriptor instead.
+func (*WebsocketWorker) Descriptor() ([]byte, []int) {
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WebsocketWorker) GetSocket() string {
+	if x != nil {
+		return x.Socket
+	}
+	return ""
+}
+
+func (x *WebsocketWorker) GetEvent() WebsocketEvent {
+	if x != nil {
+		return x.Event
+	}
+	return WebsocketEvent_Connect
+}
+
 type ScheduleRate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -536,7 +746,7 @@ type ScheduleRate struct {
 func (x *ScheduleRate) Reset() {
 	*x = ScheduleRate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[7]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -549,7 +759,7 @@ func (x *ScheduleRate) String() string {
 func (*ScheduleRate) ProtoMessage() {}
 
 func (x *ScheduleRate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[7]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,7 +772,7 @@ func (x *ScheduleRate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleRate.ProtoReflect.Descriptor instead.
 func (*ScheduleRate) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{7}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ScheduleRate) GetRate() string {
@@ -583,7 +793,7 @@ type ScheduleCron struct {
 func (x *ScheduleCron) Reset() {
 	*x = ScheduleCron{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[8]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -596,7 +806,7 @@ func (x *ScheduleCron) String() string {
 func (*ScheduleCron) ProtoMessage() {}
 
 func (x *ScheduleCron) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[8]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +819,7 @@ func (x *ScheduleCron) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScheduleCron.ProtoReflect.Descriptor instead.
 func (*ScheduleCron) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{8}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ScheduleCron) GetCron() string {
@@ -634,13 +844,20 @@ type InitRequest struct {
 	//	*InitRequest_Api
 	//	*InitRequest_Subscription
 	//	*InitRequest_Schedule
+	//	*InitRequest_Websocket
 	Worker isInitRequest_Worker `protobuf_oneof:"Worker"`
+	// The worker is able to receive request bodies as BodyChunk messages
+	// Workers that don't set this will always receive the full body in TriggerRequest.data
+	BodyStreaming bool `protobuf:"varint,1,opt,name=body_streaming,json=bodyStreaming,proto3" json:"body_streaming,omitempty"`
+	// The maximum number of triggers the worker will handle at once, unlimited if 0
+	// Triggers beyond this are queued by the membrane until a slot is free or its queue timeout elapses
+	MaxConcurrency int32 `protobuf:"varint,2,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
 }
 
 func (x *InitRequest) Reset() {
 	*x = InitRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[9]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -653,7 +870,7 @@ func (x *InitRequest) String() string {
 func (*InitRequest) ProtoMessage() {}
 
 func (x *InitRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[9]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,7 +883,7 @@ func (x *InitRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitRequest.ProtoReflect.Descriptor instead.
 func (*InitRequest) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{9}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{11}
 }
 
 func (m *InitRequest) GetWorker() isInitRequest_Worker {
@@ -697,6 +914,27 @@ func (x *InitRequest) GetSchedule() *ScheduleWorker {
 	return nil
 }
 
+func (x *InitRequest) GetWebsocket() *WebsocketWorker {
+	if x, ok := x.GetWorker().(*InitRequest_Websocket); ok {
+		return x.Websocket
+	}
+	return nil
+}
+
+func (x *InitRequest) GetBodyStreaming() bool {
+	if x != nil {
+		return x.BodyStreaming
+	}
+	return false
+}
+
+func (x *InitRequest) GetMaxConcurrency() int32 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
 type isInitRequest_Worker interface {
 	isInitRequest_Worker()
 }
@@ -713,12 +951,18 @@ type InitRequest_Schedule struct {
 	Schedule *ScheduleWorker `protobuf:"bytes,12,opt,name=schedule,proto3,oneof"`
 }
 
+type InitRequest_Websocket struct {
+	Websocket *WebsocketWorker `protobuf:"bytes,13,opt,name=websocket,proto3,oneof"`
+}
+
 func (*InitRequest_Api) isInitRequest_Worker() {}
 
 func (*InitRequest_Subscription) isInitRequest_Worker() {}
 
 func (*InitRequest_Schedule) isInitRequest_Worker() {}
 
+func (*InitRequest_Websocket) isInitRequest_Worker() {}
+
 // Placeholder message
 type InitResponse struct {
 	state         protoimpl.MessageState
@@ -729,7 +973,7 @@ type InitResponse struct {
 func (x *InitResponse) Reset() {
 	*x = InitResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[10]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -742,7 +986,7 @@ func (x *InitResponse) String() string {
 func (*InitResponse) ProtoMessage() {}
 
 func (x *InitResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[10]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -755,7 +999,7 @@ func (x *InitResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitResponse.ProtoReflect.Descriptor instead.
 func (*InitResponse) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{10}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{12}
 }
 
 type TraceContext struct {
@@ -769,7 +1013,7 @@ type TraceContext struct {
 func (x *TraceContext) Reset() {
 	*x = TraceContext{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[11]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -782,7 +1026,7 @@ func (x *TraceContext) String() string {
 func (*TraceContext) ProtoMessage() {}
 
 func (x *TraceContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[11]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -795,7 +1039,7 @@ func (x *TraceContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceContext.ProtoReflect.Descriptor instead.
 func (*TraceContext) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{11}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TraceContext) GetValues() map[string]string {
@@ -821,19 +1065,23 @@ type TriggerRequest struct {
 	// into each event request/response pair of the Bidirectional stream.
 	// which cannot be facilitated by OOTB stream interceptors from OTEL.
 	TraceContext *TraceContext `protobuf:"bytes,10,opt,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty"`
+	// The body will follow as BodyChunk messages with the same id
+	// data will be empty
+	StreamedBody bool `protobuf:"varint,11,opt,name=streamed_body,json=streamedBody,proto3" json:"streamed_body,omitempty"`
 	// The context of the trigger
 	//
 	// Types that are assignable to Context:
 	//
 	//	*TriggerRequest_Http
 	//	*TriggerRequest_Topic
+	//	*TriggerRequest_Websocket
 	Context isTriggerRequest_Context `protobuf_oneof:"context"`
 }
 
 func (x *TriggerRequest) Reset() {
 	*x = TriggerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[12]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -846,7 +1094,7 @@ func (x *TriggerRequest) String() string {
 func (*TriggerRequest) ProtoMessage() {}
 
 func (x *TriggerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[12]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,7 +1107,7 @@ func (x *TriggerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerRequest.ProtoReflect.Descriptor instead.
 func (*TriggerRequest) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{12}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TriggerRequest) GetData() []byte {
@@ -883,6 +1131,13 @@ func (x *TriggerRequest) GetTraceContext() *TraceContext {
 	return nil
 }
 
+func (x *TriggerRequest) GetStreamedBody() bool {
+	if x != nil {
+		return x.StreamedBody
+	}
+	return false
+}
+
 func (m *TriggerRequest) GetContext() isTriggerRequest_Context {
 	if m != nil {
 		return m.Context
@@ -904,6 +1159,13 @@ func (x *TriggerRequest) GetTopic() *TopicTriggerContext {
 	return nil
 }
 
+func (x *TriggerRequest) GetWebsocket() *WebsocketTriggerContext {
+	if x, ok := x.GetContext().(*TriggerRequest_Websocket); ok {
+		return x.Websocket
+	}
+	return nil
+}
+
 type isTriggerRequest_Context interface {
 	isTriggerRequest_Context()
 }
@@ -916,10 +1178,16 @@ type TriggerRequest_Topic struct {
 	Topic *TopicTriggerContext `protobuf:"bytes,4,opt,name=topic,proto3,oneof"`
 }
 
+type TriggerRequest_Websocket struct {
+	Websocket *WebsocketTriggerContext `protobuf:"bytes,5,opt,name=websocket,proto3,oneof"`
+}
+
 func (*TriggerRequest_Http) isTriggerRequest_Context() {}
 
 func (*TriggerRequest_Topic) isTriggerRequest_Context() {}
 
+func (*TriggerRequest_Websocket) isTriggerRequest_Context() {}
+
 type HeaderValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -931,7 +1199,7 @@ type HeaderValue struct {
 func (x *HeaderValue) Reset() {
 	*x = HeaderValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[13]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -944,7 +1212,7 @@ func (x *HeaderValue) String() string {
 func (*HeaderValue) ProtoMessage() {}
 
 func (x *HeaderValue) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[13]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,7 +1225,7 @@ func (x *HeaderValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeaderValue.ProtoReflect.Descriptor instead.
 func (*HeaderValue) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{13}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HeaderValue) GetValue() []string {
@@ -978,7 +1246,7 @@ type QueryValue struct {
 func (x *QueryValue) Reset() {
 	*x = QueryValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[14]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -991,7 +1259,7 @@ func (x *QueryValue) String() string {
 func (*QueryValue) ProtoMessage() {}
 
 func (x *QueryValue) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[14]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,7 +1272,7 @@ func (x *QueryValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryValue.ProtoReflect.Descriptor instead.
 func (*QueryValue) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{14}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *QueryValue) GetValue() []string {
@@ -1039,12 +1307,14 @@ type HttpTriggerContext struct {
 	QueryParams map[string]*QueryValue `protobuf:"bytes,6,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// HTTP Path parameters
 	PathParams map[string]string `protobuf:"bytes,7,rep,name=path_params,json=pathParams,proto3" json:"path_params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Claims of the validated bearer token, set when the route is secured by a JWT security definition
+	Claims *structpb.Struct `protobuf:"bytes,8,opt,name=claims,proto3" json:"claims,omitempty"`
 }
 
 func (x *HttpTriggerContext) Reset() {
 	*x = HttpTriggerContext{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[15]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1057,7 +1327,7 @@ func (x *HttpTriggerContext) String() string {
 func (*HttpTriggerContext) ProtoMessage() {}
 
 func (x *HttpTriggerContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[15]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1070,7 +1340,7 @@ func (x *HttpTriggerContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HttpTriggerContext.ProtoReflect.Descriptor instead.
 func (*HttpTriggerContext) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{15}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *HttpTriggerContext) GetMethod() string {
@@ -1124,6 +1394,13 @@ func (x *HttpTriggerContext) GetPathParams() map[string]string {
 	return nil
 }
 
+func (x *HttpTriggerContext) GetClaims() *structpb.Struct {
+	if x != nil {
+		return x.Claims
+	}
+	return nil
+}
+
 type TopicTriggerContext struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1136,7 +1413,7 @@ type TopicTriggerContext struct {
 func (x *TopicTriggerContext) Reset() {
 	*x = TopicTriggerContext{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[16]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1149,7 +1426,7 @@ func (x *TopicTriggerContext) String() string {
 func (*TopicTriggerContext) ProtoMessage() {}
 
 func (x *TopicTriggerContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[16]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,7 +1439,7 @@ func (x *TopicTriggerContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicTriggerContext.ProtoReflect.Descriptor instead.
 func (*TopicTriggerContext) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{16}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *TopicTriggerContext) GetTopic() string {
@@ -1172,6 +1449,81 @@ func (x *TopicTriggerContext) GetTopic() string {
 	return ""
 }
 
+type WebsocketTriggerContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the socket the event occurred on
+	Socket string `protobuf:"bytes,1,opt,name=socket,proto3" json:"socket,omitempty"`
+	// The websocket event
+	Event WebsocketEvent `protobuf:"varint,2,opt,name=event,proto3,enum=nitric.faas.v1.WebsocketEvent" json:"event,omitempty"`
+	// The connection the event occurred on, used to send messages back to the connection
+	ConnectionId string `protobuf:"bytes,3,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	// Query params of the connection request, only set for Connect events
+	QueryParams map[string]*QueryValue `protobuf:"bytes,4,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WebsocketTriggerContext) Reset() {
+	*x = WebsocketTriggerContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebsocketTriggerContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebsocketTriggerContext) ProtoMessage() {}
+
+func (x *WebsocketTriggerContext) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebsocketTriggerContext.ProtoReflect.Descriptor instead.
+func (*WebsocketTriggerContext) Descriptor() ([]byte, []int) {
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WebsocketTriggerContext) GetSocket() string {
+	if x != nil {
+		return x.Socket
+	}
+	return ""
+}
+
+func (x *WebsocketTriggerContext) GetEvent() WebsocketEvent {
+	if x != nil {
+		return x.Event
+	}
+	return WebsocketEvent_Connect
+}
+
+func (x *WebsocketTriggerContext) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *WebsocketTriggerContext) GetQueryParams() map[string]*QueryValue {
+	if x != nil {
+		return x.QueryParams
+	}
+	return nil
+}
+
 // The worker has successfully processed a trigger
 type TriggerResponse struct {
 	state         protoimpl.MessageState
@@ -1180,6 +1532,9 @@ type TriggerResponse struct {
 
 	// The data returned in the response
 	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The body will follow as BodyChunk messages with the same id
+	// data will be ignored, only supported for http responses
+	StreamedBody bool `protobuf:"varint,2,opt,name=streamed_body,json=streamedBody,proto3" json:"streamed_body,omitempty"`
 	// The context of the request response
 	// Typically this will be one to one with the Trigger Context
 	// i.e. if you receive http context you may return http context
@@ -1188,13 +1543,14 @@ type TriggerResponse struct {
 	//
 	//	*TriggerResponse_Http
 	//	*TriggerResponse_Topic
+	//	*TriggerResponse_Websocket
 	Context isTriggerResponse_Context `protobuf_oneof:"context"`
 }
 
 func (x *TriggerResponse) Reset() {
 	*x = TriggerResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[17]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1207,7 +1563,7 @@ func (x *TriggerResponse) String() string {
 func (*TriggerResponse) ProtoMessage() {}
 
 func (x *TriggerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[17]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1220,7 +1576,7 @@ func (x *TriggerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TriggerResponse.ProtoReflect.Descriptor instead.
 func (*TriggerResponse) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{17}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *TriggerResponse) GetData() []byte {
@@ -1230,6 +1586,13 @@ func (x *TriggerResponse) GetData() []byte {
 	return nil
 }
 
+func (x *TriggerResponse) GetStreamedBody() bool {
+	if x != nil {
+		return x.StreamedBody
+	}
+	return false
+}
+
 func (m *TriggerResponse) GetContext() isTriggerResponse_Context {
 	if m != nil {
 		return m.Context
@@ -1251,6 +1614,13 @@ func (x *TriggerResponse) GetTopic() *TopicResponseContext {
 	return nil
 }
 
+func (x *TriggerResponse) GetWebsocket() *WebsocketResponseContext {
+	if x, ok := x.GetContext().(*TriggerResponse_Websocket); ok {
+		return x.Websocket
+	}
+	return nil
+}
+
 type isTriggerResponse_Context interface {
 	isTriggerResponse_Context()
 }
@@ -1265,10 +1635,17 @@ type TriggerResponse_Topic struct {
 	Topic *TopicResponseContext `protobuf:"bytes,11,opt,name=topic,proto3,oneof"`
 }
 
+type TriggerResponse_Websocket struct {
+	// response to a websocket event
+	Websocket *WebsocketResponseContext `protobuf:"bytes,12,opt,name=websocket,proto3,oneof"`
+}
+
 func (*TriggerResponse_Http) isTriggerResponse_Context() {}
 
 func (*TriggerResponse_Topic) isTriggerResponse_Context() {}
 
+func (*TriggerResponse_Websocket) isTriggerResponse_Context() {}
+
 // Specific HttpResponse message
 // Note this does not have to be handled by the
 // User at all but they will have the option of control
@@ -1292,7 +1669,7 @@ type HttpResponseContext struct {
 func (x *HttpResponseContext) Reset() {
 	*x = HttpResponseContext{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[18]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1305,7 +1682,7 @@ func (x *HttpResponseContext) String() string {
 func (*HttpResponseContext) ProtoMessage() {}
 
 func (x *HttpResponseContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[18]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1318,7 +1695,7 @@ func (x *HttpResponseContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HttpResponseContext.ProtoReflect.Descriptor instead.
 func (*HttpResponseContext) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{18}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{21}
 }
 
 // Deprecated: Do not use.
@@ -1358,7 +1735,7 @@ type TopicResponseContext struct {
 func (x *TopicResponseContext) Reset() {
 	*x = TopicResponseContext{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_faas_v1_faas_proto_msgTypes[19]
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1371,7 +1748,7 @@ func (x *TopicResponseContext) String() string {
 func (*TopicResponseContext) ProtoMessage() {}
 
 func (x *TopicResponseContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_faas_v1_faas_proto_msgTypes[19]
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1384,7 +1761,7 @@ func (x *TopicResponseContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopicResponseContext.ProtoReflect.Descriptor instead.
 func (*TopicResponseContext) Descriptor() ([]byte, []int) {
-	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{19}
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *TopicResponseContext) GetSuccess() bool {
@@ -1394,12 +1771,66 @@ func (x *TopicResponseContext) GetSuccess() bool {
 	return false
 }
 
+// Specific websocket event response message
+type WebsocketResponseContext struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Success status of the handled event
+	// A failed Connect event rejects the connection
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *WebsocketResponseContext) Reset() {
+	*x = WebsocketResponseContext{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_faas_v1_faas_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WebsocketResponseContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebsocketResponseContext) ProtoMessage() {}
+
+func (x *WebsocketResponseContext) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_faas_v1_faas_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebsocketResponseContext.ProtoReflect.Descriptor instead.
+func (*WebsocketResponseContext) Descriptor() ([]byte, []int) {
+	return file_proto_faas_v1_faas_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WebsocketResponseContext) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_proto_faas_v1_faas_proto protoreflect.FileDescriptor
 
 var file_proto_faas_v1_faas_proto_rawDesc = []byte{
 	0x0a, 0x18, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x66, 0x61, 0x61, 0x73, 0x2f, 0x76, 0x31, 0x2f,
 	0x66, 0x61, 0x61, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x6e, 0x69, 0x74, 0x72,
-	0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x22, 0xba, 0x01, 0x0a, 0x0d, 0x43,
+	0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf6, 0x01, 0x0a, 0x0d, 0x43,
 	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x40, 0x0a, 0x0c,
 	0x69, 0x6e, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
@@ -1410,75 +1841,105 @@ var file_proto_faas_v1_faas_proto_rawDesc = []byte{
 	0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65,
 	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x74, 0x72, 0x69,
-	0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x09, 0x0a, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xba, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x43, 0x0a, 0x0d, 0x69, 0x6e, 0x69,
-	0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00,
-	0x52, 0x0c, 0x69, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49,
-	0x0a, 0x0f, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x74, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
-	0x74, 0x65, 0x6e, 0x74, 0x22, 0x29, 0x0a, 0x0f, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22,
-	0xe9, 0x01, 0x0a, 0x10, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
-	0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
-	0x12, 0x2b, 0x0a, 0x11, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x69, 0x73,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x65, 0x63,
-	0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x1a, 0x5c, 0x0a,
-	0x0d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x87, 0x01, 0x0a, 0x09,
-	0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x69,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x70, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x57,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x2a, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74,
-	0x6f, 0x70, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69,
-	0x63, 0x22, 0x95, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x57, 0x6f,
-	0x72, 0x6b, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61,
-	0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x61,
-	0x74, 0x65, 0x48, 0x00, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x63, 0x72,
-	0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
-	0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x42, 0x09,
-	0x0a, 0x07, 0x63, 0x61, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x22, 0x0a, 0x0c, 0x53, 0x63, 0x68,
-	0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x22, 0x0a,
-	0x0c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
-	0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f,
-	0x6e, 0x22, 0xce, 0x01, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2d, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03, 0x61, 0x70, 0x69,
-	0x12, 0x48, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x75,
-	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x63,
-	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63,
-	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08,
-	0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x57, 0x6f, 0x72, 0x6b,
+	0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a,
+	0x62, 0x6f, 0x64, 0x79, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x62,
+	0x6f, 0x64, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x22, 0xf6, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x43, 0x0a, 0x0d, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e,
+	0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0f, 0x74, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0e, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f, 0x64, 0x79, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6f, 0x64, 0x79, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x33, 0x0a, 0x09,
+	0x42, 0x6f, 0x64, 0x79, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x61, 0x73,
+	0x74, 0x22, 0x29, 0x0a, 0x0f, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x63,
+	0x6f, 0x70, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xaa, 0x02, 0x0a,
+	0x10, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x4a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2b, 0x0a,
+	0x11, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0a, 0x72, 0x61,
+	0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x52, 0x09, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x5c, 0x0a, 0x0d, 0x53,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x35,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x87, 0x01, 0x0a, 0x09, 0x41, 0x70,
+	0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x70, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x57, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x2a, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
+	0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x22,
+	0x95, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x57, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x63, 0x72, 0x6f, 0x6e,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x43, 0x72, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x42, 0x09, 0x0a, 0x07,
+	0x63, 0x61, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x5f, 0x0a, 0x0f, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x22, 0x0a, 0x0c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x22, 0x22, 0x0a, 0x0c,
+	0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x43, 0x72, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x63, 0x72, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e,
+	0x22, 0xdf, 0x02, 0x0a, 0x0b, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2d, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x70, 0x69, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x03, 0x61, 0x70, 0x69, 0x12,
+	0x48, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
+	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0c, 0x73, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x09, 0x77, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x77,
+	0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x6f, 0x64, 0x79,
+	0x5f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x62, 0x6f, 0x64, 0x79, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e, 0x67, 0x12,
+	0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x08, 0x0a, 0x06, 0x57, 0x6f, 0x72, 0x6b,
 	0x65, 0x72, 0x22, 0x0e, 0x0a, 0x0c, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x22, 0x8b, 0x01, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74,
 	0x65, 0x78, 0x74, 0x12, 0x40, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20,
@@ -1489,7 +1950,7 @@ var file_proto_faas_v1_faas_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x86, 0x02, 0x0a, 0x0e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x22, 0xf4, 0x02, 0x0a, 0x0e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f,
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65,
@@ -1497,130 +1958,174 @@ var file_proto_faas_v1_faas_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69,
 	0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61,
 	0x63, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x63, 0x65,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x38, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
-	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74,
-	0x70, 0x12, 0x3b, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x42, 0x09,
-	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x23, 0x0a, 0x0b, 0x48, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x22,
-	0x0a, 0x0a, 0x51, 0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x22, 0xed, 0x06, 0x0a, 0x12, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x57, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
-	0x5f, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6e, 0x69, 0x74,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x38, 0x0a, 0x04,
+	0x68, 0x74, 0x74, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6e, 0x69, 0x74,
 	0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70,
-	0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x02,
-	0x18, 0x01, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x12, 0x64,
-	0x0a, 0x10, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x5f, 0x6f,
-	0x6c, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00,
+	0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x3b, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
+	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f,
+	0x70, 0x69, 0x63, 0x12, 0x47, 0x0a, 0x09, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48,
+	0x00, 0x52, 0x09, 0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x42, 0x09, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x23, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x22, 0x0a, 0x0a,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x9e, 0x07, 0x0a, 0x12, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x12, 0x57, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x6f,
+	0x6c, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72,
+	0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x12, 0x64, 0x0a, 0x10,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x5f, 0x6f, 0x6c, 0x64,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x0e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4f,
+	0x6c, 0x64, 0x12, 0x49, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x56, 0x0a,
+	0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x53, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70,
+	0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x70, 0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x52, 0x06, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a,
+	0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5a, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x2b, 0x0a, 0x13, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69,
+	0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x22, 0xc5,
+	0x02, 0x0a, 0x17, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x72, 0x69, 0x67,
+	0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1e, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x5b, 0x0a,
+	0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x54, 0x72,
 	0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x4f, 0x6c, 0x64, 0x12, 0x49, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
-	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
-	0x56, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
-	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
-	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x72,
-	0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x53, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f,
-	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74,
-	0x74, 0x70, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74,
-	0x2e, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x0a, 0x70, 0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x3d, 0x0a, 0x0f,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x41, 0x0a, 0x13, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57,
-	0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x5a, 0x0a, 0x10, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5a, 0x0a, 0x10, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x74, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0x2b, 0x0a, 0x13, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x54, 0x72, 0x69, 0x67, 0x67,
-	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70,
-	0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x22,
-	0xa9, 0x01, 0x0a, 0x0f, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66,
-	0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74,
-	0x74, 0x70, 0x12, 0x3c, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
-	0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0xeb, 0x02, 0x0a, 0x13,
+	0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x98, 0x02, 0x0a, 0x0f, 0x54, 0x72, 0x69, 0x67, 0x67,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x65, 0x64, 0x42,
+	0x6f, 0x64, 0x79, 0x12, 0x39, 0x0a, 0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43,
+	0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x3c,
+	0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x48, 0x0a, 0x09,
+	0x77, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x48, 0x00, 0x52, 0x09, 0x77, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x22, 0xeb, 0x02, 0x0a, 0x13, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x58, 0x0a, 0x0b, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x5f, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33,
+	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e,
 	0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74,
-	0x65, 0x78, 0x74, 0x12, 0x58, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x5f, 0x6f,
-	0x6c, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x42, 0x02, 0x18,
-	0x01, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x1a, 0x57, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e,
-	0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x30, 0x0a, 0x14, 0x54, 0x6f, 0x70,
-	0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
-	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0x60, 0x0a, 0x0b, 0x46,
-	0x61, 0x61, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x0d, 0x54, 0x72,
-	0x69, 0x67, 0x67, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d, 0x2e, 0x6e, 0x69,
-	0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x6e, 0x69, 0x74,
-	0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x8a, 0x01,
-	0x0a, 0x17, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x42, 0x0a, 0x4e, 0x69, 0x74, 0x72, 0x69,
-	0x63, 0x46, 0x61, 0x61, 0x73, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x76, 0x31, 0xaa, 0x02, 0x14, 0x4e,
-	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x61, 0x61, 0x73,
-	0x2e, 0x76, 0x31, 0xca, 0x02, 0x14, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x5c, 0x46, 0x61, 0x61, 0x73, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x65, 0x78, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x4f, 0x6c, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x4a, 0x0a, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x6e,
+	0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74,
+	0x74, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78,
+	0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x4f, 0x6c, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x57, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x30, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x22, 0x34, 0x0a, 0x18, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x2a, 0x3a, 0x0a, 0x0e, 0x57, 0x65, 0x62, 0x73, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x10, 0x02, 0x32, 0x60, 0x0a, 0x0b, 0x46, 0x61, 0x61, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x51, 0x0a, 0x0d, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x53, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x66, 0x61, 0x61, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x8a, 0x01, 0x0a, 0x17, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x66, 0x61, 0x61, 0x73, 0x2e, 0x76,
+	0x31, 0x42, 0x0a, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x46, 0x61, 0x61, 0x73, 0x50, 0x01, 0x5a,
+	0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72,
+	0x69, 0x63, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x63, 0x2f, 0x76, 0x31, 0xaa, 0x02, 0x14, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x46, 0x61, 0x61, 0x73, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x14, 0x4e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x46, 0x61, 0x61, 0x73, 0x5c,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1635,74 +2140,94 @@ func file_proto_faas_v1_faas_proto_rawDescGZIP() []byte {
 	return file_proto_faas_v1_faas_proto_rawDescData
 }
 
-var file_proto_faas_v1_faas_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_proto_faas_v1_faas_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_faas_v1_faas_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_proto_faas_v1_faas_proto_goTypes = []interface{}{
-	(*ClientMessage)(nil),        // 0: nitric.faas.v1.ClientMessage
-	(*ServerMessage)(nil),        // 1: nitric.faas.v1.ServerMessage
-	(*ApiWorkerScopes)(nil),      // 2: nitric.faas.v1.ApiWorkerScopes
-	(*ApiWorkerOptions)(nil),     // 3: nitric.faas.v1.ApiWorkerOptions
-	(*ApiWorker)(nil),            // 4: nitric.faas.v1.ApiWorker
-	(*SubscriptionWorker)(nil),   // 5: nitric.faas.v1.SubscriptionWorker
-	(*ScheduleWorker)(nil),       // 6: nitric.faas.v1.ScheduleWorker
-	(*ScheduleRate)(nil),         // 7: nitric.faas.v1.ScheduleRate
-	(*ScheduleCron)(nil),         // 8: nitric.faas.v1.ScheduleCron
-	(*InitRequest)(nil),          // 9: nitric.faas.v1.InitRequest
-	(*InitResponse)(nil),         // 10: nitric.faas.v1.InitResponse
-	(*TraceContext)(nil),         // 11: nitric.faas.v1.TraceContext
-	(*TriggerRequest)(nil),       // 12: nitric.faas.v1.TriggerRequest
-	(*HeaderValue)(nil),          // 13: nitric.faas.v1.HeaderValue
-	(*QueryValue)(nil),           // 14: nitric.faas.v1.QueryValue
-	(*HttpTriggerContext)(nil),   // 15: nitric.faas.v1.HttpTriggerContext
-	(*TopicTriggerContext)(nil),  // 16: nitric.faas.v1.TopicTriggerContext
-	(*TriggerResponse)(nil),      // 17: nitric.faas.v1.TriggerResponse
-	(*HttpResponseContext)(nil),  // 18: nitric.faas.v1.HttpResponseContext
-	(*TopicResponseContext)(nil), // 19: nitric.faas.v1.TopicResponseContext
-	nil,                          // 20: nitric.faas.v1.ApiWorkerOptions.SecurityEntry
-	nil,                          // 21: nitric.faas.v1.TraceContext.ValuesEntry
-	nil,                          // 22: nitric.faas.v1.HttpTriggerContext.HeadersOldEntry
-	nil,                          // 23: nitric.faas.v1.HttpTriggerContext.QueryParamsOldEntry
-	nil,                          // 24: nitric.faas.v1.HttpTriggerContext.HeadersEntry
-	nil,                          // 25: nitric.faas.v1.HttpTriggerContext.QueryParamsEntry
-	nil,                          // 26: nitric.faas.v1.HttpTriggerContext.PathParamsEntry
-	nil,                          // 27: nitric.faas.v1.HttpResponseContext.HeadersOldEntry
-	nil,                          // 28: nitric.faas.v1.HttpResponseContext.HeadersEntry
+	(WebsocketEvent)(0),              // 0: nitric.faas.v1.WebsocketEvent
+	(*ClientMessage)(nil),            // 1: nitric.faas.v1.ClientMessage
+	(*ServerMessage)(nil),            // 2: nitric.faas.v1.ServerMessage
+	(*BodyChunk)(nil),                // 3: nitric.faas.v1.BodyChunk
+	(*ApiWorkerScopes)(nil),          // 4: nitric.faas.v1.ApiWorkerScopes
+	(*ApiWorkerOptions)(nil),         // 5: nitric.faas.v1.ApiWorkerOptions
+	(*ApiWorker)(nil),                // 6: nitric.faas.v1.ApiWorker
+	(*SubscriptionWorker)(nil),       // 7: nitric.faas.v1.SubscriptionWorker
+	(*ScheduleWorker)(nil),           // 8: nitric.faas.v1.ScheduleWorker
+	(*WebsocketWorker)(nil),          // 9: nitric.faas.v1.WebsocketWorker
+	(*ScheduleRate)(nil),             // 10: nitric.faas.v1.ScheduleRate
+	(*ScheduleCron)(nil),             // 11: nitric.faas.v1.ScheduleCron
+	(*InitRequest)(nil),              // 12: nitric.faas.v1.InitRequest
+	(*InitResponse)(nil),             // 13: nitric.faas.v1.InitResponse
+	(*TraceContext)(nil),             // 14: nitric.faas.v1.TraceContext
+	(*TriggerRequest)(nil),           // 15: nitric.faas.v1.TriggerRequest
+	(*HeaderValue)(nil),              // 16: nitric.faas.v1.HeaderValue
+	(*QueryValue)(nil),               // 17: nitric.faas.v1.QueryValue
+	(*HttpTriggerContext)(nil),       // 18: nitric.faas.v1.HttpTriggerContext
+	(*TopicTriggerContext)(nil),      // 19: nitric.faas.v1.TopicTriggerContext
+	(*WebsocketTriggerContext)(nil),  // 20: nitric.faas.v1.WebsocketTriggerContext
+	(*TriggerResponse)(nil),          // 21: nitric.faas.v1.TriggerResponse
+	(*HttpResponseContext)(nil),      // 22: nitric.faas.v1.HttpResponseContext
+	(*TopicResponseContext)(nil),     // 23: nitric.faas.v1.TopicResponseContext
+	(*WebsocketResponseContext)(nil), // 24: nitric.faas.v1.WebsocketResponseContext
+	nil,                              // 25: nitric.faas.v1.ApiWorkerOptions.SecurityEntry
+	nil,                              // 26: nitric.faas.v1.TraceContext.ValuesEntry
+	nil,                              // 27: nitric.faas.v1.HttpTriggerContext.HeadersOldEntry
+	nil,                              // 28: nitric.faas.v1.HttpTriggerContext.QueryParamsOldEntry
+	nil,                              // 29: nitric.faas.v1.HttpTriggerContext.HeadersEntry
+	nil,                              // 30: nitric.faas.v1.HttpTriggerContext.QueryParamsEntry
+	nil,                              // 31: nitric.faas.v1.HttpTriggerContext.PathParamsEntry
+	nil,                              // 32: nitric.faas.v1.WebsocketTriggerContext.QueryParamsEntry
+	nil,                              // 33: nitric.faas.v1.HttpResponseContext.HeadersOldEntry
+	nil,                              // 34: nitric.faas.v1.HttpResponseContext.HeadersEntry
+	(*ApiRateLimit)(nil),             // 35: nitric.resource.v1.ApiRateLimit
+	(*structpb.Struct)(nil),          // 36: google.protobuf.Struct
 }
 var file_proto_faas_v1_faas_proto_depIdxs = []int32{
-	9,  // 0: nitric.faas.v1.ClientMessage.init_request:type_name -> nitric.faas.v1.InitRequest
-	17, // 1: nitric.faas.v1.ClientMessage.trigger_response:type_name -> nitric.faas.v1.TriggerResponse
-	10, // 2: nitric.faas.v1.ServerMessage.init_response:type_name -> nitric.faas.v1.InitResponse
-	12, // 3: nitric.faas.v1.ServerMessage.trigger_request:type_name -> nitric.faas.v1.TriggerRequest
-	20, // 4: nitric.faas.v1.ApiWorkerOptions.security:type_name -> nitric.faas.v1.ApiWorkerOptions.SecurityEntry
-	3,  // 5: nitric.faas.v1.ApiWorker.options:type_name -> nitric.faas.v1.ApiWorkerOptions
-	7,  // 6: nitric.faas.v1.ScheduleWorker.rate:type_name -> nitric.faas.v1.ScheduleRate
-	8,  // 7: nitric.faas.v1.ScheduleWorker.cron:type_name -> nitric.faas.v1.ScheduleCron
-	4,  // 8: nitric.faas.v1.InitRequest.api:type_name -> nitric.faas.v1.ApiWorker
-	5,  // 9: nitric.faas.v1.InitRequest.subscription:type_name -> nitric.faas.v1.SubscriptionWorker
-	6,  // 10: nitric.faas.v1.InitRequest.schedule:type_name -> nitric.faas.v1.ScheduleWorker
-	21, // 11: nitric.faas.v1.TraceContext.values:type_name -> nitric.faas.v1.TraceContext.ValuesEntry
-	11, // 12: nitric.faas.v1.TriggerRequest.trace_context:type_name -> nitric.faas.v1.TraceContext
-	15, // 13: nitric.faas.v1.TriggerRequest.http:type_name -> nitric.faas.v1.HttpTriggerContext
-	16, // 14: nitric.faas.v1.TriggerRequest.topic:type_name -> nitric.faas.v1.TopicTriggerContext
-	22, // 15: nitric.faas.v1.HttpTriggerContext.headers_old:type_name -> nitric.faas.v1.HttpTriggerContext.HeadersOldEntry
-	23, // 16: nitric.faas.v1.HttpTriggerContext.query_params_old:type_name -> nitric.faas.v1.HttpTriggerContext.QueryParamsOldEntry
-	24, // 17: nitric.faas.v1.HttpTriggerContext.headers:type_name -> nitric.faas.v1.HttpTriggerContext.HeadersEntry
-	25, // 18: nitric.faas.v1.HttpTriggerContext.query_params:type_name -> nitric.faas.v1.HttpTriggerContext.QueryParamsEntry
-	26, // 19: nitric.faas.v1.HttpTriggerContext.path_params:type_name -> nitric.faas.v1.HttpTriggerContext.PathParamsEntry
-	18, // 20: nitric.faas.v1.TriggerResponse.http:type_name -> nitric.faas.v1.HttpResponseContext
-	19, // 21: nitric.faas.v1.TriggerResponse.topic:type_name -> nitric.faas.v1.TopicResponseContext
-	27, // 22: nitric.faas.v1.HttpResponseContext.headers_old:type_name -> nitric.faas.v1.HttpResponseContext.HeadersOldEntry
-	28, // 23: nitric.faas.v1.HttpResponseContext.headers:type_name -> nitric.faas.v1.HttpResponseContext.HeadersEntry
-	2,  // 24: nitric.faas.v1.ApiWorkerOptions.SecurityEntry.value:type_name -> nitric.faas.v1.ApiWorkerScopes
-	13, // 25: nitric.faas.v1.HttpTriggerContext.HeadersEntry.value:type_name -> nitric.faas.v1.HeaderValue
-	14, // 26: nitric.faas.v1.HttpTriggerContext.QueryParamsEntry.value:type_name -> nitric.faas.v1.QueryValue
-	13, // 27: nitric.faas.v1.HttpResponseContext.HeadersEntry.value:type_name -> nitric.faas.v1.HeaderValue
-	0,  // 28: nitric.faas.v1.FaasService.TriggerStream:input_type -> nitric.faas.v1.ClientMessage
-	1,  // 29: nitric.faas.v1.FaasService.TriggerStream:output_type -> nitric.faas.v1.ServerMessage
-	29, // [29:30] is the sub-list for method output_type
-	28, // [28:29] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	12, // 0: nitric.faas.v1.ClientMessage.init_request:type_name -> nitric.faas.v1.InitRequest
+	21, // 1: nitric.faas.v1.ClientMessage.trigger_response:type_name -> nitric.faas.v1.TriggerResponse
+	3,  // 2: nitric.faas.v1.ClientMessage.body_chunk:type_name -> nitric.faas.v1.BodyChunk
+	13, // 3: nitric.faas.v1.ServerMessage.init_response:type_name -> nitric.faas.v1.InitResponse
+	15, // 4: nitric.faas.v1.ServerMessage.trigger_request:type_name -> nitric.faas.v1.TriggerRequest
+	3,  // 5: nitric.faas.v1.ServerMessage.body_chunk:type_name -> nitric.faas.v1.BodyChunk
+	25, // 6: nitric.faas.v1.ApiWorkerOptions.security:type_name -> nitric.faas.v1.ApiWorkerOptions.SecurityEntry
+	35, // 7: nitric.faas.v1.ApiWorkerOptions.rate_limit:type_name -> nitric.resource.v1.ApiRateLimit
+	5,  // 8: nitric.faas.v1.ApiWorker.options:type_name -> nitric.faas.v1.ApiWorkerOptions
+	10, // 9: nitric.faas.v1.ScheduleWorker.rate:type_name -> nitric.faas.v1.ScheduleRate
+	11, // 10: nitric.faas.v1.ScheduleWorker.cron:type_name -> nitric.faas.v1.ScheduleCron
+	0,  // 11: nitric.faas.v1.WebsocketWorker.event:type_name -> nitric.faas.v1.WebsocketEvent
+	6,  // 12: nitric.faas.v1.InitRequest.api:type_name -> nitric.faas.v1.ApiWorker
+	7,  // 13: nitric.faas.v1.InitRequest.subscription:type_name -> nitric.faas.v1.SubscriptionWorker
+	8,  // 14: nitric.faas.v1.InitRequest.schedule:type_name -> nitric.faas.v1.ScheduleWorker
+	9,  // 15: nitric.faas.v1.InitRequest.websocket:type_name -> nitric.faas.v1.WebsocketWorker
+	26, // 16: nitric.faas.v1.TraceContext.values:type_name -> nitric.faas.v1.TraceContext.ValuesEntry
+	14, // 17: nitric.faas.v1.TriggerRequest.trace_context:type_name -> nitric.faas.v1.TraceContext
+	18, // 18: nitric.faas.v1.TriggerRequest.http:type_name -> nitric.faas.v1.HttpTriggerContext
+	19, // 19: nitric.faas.v1.TriggerRequest.topic:type_name -> nitric.faas.v1.TopicTriggerContext
+	20, // 20: nitric.faas.v1.TriggerRequest.websocket:type_name -> nitric.faas.v1.WebsocketTriggerContext
+	27, // 21: nitric.faas.v1.HttpTriggerContext.headers_old:type_name -> nitric.faas.v1.HttpTriggerContext.HeadersOldEntry
+	28, // 22: nitric.faas.v1.HttpTriggerContext.query_params_old:type_name -> nitric.faas.v1.HttpTriggerContext.QueryParamsOldEntry
+	29, // 23: nitric.faas.v1.HttpTriggerContext.headers:type_name -> nitric.faas.v1.HttpTriggerContext.HeadersEntry
+	30, // 24: nitric.faas.v1.HttpTriggerContext.query_params:type_name -> nitric.faas.v1.HttpTriggerContext.QueryParamsEntry
+	31, // 25: nitric.faas.v1.HttpTriggerContext.path_params:type_name -> nitric.faas.v1.HttpTriggerContext.PathParamsEntry
+	36, // 26: nitric.faas.v1.HttpTriggerContext.claims:type_name -> google.protobuf.Struct
+	0,  // 27: nitric.faas.v1.WebsocketTriggerContext.event:type_name -> nitric.faas.v1.WebsocketEvent
+	32, // 28: nitric.faas.v1.WebsocketTriggerContext.query_params:type_name -> nitric.faas.v1.WebsocketTriggerContext.QueryParamsEntry
+	22, // 29: nitric.faas.v1.TriggerResponse.http:type_name -> nitric.faas.v1.HttpResponseContext
+	23, // 30: nitric.faas.v1.TriggerResponse.topic:type_name -> nitric.faas.v1.TopicResponseContext
+	24, // 31: nitric.faas.v1.TriggerResponse.websocket:type_name -> nitric.faas.v1.WebsocketResponseContext
+	33, // 32: nitric.faas.v1.HttpResponseContext.headers_old:type_name -> nitric.faas.v1.HttpResponseContext.HeadersOldEntry
+	34, // 33: nitric.faas.v1.HttpResponseContext.headers:type_name -> nitric.faas.v1.HttpResponseContext.HeadersEntry
+	4,  // 34: nitric.faas.v1.ApiWorkerOptions.SecurityEntry.value:type_name -> nitric.faas.v1.ApiWorkerScopes
+	16, // 35: nitric.faas.v1.HttpTriggerContext.HeadersEntry.value:type_name -> nitric.faas.v1.HeaderValue
+	17, // 36: nitric.faas.v1.HttpTriggerContext.QueryParamsEntry.value:type_name -> nitric.faas.v1.QueryValue
+	17, // 37: nitric.faas.v1.WebsocketTriggerContext.QueryParamsEntry.value:type_name -> nitric.faas.v1.QueryValue
+	16, // 38: nitric.faas.v1.HttpResponseContext.HeadersEntry.value:type_name -> nitric.faas.v1.HeaderValue
+	1,  // 39: nitric.faas.v1.FaasService.TriggerStream:input_type -> nitric.faas.v1.ClientMessage
+	2,  // 40: nitric.faas.v1.FaasService.TriggerStream:output_type -> nitric.faas.v1.ServerMessage
+	40, // [40:41] is the sub-list for method output_type
+	39, // [39:40] is the sub-list for method input_type
+	39, // [39:39] is the sub-list for extension type_name
+	39, // [39:39] is the sub-list for extension extendee
+	0,  // [0:39] is the sub-list for field type_name
 }
 
 func init() { file_proto_faas_v1_faas_proto_init() }
@@ -1710,6 +2235,7 @@ func file_proto_faas_v1_faas_proto_init() {
 	if File_proto_faas_v1_faas_proto != nil {
 		return
 	}
+	file_proto_resource_v1_resource_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_proto_faas_v1_faas_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ClientMessage); i {
@@ -1736,7 +2262,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiWorkerScopes); i {
+			switch v := v.(*BodyChunk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1748,7 +2274,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiWorkerOptions); i {
+			switch v := v.(*ApiWorkerScopes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1760,7 +2286,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiWorker); i {
+			switch v := v.(*ApiWorkerOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1772,7 +2298,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriptionWorker); i {
+			switch v := v.(*ApiWorker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1784,7 +2310,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleWorker); i {
+			switch v := v.(*SubscriptionWorker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1796,7 +2322,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleRate); i {
+			switch v := v.(*ScheduleWorker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1808,7 +2334,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScheduleCron); i {
+			switch v := v.(*WebsocketWorker); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1820,7 +2346,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitRequest); i {
+			switch v := v.(*ScheduleRate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1832,7 +2358,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitResponse); i {
+			switch v := v.(*ScheduleCron); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1844,7 +2370,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceContext); i {
+			switch v := v.(*InitRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1856,7 +2382,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TriggerRequest); i {
+			switch v := v.(*InitResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1868,7 +2394,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeaderValue); i {
+			switch v := v.(*TraceContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1880,7 +2406,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryValue); i {
+			switch v := v.(*TriggerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1892,7 +2418,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HttpTriggerContext); i {
+			switch v := v.(*HeaderValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1904,7 +2430,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopicTriggerContext); i {
+			switch v := v.(*QueryValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1916,7 +2442,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TriggerResponse); i {
+			switch v := v.(*HttpTriggerContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1928,7 +2454,7 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HttpResponseContext); i {
+			switch v := v.(*TopicTriggerContext); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1940,6 +2466,42 @@ func file_proto_faas_v1_faas_proto_init() {
 			}
 		}
 		file_proto_faas_v1_faas_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebsocketTriggerContext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_faas_v1_faas_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TriggerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_faas_v1_faas_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HttpResponseContext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_faas_v1_faas_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TopicResponseContext); i {
 			case 0:
 				return &v.state
@@ -1951,44 +2513,62 @@ func file_proto_faas_v1_faas_proto_init() {
 				return nil
 			}
 		}
+		file_proto_faas_v1_faas_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebsocketResponseContext); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_proto_faas_v1_faas_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*ClientMessage_InitRequest)(nil),
 		(*ClientMessage_TriggerResponse)(nil),
+		(*ClientMessage_BodyChunk)(nil),
 	}
 	file_proto_faas_v1_faas_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*ServerMessage_InitResponse)(nil),
 		(*ServerMessage_TriggerRequest)(nil),
+		(*ServerMessage_BodyChunk)(nil),
 	}
-	file_proto_faas_v1_faas_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_proto_faas_v1_faas_proto_msgTypes[7].OneofWrappers = []interface{}{
 		(*ScheduleWorker_Rate)(nil),
 		(*ScheduleWorker_Cron)(nil),
 	}
-	file_proto_faas_v1_faas_proto_msgTypes[9].OneofWrappers = []interface{}{
+	file_proto_faas_v1_faas_proto_msgTypes[11].OneofWrappers = []interface{}{
 		(*InitRequest_Api)(nil),
 		(*InitRequest_Subscription)(nil),
 		(*InitRequest_Schedule)(nil),
+		(*InitRequest_Websocket)(nil),
 	}
-	file_proto_faas_v1_faas_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_proto_faas_v1_faas_proto_msgTypes[14].OneofWrappers = []interface{}{
 		(*TriggerRequest_Http)(nil),
 		(*TriggerRequest_Topic)(nil),
+		(*TriggerRequest_Websocket)(nil),
 	}
-	file_proto_faas_v1_faas_proto_msgTypes[17].OneofWrappers = []interface{}{
+	file_proto_faas_v1_faas_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*TriggerResponse_Http)(nil),
 		(*TriggerResponse_Topic)(nil),
+		(*TriggerResponse_Websocket)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_faas_v1_faas_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   29,
+			NumEnums:      1,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_faas_v1_faas_proto_goTypes,
 		DependencyIndexes: file_proto_faas_v1_faas_proto_depIdxs,
+		EnumInfos:         file_proto_faas_v1_faas_proto_enumTypes,
 		MessageInfos:      file_proto_faas_v1_faas_proto_msgTypes,
 	}.Build()
 	File_proto_faas_v1_faas_proto = out.File
@@ -123,6 +123,37 @@ func (m *ClientMessage) validate(all bool) error {
 			}
 		}
 
+	case *ClientMessage_BodyChunk:
+
+		if all {
+			switch v := interface{}(m.GetBodyChunk()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ClientMessageValidationError{
+						field:  "BodyChunk",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ClientMessageValidationError{
+						field:  "BodyChunk",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetBodyChunk()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ClientMessageValidationError{
+					field:  "BodyChunk",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -291,6 +322,37 @@ func (m *ServerMessage) validate(all bool) error {
 			}
 		}
 
+	case *ServerMessage_BodyChunk:
+
+		if all {
+			switch v := interface{}(m.GetBodyChunk()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ServerMessageValidationError{
+						field:  "BodyChunk",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ServerMessageValidationError{
+						field:  "BodyChunk",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetBodyChunk()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ServerMessageValidationError{
+					field:  "BodyChunk",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -371,6 +433,109 @@ var _ interface {
 	ErrorName() string
 } = ServerMessageValidationError{}
 
+// Validate checks the field values on BodyChunk with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BodyChunk) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BodyChunk with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BodyChunkMultiError, or nil
+// if none found.
+func (m *BodyChunk) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BodyChunk) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Data
+
+	// no validation rules for Last
+
+	if len(errors) > 0 {
+		return BodyChunkMultiError(errors)
+	}
+
+	return nil
+}
+
+// BodyChunkMultiError is an error wrapping multiple validation errors returned
+// by BodyChunk.ValidateAll() if the designated constraints aren't met.
+type BodyChunkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BodyChunkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BodyChunkMultiError) AllErrors() []error { return m }
+
+// BodyChunkValidationError is the validation error returned by
+// BodyChunk.Validate if the designated constraints aren't met.
+type BodyChunkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BodyChunkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BodyChunkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BodyChunkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BodyChunkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BodyChunkValidationError) ErrorName() string { return "BodyChunkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BodyChunkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBodyChunk.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BodyChunkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BodyChunkValidationError{}
+
 // Validate checks the field values on ApiWorkerScopes with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -541,6 +706,35 @@ func (m *ApiWorkerOptions) validate(all bool) error {
 
 	// no validation rules for SecurityDisabled
 
+	if all {
+		switch v := interface{}(m.GetRateLimit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ApiWorkerOptionsValidationError{
+					field:  "RateLimit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ApiWorkerOptionsValidationError{
+					field:  "RateLimit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRateLimit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApiWorkerOptionsValidationError{
+				field:  "RateLimit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ApiWorkerOptionsMultiError(errors)
 	}
@@ -1023,6 +1217,110 @@ var _ interface {
 	ErrorName() string
 } = ScheduleWorkerValidationError{}
 
+// Validate checks the field values on WebsocketWorker with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketWorker) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketWorker with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketWorkerMultiError, or nil if none found.
+func (m *WebsocketWorker) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketWorker) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Socket
+
+	// no validation rules for Event
+
+	if len(errors) > 0 {
+		return WebsocketWorkerMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketWorkerMultiError is an error wrapping multiple validation errors
+// returned by WebsocketWorker.ValidateAll() if the designated constraints
+// aren't met.
+type WebsocketWorkerMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketWorkerMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketWorkerMultiError) AllErrors() []error { return m }
+
+// WebsocketWorkerValidationError is the validation error returned by
+// WebsocketWorker.Validate if the designated constraints aren't met.
+type WebsocketWorkerValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketWorkerValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketWorkerValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketWorkerValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketWorkerValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketWorkerValidationError) ErrorName() string { return "WebsocketWorkerValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WebsocketWorkerValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketWorker.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketWorkerValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketWorkerValidationError{}
+
 // Validate checks the field values on ScheduleRate with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -1247,6 +1545,10 @@ func (m *InitRequest) validate(all bool) error {
 
 	var errors []error
 
+	// no validation rules for BodyStreaming
+
+	// no validation rules for MaxConcurrency
+
 	switch m.Worker.(type) {
 
 	case *InitRequest_Api:
@@ -1342,6 +1644,37 @@ func (m *InitRequest) validate(all bool) error {
 			}
 		}
 
+	case *InitRequest_Websocket:
+
+		if all {
+			switch v := interface{}(m.GetWebsocket()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, InitRequestValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, InitRequestValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetWebsocket()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return InitRequestValidationError{
+					field:  "Websocket",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -1676,6 +2009,8 @@ func (m *TriggerRequest) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for StreamedBody
+
 	switch m.Context.(type) {
 
 	case *TriggerRequest_Http:
@@ -1740,7 +2075,38 @@ func (m *TriggerRequest) validate(all bool) error {
 			}
 		}
 
-	}
+	case *TriggerRequest_Websocket:
+
+		if all {
+			switch v := interface{}(m.GetWebsocket()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TriggerRequestValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TriggerRequestValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetWebsocket()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TriggerRequestValidationError{
+					field:  "Websocket",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
 
 	if len(errors) > 0 {
 		return TriggerRequestMultiError(errors)
@@ -2142,6 +2508,35 @@ func (m *HttpTriggerContext) validate(all bool) error {
 
 	// no validation rules for PathParams
 
+	if all {
+		switch v := interface{}(m.GetClaims()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, HttpTriggerContextValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, HttpTriggerContextValidationError{
+					field:  "Claims",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetClaims()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return HttpTriggerContextValidationError{
+				field:  "Claims",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return HttpTriggerContextMultiError(errors)
 	}
@@ -2326,6 +2721,160 @@ var _ interface {
 	ErrorName() string
 } = TopicTriggerContextValidationError{}
 
+// Validate checks the field values on WebsocketTriggerContext with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketTriggerContext) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketTriggerContext with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketTriggerContextMultiError, or nil if none found.
+func (m *WebsocketTriggerContext) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketTriggerContext) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Socket
+
+	// no validation rules for Event
+
+	// no validation rules for ConnectionId
+
+	{
+		sorted_keys := make([]string, len(m.GetQueryParams()))
+		i := 0
+		for key := range m.GetQueryParams() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetQueryParams()[key]
+			_ = val
+
+			// no validation rules for QueryParams[key]
+
+			if all {
+				switch v := interface{}(val).(type) {
+				case interface{ ValidateAll() error }:
+					if err := v.ValidateAll(); err != nil {
+						errors = append(errors, WebsocketTriggerContextValidationError{
+							field:  fmt.Sprintf("QueryParams[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				case interface{ Validate() error }:
+					if err := v.Validate(); err != nil {
+						errors = append(errors, WebsocketTriggerContextValidationError{
+							field:  fmt.Sprintf("QueryParams[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				}
+			} else if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return WebsocketTriggerContextValidationError{
+						field:  fmt.Sprintf("QueryParams[%v]", key),
+						reason: "embedded message failed validation",
+						cause:  err,
+					}
+				}
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return WebsocketTriggerContextMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketTriggerContextMultiError is an error wrapping multiple validation
+// errors returned by WebsocketTriggerContext.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketTriggerContextMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketTriggerContextMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketTriggerContextMultiError) AllErrors() []error { return m }
+
+// WebsocketTriggerContextValidationError is the validation error returned by
+// WebsocketTriggerContext.Validate if the designated constraints aren't met.
+type WebsocketTriggerContextValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketTriggerContextValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketTriggerContextValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketTriggerContextValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketTriggerContextValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketTriggerContextValidationError) ErrorName() string {
+	return "WebsocketTriggerContextValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketTriggerContextValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketTriggerContext.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketTriggerContextValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketTriggerContextValidationError{}
+
 // Validate checks the field values on TriggerResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -2350,6 +2899,8 @@ func (m *TriggerResponse) validate(all bool) error {
 
 	// no validation rules for Data
 
+	// no validation rules for StreamedBody
+
 	switch m.Context.(type) {
 
 	case *TriggerResponse_Http:
@@ -2414,6 +2965,37 @@ func (m *TriggerResponse) validate(all bool) error {
 			}
 		}
 
+	case *TriggerResponse_Websocket:
+
+		if all {
+			switch v := interface{}(m.GetWebsocket()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TriggerResponseValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TriggerResponseValidationError{
+						field:  "Websocket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetWebsocket()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TriggerResponseValidationError{
+					field:  "Websocket",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
@@ -2749,3 +3331,107 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = TopicResponseContextValidationError{}
+
+// Validate checks the field values on WebsocketResponseContext with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketResponseContext) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketResponseContext with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketResponseContextMultiError, or nil if none found.
+func (m *WebsocketResponseContext) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketResponseContext) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Success
+
+	if len(errors) > 0 {
+		return WebsocketResponseContextMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketResponseContextMultiError is an error wrapping multiple validation
+// errors returned by WebsocketResponseContext.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketResponseContextMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketResponseContextMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketResponseContextMultiError) AllErrors() []error { return m }
+
+// WebsocketResponseContextValidationError is the validation error returned by
+// WebsocketResponseContext.Validate if the designated constraints aren't met.
+type WebsocketResponseContextValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketResponseContextValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketResponseContextValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketResponseContextValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketResponseContextValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketResponseContextValidationError) ErrorName() string {
+	return "WebsocketResponseContextValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketResponseContextValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketResponseContext.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketResponseContextValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketResponseContextValidationError{}
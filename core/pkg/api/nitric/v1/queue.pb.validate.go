@@ -1298,6 +1298,35 @@ func (m *NitricTask) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetTraceContext()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, NitricTaskValidationError{
+					field:  "TraceContext",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, NitricTaskValidationError{
+					field:  "TraceContext",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTraceContext()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return NitricTaskValidationError{
+				field:  "TraceContext",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return NitricTaskMultiError(errors)
 	}
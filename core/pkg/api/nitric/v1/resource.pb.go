@@ -90,6 +90,63 @@ func (ResourceType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{0}
 }
 
+// The clients requests are counted against when rate limiting
+type ApiRateLimitKey int32
+
+const (
+	// A single limit shared by all clients
+	ApiRateLimitKey_RateLimitGlobal ApiRateLimitKey = 0
+	// A limit per client IP address
+	ApiRateLimitKey_RateLimitIp ApiRateLimitKey = 1
+	// A limit per subject of the request's validated bearer token, falling back to the client IP
+	ApiRateLimitKey_RateLimitSubject ApiRateLimitKey = 2
+	// A limit per value of a request header, falling back to the client IP
+	ApiRateLimitKey_RateLimitHeader ApiRateLimitKey = 3
+)
+
+// Enum value maps for ApiRateLimitKey.
+var (
+	ApiRateLimitKey_name = map[int32]string{
+		0: "RateLimitGlobal",
+		1: "RateLimitIp",
+		2: "RateLimitSubject",
+		3: "RateLimitHeader",
+	}
+	ApiRateLimitKey_value = map[string]int32{
+		"RateLimitGlobal":  0,
+		"RateLimitIp":      1,
+		"RateLimitSubject": 2,
+		"RateLimitHeader":  3,
+	}
+)
+
+func (x ApiRateLimitKey) Enum() *ApiRateLimitKey {
+	p := new(ApiRateLimitKey)
+	*p = x
+	return p
+}
+
+func (x ApiRateLimitKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ApiRateLimitKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_resource_v1_resource_proto_enumTypes[1].Descriptor()
+}
+
+func (ApiRateLimitKey) Type() protoreflect.EnumType {
+	return &file_proto_resource_v1_resource_proto_enumTypes[1]
+}
+
+func (x ApiRateLimitKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ApiRateLimitKey.Descriptor instead.
+func (ApiRateLimitKey) EnumDescriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{1}
+}
+
 type Action int32
 
 const (
@@ -173,11 +230,11 @@ func (x Action) String() string {
 }
 
 func (Action) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_resource_v1_resource_proto_enumTypes[1].Descriptor()
+	return file_proto_resource_v1_resource_proto_enumTypes[2].Descriptor()
 }
 
 func (Action) Type() protoreflect.EnumType {
-	return &file_proto_resource_v1_resource_proto_enumTypes[1]
+	return &file_proto_resource_v1_resource_proto_enumTypes[2]
 }
 
 func (x Action) Number() protoreflect.EnumNumber {
@@ -186,7 +243,7 @@ func (x Action) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Action.Descriptor instead.
 func (Action) EnumDescriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{1}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{2}
 }
 
 type PolicyResource struct {
@@ -826,6 +883,176 @@ func (x *ApiScopes) GetScopes() []string {
 	return nil
 }
 
+// Cross-origin resource sharing configuration for an api
+type ApiCorsDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Origins allowed to make requests, '*' allows any origin
+	AllowOrigins []string `protobuf:"bytes,1,rep,name=allow_origins,json=allowOrigins,proto3" json:"allow_origins,omitempty"`
+	// Methods allowed in cross-origin requests, defaults to the methods of the matched route
+	AllowMethods []string `protobuf:"bytes,2,rep,name=allow_methods,json=allowMethods,proto3" json:"allow_methods,omitempty"`
+	// Request headers allowed in cross-origin requests, '*' allows any header
+	AllowHeaders []string `protobuf:"bytes,3,rep,name=allow_headers,json=allowHeaders,proto3" json:"allow_headers,omitempty"`
+	// Response headers exposed to the browser
+	ExposeHeaders []string `protobuf:"bytes,4,rep,name=expose_headers,json=exposeHeaders,proto3" json:"expose_headers,omitempty"`
+	// Allow requests that include credentials such as cookies or authorization headers
+	AllowCredentials bool `protobuf:"varint,5,opt,name=allow_credentials,json=allowCredentials,proto3" json:"allow_credentials,omitempty"`
+	// How long in seconds the result of a preflight request may be cached
+	MaxAge int32 `protobuf:"varint,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+}
+
+func (x *ApiCorsDefinition) Reset() {
+	*x = ApiCorsDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApiCorsDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiCorsDefinition) ProtoMessage() {}
+
+func (x *ApiCorsDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiCorsDefinition.ProtoReflect.Descriptor instead.
+func (*ApiCorsDefinition) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ApiCorsDefinition) GetAllowOrigins() []string {
+	if x != nil {
+		return x.AllowOrigins
+	}
+	return nil
+}
+
+func (x *ApiCorsDefinition) GetAllowMethods() []string {
+	if x != nil {
+		return x.AllowMethods
+	}
+	return nil
+}
+
+func (x *ApiCorsDefinition) GetAllowHeaders() []string {
+	if x != nil {
+		return x.AllowHeaders
+	}
+	return nil
+}
+
+func (x *ApiCorsDefinition) GetExposeHeaders() []string {
+	if x != nil {
+		return x.ExposeHeaders
+	}
+	return nil
+}
+
+func (x *ApiCorsDefinition) GetAllowCredentials() bool {
+	if x != nil {
+		return x.AllowCredentials
+	}
+	return false
+}
+
+func (x *ApiCorsDefinition) GetMaxAge() int32 {
+	if x != nil {
+		return x.MaxAge
+	}
+	return 0
+}
+
+// Token bucket rate limit for an api or route
+type ApiRateLimit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sustained number of requests allowed per second
+	RequestsPerSecond float64 `protobuf:"fixed64,1,opt,name=requests_per_second,json=requestsPerSecond,proto3" json:"requests_per_second,omitempty"`
+	// Maximum number of requests allowed in a burst, defaults to requests_per_second
+	Burst int32 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	// The clients requests are counted against
+	Key ApiRateLimitKey `protobuf:"varint,3,opt,name=key,proto3,enum=nitric.resource.v1.ApiRateLimitKey" json:"key,omitempty"`
+	// The request header identifying the client when key is RateLimitHeader
+	Header string `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
+}
+
+func (x *ApiRateLimit) Reset() {
+	*x = ApiRateLimit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApiRateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiRateLimit) ProtoMessage() {}
+
+func (x *ApiRateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiRateLimit.ProtoReflect.Descriptor instead.
+func (*ApiRateLimit) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ApiRateLimit) GetRequestsPerSecond() float64 {
+	if x != nil {
+		return x.RequestsPerSecond
+	}
+	return 0
+}
+
+func (x *ApiRateLimit) GetBurst() int32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *ApiRateLimit) GetKey() ApiRateLimitKey {
+	if x != nil {
+		return x.Key
+	}
+	return ApiRateLimitKey_RateLimitGlobal
+}
+
+func (x *ApiRateLimit) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
 type ApiResource struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -836,12 +1063,16 @@ type ApiResource struct {
 	SecurityDefinitions map[string]*ApiSecurityDefinition `protobuf:"bytes,1,rep,name=security_definitions,json=securityDefinitions,proto3" json:"security_definitions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// root level security for this api
 	Security map[string]*ApiScopes `protobuf:"bytes,2,rep,name=security,proto3" json:"security,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// CORS configuration for this api, cross-origin requests are not handled when unset
+	Cors *ApiCorsDefinition `protobuf:"bytes,3,opt,name=cors,proto3" json:"cors,omitempty"`
+	// Rate limit applied to all requests to this api, no limit is applied when unset
+	RateLimit *ApiRateLimit `protobuf:"bytes,4,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
 }
 
 func (x *ApiResource) Reset() {
 	*x = ApiResource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_resource_v1_resource_proto_msgTypes[11]
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -854,7 +1085,7 @@ func (x *ApiResource) String() string {
 func (*ApiResource) ProtoMessage() {}
 
 func (x *ApiResource) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_resource_v1_resource_proto_msgTypes[11]
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -867,7 +1098,7 @@ func (x *ApiResource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiResource.ProtoReflect.Descriptor instead.
 func (*ApiResource) Descriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{11}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ApiResource) GetSecurityDefinitions() map[string]*ApiSecurityDefinition {
@@ -884,6 +1115,20 @@ func (x *ApiResource) GetSecurity() map[string]*ApiScopes {
 	return nil
 }
 
+func (x *ApiResource) GetCors() *ApiCorsDefinition {
+	if x != nil {
+		return x.Cors
+	}
+	return nil
+}
+
+func (x *ApiResource) GetRateLimit() *ApiRateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
 type ResourceDeclareResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -893,7 +1138,7 @@ type ResourceDeclareResponse struct {
 func (x *ResourceDeclareResponse) Reset() {
 	*x = ResourceDeclareResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_resource_v1_resource_proto_msgTypes[12]
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -906,7 +1151,7 @@ func (x *ResourceDeclareResponse) String() string {
 func (*ResourceDeclareResponse) ProtoMessage() {}
 
 func (x *ResourceDeclareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_resource_v1_resource_proto_msgTypes[12]
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,7 +1164,7 @@ func (x *ResourceDeclareResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceDeclareResponse.ProtoReflect.Descriptor instead.
 func (*ResourceDeclareResponse) Descriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{12}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{14}
 }
 
 type ApiResourceDetails struct {
@@ -933,7 +1178,7 @@ type ApiResourceDetails struct {
 func (x *ApiResourceDetails) Reset() {
 	*x = ApiResourceDetails{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_resource_v1_resource_proto_msgTypes[13]
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -946,7 +1191,7 @@ func (x *ApiResourceDetails) String() string {
 func (*ApiResourceDetails) ProtoMessage() {}
 
 func (x *ApiResourceDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_resource_v1_resource_proto_msgTypes[13]
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1204,7 @@ func (x *ApiResourceDetails) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ApiResourceDetails.ProtoReflect.Descriptor instead.
 func (*ApiResourceDetails) Descriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{13}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ApiResourceDetails) GetUrl() string {
@@ -969,6 +1214,264 @@ func (x *ApiResourceDetails) GetUrl() string {
 	return ""
 }
 
+type BucketResourceDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider name of the bucket
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The url of the bucket
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *BucketResourceDetails) Reset() {
+	*x = BucketResourceDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BucketResourceDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BucketResourceDetails) ProtoMessage() {}
+
+func (x *BucketResourceDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BucketResourceDetails.ProtoReflect.Descriptor instead.
+func (*BucketResourceDetails) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BucketResourceDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BucketResourceDetails) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TopicResourceDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider name of the topic
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *TopicResourceDetails) Reset() {
+	*x = TopicResourceDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopicResourceDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicResourceDetails) ProtoMessage() {}
+
+func (x *TopicResourceDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicResourceDetails.ProtoReflect.Descriptor instead.
+func (*TopicResourceDetails) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *TopicResourceDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type QueueResourceDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider name of the queue
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// The url messages are sent to
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *QueueResourceDetails) Reset() {
+	*x = QueueResourceDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QueueResourceDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueueResourceDetails) ProtoMessage() {}
+
+func (x *QueueResourceDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueueResourceDetails.ProtoReflect.Descriptor instead.
+func (*QueueResourceDetails) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *QueueResourceDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueueResourceDetails) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type CollectionResourceDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider name of the collection
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *CollectionResourceDetails) Reset() {
+	*x = CollectionResourceDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectionResourceDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionResourceDetails) ProtoMessage() {}
+
+func (x *CollectionResourceDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionResourceDetails.ProtoReflect.Descriptor instead.
+func (*CollectionResourceDetails) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CollectionResourceDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SecretResourceDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The provider name of the secret
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *SecretResourceDetails) Reset() {
+	*x = SecretResourceDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretResourceDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretResourceDetails) ProtoMessage() {}
+
+func (x *SecretResourceDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretResourceDetails.ProtoReflect.Descriptor instead.
+func (*SecretResourceDetails) Descriptor() ([]byte, []int) {
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SecretResourceDetails) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
 type ResourceDetailsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -980,7 +1483,7 @@ type ResourceDetailsRequest struct {
 func (x *ResourceDetailsRequest) Reset() {
 	*x = ResourceDetailsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_resource_v1_resource_proto_msgTypes[14]
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -993,7 +1496,7 @@ func (x *ResourceDetailsRequest) String() string {
 func (*ResourceDetailsRequest) ProtoMessage() {}
 
 func (x *ResourceDetailsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_resource_v1_resource_proto_msgTypes[14]
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1006,7 +1509,7 @@ func (x *ResourceDetailsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceDetailsRequest.ProtoReflect.Descriptor instead.
 func (*ResourceDetailsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{14}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ResourceDetailsRequest) GetResource() *Resource {
@@ -1032,13 +1535,18 @@ type ResourceDetailsResponse struct {
 	// Types that are assignable to Details:
 	//
 	//	*ResourceDetailsResponse_Api
+	//	*ResourceDetailsResponse_Bucket
+	//	*ResourceDetailsResponse_Topic
+	//	*ResourceDetailsResponse_Queue
+	//	*ResourceDetailsResponse_Collection
+	//	*ResourceDetailsResponse_Secret
 	Details isResourceDetailsResponse_Details `protobuf_oneof:"details"`
 }
 
 func (x *ResourceDetailsResponse) Reset() {
 	*x = ResourceDetailsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_resource_v1_resource_proto_msgTypes[15]
+		mi := &file_proto_resource_v1_resource_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1051,7 +1559,7 @@ func (x *ResourceDetailsResponse) String() string {
 func (*ResourceDetailsResponse) ProtoMessage() {}
 
 func (x *ResourceDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_resource_v1_resource_proto_msgTypes[15]
+	mi := &file_proto_resource_v1_resource_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1064,7 +1572,7 @@ func (x *ResourceDetailsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResourceDetailsResponse.ProtoReflect.Descriptor instead.
 func (*ResourceDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{15}
+	return file_proto_resource_v1_resource_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *ResourceDetailsResponse) GetId() string {
@@ -1102,6 +1610,41 @@ func (x *ResourceDetailsResponse) GetApi() *ApiResourceDetails {
 	return nil
 }
 
+func (x *ResourceDetailsResponse) GetBucket() *BucketResourceDetails {
+	if x, ok := x.GetDetails().(*ResourceDetailsResponse_Bucket); ok {
+		return x.Bucket
+	}
+	return nil
+}
+
+func (x *ResourceDetailsResponse) GetTopic() *TopicResourceDetails {
+	if x, ok := x.GetDetails().(*ResourceDetailsResponse_Topic); ok {
+		return x.Topic
+	}
+	return nil
+}
+
+func (x *ResourceDetailsResponse) GetQueue() *QueueResourceDetails {
+	if x, ok := x.GetDetails().(*ResourceDetailsResponse_Queue); ok {
+		return x.Queue
+	}
+	return nil
+}
+
+func (x *ResourceDetailsResponse) GetCollection() *CollectionResourceDetails {
+	if x, ok := x.GetDetails().(*ResourceDetailsResponse_Collection); ok {
+		return x.Collection
+	}
+	return nil
+}
+
+func (x *ResourceDetailsResponse) GetSecret() *SecretResourceDetails {
+	if x, ok := x.GetDetails().(*ResourceDetailsResponse_Secret); ok {
+		return x.Secret
+	}
+	return nil
+}
+
 type isResourceDetailsResponse_Details interface {
 	isResourceDetailsResponse_Details()
 }
@@ -1110,8 +1653,38 @@ type ResourceDetailsResponse_Api struct {
 	Api *ApiResourceDetails `protobuf:"bytes,10,opt,name=api,proto3,oneof"`
 }
 
+type ResourceDetailsResponse_Bucket struct {
+	Bucket *BucketResourceDetails `protobuf:"bytes,11,opt,name=bucket,proto3,oneof"`
+}
+
+type ResourceDetailsResponse_Topic struct {
+	Topic *TopicResourceDetails `protobuf:"bytes,12,opt,name=topic,proto3,oneof"`
+}
+
+type ResourceDetailsResponse_Queue struct {
+	Queue *QueueResourceDetails `protobuf:"bytes,13,opt,name=queue,proto3,oneof"`
+}
+
+type ResourceDetailsResponse_Collection struct {
+	Collection *CollectionResourceDetails `protobuf:"bytes,14,opt,name=collection,proto3,oneof"`
+}
+
+type ResourceDetailsResponse_Secret struct {
+	Secret *SecretResourceDetails `protobuf:"bytes,15,opt,name=secret,proto3,oneof"`
+}
+
 func (*ResourceDetailsResponse_Api) isResourceDetailsResponse_Details() {}
 
+func (*ResourceDetailsResponse_Bucket) isResourceDetailsResponse_Details() {}
+
+func (*ResourceDetailsResponse_Topic) isResourceDetailsResponse_Details() {}
+
+func (*ResourceDetailsResponse_Queue) isResourceDetailsResponse_Details() {}
+
+func (*ResourceDetailsResponse_Collection) isResourceDetailsResponse_Details() {}
+
+func (*ResourceDetailsResponse_Secret) isResourceDetailsResponse_Details() {}
+
 var File_proto_resource_v1_resource_proto protoreflect.FileDescriptor
 
 var file_proto_resource_v1_resource_proto_rawDesc = []byte{
@@ -1188,109 +1761,187 @@ var file_proto_resource_v1_resource_proto_rawDesc = []byte{
 	0x00, 0x52, 0x03, 0x6a, 0x77, 0x74, 0x42, 0x0c, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69,
 	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x23, 0x0a, 0x09, 0x41, 0x70, 0x69, 0x53, 0x63, 0x6f, 0x70, 0x65,
 	0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0x94, 0x03, 0x0a, 0x0b, 0x41, 0x70,
-	0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x6b, 0x0a, 0x14, 0x73, 0x65, 0x63,
-	0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
+	0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x22, 0xef, 0x01, 0x0a, 0x11, 0x41, 0x70,
+	0x69, 0x43, 0x6f, 0x72, 0x73, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4f, 0x72, 0x69,
+	0x67, 0x69, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x65, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x63,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x0c,
+	0x41, 0x70, 0x69, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2e, 0x0a, 0x13,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x62, 0x75, 0x72, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x62, 0x75, 0x72,
+	0x73, 0x74, 0x12, 0x35, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x23, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x22, 0x90, 0x04, 0x0a, 0x0b, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x6b, 0x0a, 0x14, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x64, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x38, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x73, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49,
+	0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x39, 0x0a, 0x04, 0x63, 0x6f, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
 	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69,
-	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
-	0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x52, 0x13, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
-	0x74, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
+	0x43, 0x6f, 0x72, 0x73, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x63, 0x6f, 0x72, 0x73, 0x12, 0x3f, 0x0a, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69,
 	0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70,
-	0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69,
-	0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
-	0x79, 0x1a, 0x71, 0x0a, 0x18, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44, 0x65, 0x66,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x3f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
-	0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x44,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5a, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x53,
-	0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x26, 0x0a, 0x12, 0x41,
-	0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x75, 0x72, 0x6c, 0x22, 0x52, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44,
-	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a,
-	0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0xa6, 0x01, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x03, 0x61, 0x70, 0x69,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
-	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52,
+	0x69, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x09, 0x72, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x1a, 0x71, 0x0a, 0x18, 0x53, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74,
+	0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x53, 0x65, 0x63, 0x75, 0x72,
+	0x69, 0x74, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5a, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x75,
+	0x72, 0x69, 0x74, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x41, 0x70, 0x69, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x19, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x26, 0x0a, 0x12, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x3d, 0x0a, 0x15, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x2a, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x3c, 0x0a, 0x14, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x22, 0x2f, 0x0a, 0x19, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x2b, 0x0a, 0x15, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x52,
+	0x0a, 0x16, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x22, 0x85, 0x04, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x03, 0x61, 0x70, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x26, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x70, 0x69, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x03, 0x61, 0x70, 0x69,
+	0x12, 0x43, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x06, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x40, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x52,
 	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00,
-	0x52, 0x03, 0x61, 0x70, 0x69, 0x42, 0x09, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
-	0x2a, 0x8f, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x70, 0x69, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x75,
-	0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b,
-	0x65, 0x74, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65, 0x10, 0x03, 0x12,
-	0x09, 0x0a, 0x05, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63,
-	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x6f,
-	0x6c, 0x69, 0x63, 0x79, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x10, 0x09, 0x2a, 0xff, 0x02, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
-	0x0e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x10,
-	0x00, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x47,
-	0x65, 0x74, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69,
-	0x6c, 0x65, 0x50, 0x75, 0x74, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x03, 0x12, 0x0e, 0x0a,
-	0x09, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x10, 0xc8, 0x01, 0x12, 0x10, 0x0a,
-	0x0b, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x10, 0xc9, 0x01, 0x12,
-	0x16, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x73, 0x68, 0x10, 0xca, 0x01, 0x12, 0x0e, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x75, 0x65,
-	0x53, 0x65, 0x6e, 0x64, 0x10, 0xac, 0x02, 0x12, 0x11, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x10, 0xad, 0x02, 0x12, 0x0e, 0x0a, 0x09, 0x51, 0x75,
-	0x65, 0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x10, 0xae, 0x02, 0x12, 0x10, 0x0a, 0x0b, 0x51, 0x75,
-	0x65, 0x75, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x10, 0xaf, 0x02, 0x12, 0x1b, 0x0a, 0x16,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x61, 0x64, 0x10, 0x90, 0x03, 0x12, 0x1c, 0x0a, 0x17, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x10, 0x91, 0x03, 0x12, 0x1d, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x10, 0x92, 0x03, 0x12, 0x14, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x10, 0x93, 0x03, 0x12, 0x13, 0x0a, 0x0e,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x10, 0x94,
-	0x03, 0x12, 0x0e, 0x0a, 0x09, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75, 0x74, 0x10, 0xf4,
-	0x03, 0x12, 0x11, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73,
-	0x73, 0x10, 0xf5, 0x03, 0x32, 0xd9, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x6c,
-	0x61, 0x72, 0x65, 0x12, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x63,
-	0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x07,
-	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x42, 0x95, 0x01, 0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x42, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x50, 0x01, 0x5a, 0x33, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63,
-	0x74, 0x65, 0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65,
-	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f,
-	0x76, 0x31, 0xaa, 0x02, 0x18, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x18,
-	0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x40, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73,
+	0x48, 0x00, 0x52, 0x05, 0x71, 0x75, 0x65, 0x75, 0x65, 0x12, 0x4f, 0x0a, 0x0a, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x0a,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6e, 0x69, 0x74,
+	0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x48, 0x00, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x42,
+	0x09, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x2a, 0x8f, 0x01, 0x0a, 0x0c, 0x52,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x41,
+	0x70, 0x69, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x10, 0x02, 0x12, 0x09,
+	0x0a, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x6f, 0x70,
+	0x69, 0x63, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x10, 0x06, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x10, 0x07, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x10, 0x08,
+	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x10, 0x09, 0x2a, 0x62, 0x0a, 0x0f,
+	0x41, 0x70, 0x69, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4b, 0x65, 0x79, 0x12,
+	0x13, 0x0a, 0x0f, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x47, 0x6c, 0x6f, 0x62,
+	0x61, 0x6c, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x49, 0x70, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x10, 0x03,
+	0x2a, 0xff, 0x02, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x0e, 0x42,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x10, 0x00, 0x12,
+	0x11, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x47, 0x65, 0x74,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65,
+	0x50, 0x75, 0x74, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x46,
+	0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x09, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x4c, 0x69, 0x73, 0x74, 0x10, 0xc8, 0x01, 0x12, 0x10, 0x0a, 0x0b, 0x54,
+	0x6f, 0x70, 0x69, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x10, 0xc9, 0x01, 0x12, 0x16, 0x0a,
+	0x11, 0x54, 0x6f, 0x70, 0x69, 0x63, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x10, 0xca, 0x01, 0x12, 0x0e, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x75, 0x65, 0x53, 0x65,
+	0x6e, 0x64, 0x10, 0xac, 0x02, 0x12, 0x11, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x10, 0xad, 0x02, 0x12, 0x0e, 0x0a, 0x09, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x10, 0xae, 0x02, 0x12, 0x10, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x75,
+	0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x10, 0xaf, 0x02, 0x12, 0x1b, 0x0a, 0x16, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x61, 0x64, 0x10, 0x90, 0x03, 0x12, 0x1c, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x10, 0x91, 0x03, 0x12, 0x1d, 0x0a, 0x18, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x10, 0x92, 0x03, 0x12, 0x14, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x10, 0x93, 0x03, 0x12, 0x13, 0x0a, 0x0e, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x10, 0x94, 0x03, 0x12,
+	0x0e, 0x0a, 0x09, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x50, 0x75, 0x74, 0x10, 0xf4, 0x03, 0x12,
+	0x11, 0x0a, 0x0c, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x10,
+	0xf5, 0x03, 0x32, 0xd9, 0x01, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x62, 0x0a, 0x07, 0x44, 0x65, 0x63, 0x6c, 0x61, 0x72,
+	0x65, 0x12, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44,
+	0x65, 0x63, 0x6c, 0x61, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e,
+	0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x65, 0x63, 0x6c, 0x61,
+	0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x62, 0x0a, 0x07, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2a, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2b, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x95,
+	0x01, 0x0a, 0x1b, 0x69, 0x6f, 0x2e, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x42, 0x09,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x50, 0x01, 0x5a, 0x33, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x74, 0x65,
+	0x63, 0x68, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2f, 0x76, 0x31,
+	0xaa, 0x02, 0x18, 0x4e, 0x69, 0x74, 0x72, 0x69, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x76, 0x31, 0xca, 0x02, 0x18, 0x4e, 0x69,
+	0x74, 0x72, 0x69, 0x63, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x5c, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x5c, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1305,59 +1956,75 @@ func file_proto_resource_v1_resource_proto_rawDescGZIP() []byte {
 	return file_proto_resource_v1_resource_proto_rawDescData
 }
 
-var file_proto_resource_v1_resource_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_resource_v1_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_resource_v1_resource_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_resource_v1_resource_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_resource_v1_resource_proto_goTypes = []interface{}{
-	(ResourceType)(0),                // 0: nitric.resource.v1.ResourceType
-	(Action)(0),                      // 1: nitric.resource.v1.Action
-	(*PolicyResource)(nil),           // 2: nitric.resource.v1.PolicyResource
-	(*Resource)(nil),                 // 3: nitric.resource.v1.Resource
-	(*ResourceDeclareRequest)(nil),   // 4: nitric.resource.v1.ResourceDeclareRequest
-	(*BucketResource)(nil),           // 5: nitric.resource.v1.BucketResource
-	(*QueueResource)(nil),            // 6: nitric.resource.v1.QueueResource
-	(*TopicResource)(nil),            // 7: nitric.resource.v1.TopicResource
-	(*CollectionResource)(nil),       // 8: nitric.resource.v1.CollectionResource
-	(*SecretResource)(nil),           // 9: nitric.resource.v1.SecretResource
-	(*ApiSecurityDefinitionJwt)(nil), // 10: nitric.resource.v1.ApiSecurityDefinitionJwt
-	(*ApiSecurityDefinition)(nil),    // 11: nitric.resource.v1.ApiSecurityDefinition
-	(*ApiScopes)(nil),                // 12: nitric.resource.v1.ApiScopes
-	(*ApiResource)(nil),              // 13: nitric.resource.v1.ApiResource
-	(*ResourceDeclareResponse)(nil),  // 14: nitric.resource.v1.ResourceDeclareResponse
-	(*ApiResourceDetails)(nil),       // 15: nitric.resource.v1.ApiResourceDetails
-	(*ResourceDetailsRequest)(nil),   // 16: nitric.resource.v1.ResourceDetailsRequest
-	(*ResourceDetailsResponse)(nil),  // 17: nitric.resource.v1.ResourceDetailsResponse
-	nil,                              // 18: nitric.resource.v1.ApiResource.SecurityDefinitionsEntry
-	nil,                              // 19: nitric.resource.v1.ApiResource.SecurityEntry
+	(ResourceType)(0),                 // 0: nitric.resource.v1.ResourceType
+	(ApiRateLimitKey)(0),              // 1: nitric.resource.v1.ApiRateLimitKey
+	(Action)(0),                       // 2: nitric.resource.v1.Action
+	(*PolicyResource)(nil),            // 3: nitric.resource.v1.PolicyResource
+	(*Resource)(nil),                  // 4: nitric.resource.v1.Resource
+	(*ResourceDeclareRequest)(nil),    // 5: nitric.resource.v1.ResourceDeclareRequest
+	(*BucketResource)(nil),            // 6: nitric.resource.v1.BucketResource
+	(*QueueResource)(nil),             // 7: nitric.resource.v1.QueueResource
+	(*TopicResource)(nil),             // 8: nitric.resource.v1.TopicResource
+	(*CollectionResource)(nil),        // 9: nitric.resource.v1.CollectionResource
+	(*SecretResource)(nil),            // 10: nitric.resource.v1.SecretResource
+	(*ApiSecurityDefinitionJwt)(nil),  // 11: nitric.resource.v1.ApiSecurityDefinitionJwt
+	(*ApiSecurityDefinition)(nil),     // 12: nitric.resource.v1.ApiSecurityDefinition
+	(*ApiScopes)(nil),                 // 13: nitric.resource.v1.ApiScopes
+	(*ApiCorsDefinition)(nil),         // 14: nitric.resource.v1.ApiCorsDefinition
+	(*ApiRateLimit)(nil),              // 15: nitric.resource.v1.ApiRateLimit
+	(*ApiResource)(nil),               // 16: nitric.resource.v1.ApiResource
+	(*ResourceDeclareResponse)(nil),   // 17: nitric.resource.v1.ResourceDeclareResponse
+	(*ApiResourceDetails)(nil),        // 18: nitric.resource.v1.ApiResourceDetails
+	(*BucketResourceDetails)(nil),     // 19: nitric.resource.v1.BucketResourceDetails
+	(*TopicResourceDetails)(nil),      // 20: nitric.resource.v1.TopicResourceDetails
+	(*QueueResourceDetails)(nil),      // 21: nitric.resource.v1.QueueResourceDetails
+	(*CollectionResourceDetails)(nil), // 22: nitric.resource.v1.CollectionResourceDetails
+	(*SecretResourceDetails)(nil),     // 23: nitric.resource.v1.SecretResourceDetails
+	(*ResourceDetailsRequest)(nil),    // 24: nitric.resource.v1.ResourceDetailsRequest
+	(*ResourceDetailsResponse)(nil),   // 25: nitric.resource.v1.ResourceDetailsResponse
+	nil,                               // 26: nitric.resource.v1.ApiResource.SecurityDefinitionsEntry
+	nil,                               // 27: nitric.resource.v1.ApiResource.SecurityEntry
 }
 var file_proto_resource_v1_resource_proto_depIdxs = []int32{
-	3,  // 0: nitric.resource.v1.PolicyResource.principals:type_name -> nitric.resource.v1.Resource
-	1,  // 1: nitric.resource.v1.PolicyResource.actions:type_name -> nitric.resource.v1.Action
-	3,  // 2: nitric.resource.v1.PolicyResource.resources:type_name -> nitric.resource.v1.Resource
+	4,  // 0: nitric.resource.v1.PolicyResource.principals:type_name -> nitric.resource.v1.Resource
+	2,  // 1: nitric.resource.v1.PolicyResource.actions:type_name -> nitric.resource.v1.Action
+	4,  // 2: nitric.resource.v1.PolicyResource.resources:type_name -> nitric.resource.v1.Resource
 	0,  // 3: nitric.resource.v1.Resource.type:type_name -> nitric.resource.v1.ResourceType
-	3,  // 4: nitric.resource.v1.ResourceDeclareRequest.resource:type_name -> nitric.resource.v1.Resource
-	2,  // 5: nitric.resource.v1.ResourceDeclareRequest.policy:type_name -> nitric.resource.v1.PolicyResource
-	5,  // 6: nitric.resource.v1.ResourceDeclareRequest.bucket:type_name -> nitric.resource.v1.BucketResource
-	6,  // 7: nitric.resource.v1.ResourceDeclareRequest.queue:type_name -> nitric.resource.v1.QueueResource
-	7,  // 8: nitric.resource.v1.ResourceDeclareRequest.topic:type_name -> nitric.resource.v1.TopicResource
-	8,  // 9: nitric.resource.v1.ResourceDeclareRequest.collection:type_name -> nitric.resource.v1.CollectionResource
-	9,  // 10: nitric.resource.v1.ResourceDeclareRequest.secret:type_name -> nitric.resource.v1.SecretResource
-	13, // 11: nitric.resource.v1.ResourceDeclareRequest.api:type_name -> nitric.resource.v1.ApiResource
-	10, // 12: nitric.resource.v1.ApiSecurityDefinition.jwt:type_name -> nitric.resource.v1.ApiSecurityDefinitionJwt
-	18, // 13: nitric.resource.v1.ApiResource.security_definitions:type_name -> nitric.resource.v1.ApiResource.SecurityDefinitionsEntry
-	19, // 14: nitric.resource.v1.ApiResource.security:type_name -> nitric.resource.v1.ApiResource.SecurityEntry
-	3,  // 15: nitric.resource.v1.ResourceDetailsRequest.resource:type_name -> nitric.resource.v1.Resource
-	15, // 16: nitric.resource.v1.ResourceDetailsResponse.api:type_name -> nitric.resource.v1.ApiResourceDetails
-	11, // 17: nitric.resource.v1.ApiResource.SecurityDefinitionsEntry.value:type_name -> nitric.resource.v1.ApiSecurityDefinition
-	12, // 18: nitric.resource.v1.ApiResource.SecurityEntry.value:type_name -> nitric.resource.v1.ApiScopes
-	4,  // 19: nitric.resource.v1.ResourceService.Declare:input_type -> nitric.resource.v1.ResourceDeclareRequest
-	16, // 20: nitric.resource.v1.ResourceService.Details:input_type -> nitric.resource.v1.ResourceDetailsRequest
-	14, // 21: nitric.resource.v1.ResourceService.Declare:output_type -> nitric.resource.v1.ResourceDeclareResponse
-	17, // 22: nitric.resource.v1.ResourceService.Details:output_type -> nitric.resource.v1.ResourceDetailsResponse
-	21, // [21:23] is the sub-list for method output_type
-	19, // [19:21] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	4,  // 4: nitric.resource.v1.ResourceDeclareRequest.resource:type_name -> nitric.resource.v1.Resource
+	3,  // 5: nitric.resource.v1.ResourceDeclareRequest.policy:type_name -> nitric.resource.v1.PolicyResource
+	6,  // 6: nitric.resource.v1.ResourceDeclareRequest.bucket:type_name -> nitric.resource.v1.BucketResource
+	7,  // 7: nitric.resource.v1.ResourceDeclareRequest.queue:type_name -> nitric.resource.v1.QueueResource
+	8,  // 8: nitric.resource.v1.ResourceDeclareRequest.topic:type_name -> nitric.resource.v1.TopicResource
+	9,  // 9: nitric.resource.v1.ResourceDeclareRequest.collection:type_name -> nitric.resource.v1.CollectionResource
+	10, // 10: nitric.resource.v1.ResourceDeclareRequest.secret:type_name -> nitric.resource.v1.SecretResource
+	16, // 11: nitric.resource.v1.ResourceDeclareRequest.api:type_name -> nitric.resource.v1.ApiResource
+	11, // 12: nitric.resource.v1.ApiSecurityDefinition.jwt:type_name -> nitric.resource.v1.ApiSecurityDefinitionJwt
+	1,  // 13: nitric.resource.v1.ApiRateLimit.key:type_name -> nitric.resource.v1.ApiRateLimitKey
+	26, // 14: nitric.resource.v1.ApiResource.security_definitions:type_name -> nitric.resource.v1.ApiResource.SecurityDefinitionsEntry
+	27, // 15: nitric.resource.v1.ApiResource.security:type_name -> nitric.resource.v1.ApiResource.SecurityEntry
+	14, // 16: nitric.resource.v1.ApiResource.cors:type_name -> nitric.resource.v1.ApiCorsDefinition
+	15, // 17: nitric.resource.v1.ApiResource.rate_limit:type_name -> nitric.resource.v1.ApiRateLimit
+	4,  // 18: nitric.resource.v1.ResourceDetailsRequest.resource:type_name -> nitric.resource.v1.Resource
+	18, // 19: nitric.resource.v1.ResourceDetailsResponse.api:type_name -> nitric.resource.v1.ApiResourceDetails
+	19, // 20: nitric.resource.v1.ResourceDetailsResponse.bucket:type_name -> nitric.resource.v1.BucketResourceDetails
+	20, // 21: nitric.resource.v1.ResourceDetailsResponse.topic:type_name -> nitric.resource.v1.TopicResourceDetails
+	21, // 22: nitric.resource.v1.ResourceDetailsResponse.queue:type_name -> nitric.resource.v1.QueueResourceDetails
+	22, // 23: nitric.resource.v1.ResourceDetailsResponse.collection:type_name -> nitric.resource.v1.CollectionResourceDetails
+	23, // 24: nitric.resource.v1.ResourceDetailsResponse.secret:type_name -> nitric.resource.v1.SecretResourceDetails
+	12, // 25: nitric.resource.v1.ApiResource.SecurityDefinitionsEntry.value:type_name -> nitric.resource.v1.ApiSecurityDefinition
+	13, // 26: nitric.resource.v1.ApiResource.SecurityEntry.value:type_name -> nitric.resource.v1.ApiScopes
+	5,  // 27: nitric.resource.v1.ResourceService.Declare:input_type -> nitric.resource.v1.ResourceDeclareRequest
+	24, // 28: nitric.resource.v1.ResourceService.Details:input_type -> nitric.resource.v1.ResourceDetailsRequest
+	17, // 29: nitric.resource.v1.ResourceService.Declare:output_type -> nitric.resource.v1.ResourceDeclareResponse
+	25, // 30: nitric.resource.v1.ResourceService.Details:output_type -> nitric.resource.v1.ResourceDetailsResponse
+	29, // [29:31] is the sub-list for method output_type
+	27, // [27:29] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_proto_resource_v1_resource_proto_init() }
@@ -1499,7 +2166,7 @@ func file_proto_resource_v1_resource_proto_init() {
 			}
 		}
 		file_proto_resource_v1_resource_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiResource); i {
+			switch v := v.(*ApiCorsDefinition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1511,7 +2178,7 @@ func file_proto_resource_v1_resource_proto_init() {
 			}
 		}
 		file_proto_resource_v1_resource_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceDeclareResponse); i {
+			switch v := v.(*ApiRateLimit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1523,7 +2190,7 @@ func file_proto_resource_v1_resource_proto_init() {
 			}
 		}
 		file_proto_resource_v1_resource_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ApiResourceDetails); i {
+			switch v := v.(*ApiResource); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1535,7 +2202,7 @@ func file_proto_resource_v1_resource_proto_init() {
 			}
 		}
 		file_proto_resource_v1_resource_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResourceDetailsRequest); i {
+			switch v := v.(*ResourceDeclareResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1547,6 +2214,90 @@ func file_proto_resource_v1_resource_proto_init() {
 			}
 		}
 		file_proto_resource_v1_resource_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApiResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BucketResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopicResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueueResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SecretResourceDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceDetailsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_resource_v1_resource_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResourceDetailsResponse); i {
 			case 0:
 				return &v.state
@@ -1571,16 +2322,21 @@ func file_proto_resource_v1_resource_proto_init() {
 	file_proto_resource_v1_resource_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*ApiSecurityDefinition_Jwt)(nil),
 	}
-	file_proto_resource_v1_resource_proto_msgTypes[15].OneofWrappers = []interface{}{
+	file_proto_resource_v1_resource_proto_msgTypes[22].OneofWrappers = []interface{}{
 		(*ResourceDetailsResponse_Api)(nil),
+		(*ResourceDetailsResponse_Bucket)(nil),
+		(*ResourceDetailsResponse_Topic)(nil),
+		(*ResourceDetailsResponse_Queue)(nil),
+		(*ResourceDetailsResponse_Collection)(nil),
+		(*ResourceDetailsResponse_Secret)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_resource_v1_resource_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   18,
+			NumEnums:      3,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
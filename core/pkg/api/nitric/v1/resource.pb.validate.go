@@ -1500,6 +1500,219 @@ var _ interface {
 	ErrorName() string
 } = ApiScopesValidationError{}
 
+// Validate checks the field values on ApiCorsDefinition with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ApiCorsDefinition) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ApiCorsDefinition with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ApiCorsDefinitionMultiError, or nil if none found.
+func (m *ApiCorsDefinition) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ApiCorsDefinition) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for AllowCredentials
+
+	// no validation rules for MaxAge
+
+	if len(errors) > 0 {
+		return ApiCorsDefinitionMultiError(errors)
+	}
+
+	return nil
+}
+
+// ApiCorsDefinitionMultiError is an error wrapping multiple validation errors
+// returned by ApiCorsDefinition.ValidateAll() if the designated constraints
+// aren't met.
+type ApiCorsDefinitionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ApiCorsDefinitionMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ApiCorsDefinitionMultiError) AllErrors() []error { return m }
+
+// ApiCorsDefinitionValidationError is the validation error returned by
+// ApiCorsDefinition.Validate if the designated constraints aren't met.
+type ApiCorsDefinitionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ApiCorsDefinitionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ApiCorsDefinitionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ApiCorsDefinitionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ApiCorsDefinitionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ApiCorsDefinitionValidationError) ErrorName() string {
+	return "ApiCorsDefinitionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ApiCorsDefinitionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sApiCorsDefinition.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ApiCorsDefinitionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ApiCorsDefinitionValidationError{}
+
+// Validate checks the field values on ApiRateLimit with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ApiRateLimit) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ApiRateLimit with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ApiRateLimitMultiError, or
+// nil if none found.
+func (m *ApiRateLimit) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ApiRateLimit) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RequestsPerSecond
+
+	// no validation rules for Burst
+
+	// no validation rules for Key
+
+	// no validation rules for Header
+
+	if len(errors) > 0 {
+		return ApiRateLimitMultiError(errors)
+	}
+
+	return nil
+}
+
+// ApiRateLimitMultiError is an error wrapping multiple validation errors
+// returned by ApiRateLimit.ValidateAll() if the designated constraints aren't met.
+type ApiRateLimitMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ApiRateLimitMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ApiRateLimitMultiError) AllErrors() []error { return m }
+
+// ApiRateLimitValidationError is the validation error returned by
+// ApiRateLimit.Validate if the designated constraints aren't met.
+type ApiRateLimitValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ApiRateLimitValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ApiRateLimitValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ApiRateLimitValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ApiRateLimitValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ApiRateLimitValidationError) ErrorName() string { return "ApiRateLimitValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ApiRateLimitValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sApiRateLimit.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ApiRateLimitValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ApiRateLimitValidationError{}
+
 // Validate checks the field values on ApiResource with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -1614,6 +1827,64 @@ func (m *ApiResource) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetCors()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ApiResourceValidationError{
+					field:  "Cors",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ApiResourceValidationError{
+					field:  "Cors",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCors()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApiResourceValidationError{
+				field:  "Cors",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRateLimit()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ApiResourceValidationError{
+					field:  "RateLimit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ApiResourceValidationError{
+					field:  "RateLimit",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRateLimit()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ApiResourceValidationError{
+				field:  "RateLimit",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return ApiResourceMultiError(errors)
 	}
@@ -1897,71 +2168,46 @@ var _ interface {
 	ErrorName() string
 } = ApiResourceDetailsValidationError{}
 
-// Validate checks the field values on ResourceDetailsRequest with the rules
+// Validate checks the field values on BucketResourceDetails with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *ResourceDetailsRequest) Validate() error {
+func (m *BucketResourceDetails) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on ResourceDetailsRequest with the rules
+// ValidateAll checks the field values on BucketResourceDetails with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// ResourceDetailsRequestMultiError, or nil if none found.
-func (m *ResourceDetailsRequest) ValidateAll() error {
+// BucketResourceDetailsMultiError, or nil if none found.
+func (m *BucketResourceDetails) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *ResourceDetailsRequest) validate(all bool) error {
+func (m *BucketResourceDetails) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if all {
-		switch v := interface{}(m.GetResource()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, ResourceDetailsRequestValidationError{
-					field:  "Resource",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, ResourceDetailsRequestValidationError{
-					field:  "Resource",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetResource()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return ResourceDetailsRequestValidationError{
-				field:  "Resource",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Name
+
+	// no validation rules for Url
 
 	if len(errors) > 0 {
-		return ResourceDetailsRequestMultiError(errors)
+		return BucketResourceDetailsMultiError(errors)
 	}
 
 	return nil
 }
 
-// ResourceDetailsRequestMultiError is an error wrapping multiple validation
-// errors returned by ResourceDetailsRequest.ValidateAll() if the designated
+// BucketResourceDetailsMultiError is an error wrapping multiple validation
+// errors returned by BucketResourceDetails.ValidateAll() if the designated
 // constraints aren't met.
-type ResourceDetailsRequestMultiError []error
+type BucketResourceDetailsMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m ResourceDetailsRequestMultiError) Error() string {
+func (m BucketResourceDetailsMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1970,11 +2216,11 @@ func (m ResourceDetailsRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m ResourceDetailsRequestMultiError) AllErrors() []error { return m }
+func (m BucketResourceDetailsMultiError) AllErrors() []error { return m }
 
-// ResourceDetailsRequestValidationError is the validation error returned by
-// ResourceDetailsRequest.Validate if the designated constraints aren't met.
-type ResourceDetailsRequestValidationError struct {
+// BucketResourceDetailsValidationError is the validation error returned by
+// BucketResourceDetails.Validate if the designated constraints aren't met.
+type BucketResourceDetailsValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1982,24 +2228,24 @@ type ResourceDetailsRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e ResourceDetailsRequestValidationError) Field() string { return e.field }
+func (e BucketResourceDetailsValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e ResourceDetailsRequestValidationError) Reason() string { return e.reason }
+func (e BucketResourceDetailsValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e ResourceDetailsRequestValidationError) Cause() error { return e.cause }
+func (e BucketResourceDetailsValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e ResourceDetailsRequestValidationError) Key() bool { return e.key }
+func (e BucketResourceDetailsValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e ResourceDetailsRequestValidationError) ErrorName() string {
-	return "ResourceDetailsRequestValidationError"
+func (e BucketResourceDetailsValidationError) ErrorName() string {
+	return "BucketResourceDetailsValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e ResourceDetailsRequestValidationError) Error() string {
+func (e BucketResourceDetailsValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -2011,14 +2257,14 @@ func (e ResourceDetailsRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sResourceDetailsRequest.%s: %s%s",
+		"invalid %sBucketResourceDetails.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = ResourceDetailsRequestValidationError{}
+var _ error = BucketResourceDetailsValidationError{}
 
 var _ interface {
 	Field() string
@@ -2026,9 +2272,558 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = ResourceDetailsRequestValidationError{}
+} = BucketResourceDetailsValidationError{}
 
-// Validate checks the field values on ResourceDetailsResponse with the rules
+// Validate checks the field values on TopicResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *TopicResourceDetails) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TopicResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// TopicResourceDetailsMultiError, or nil if none found.
+func (m *TopicResourceDetails) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TopicResourceDetails) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return TopicResourceDetailsMultiError(errors)
+	}
+
+	return nil
+}
+
+// TopicResourceDetailsMultiError is an error wrapping multiple validation
+// errors returned by TopicResourceDetails.ValidateAll() if the designated
+// constraints aren't met.
+type TopicResourceDetailsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TopicResourceDetailsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TopicResourceDetailsMultiError) AllErrors() []error { return m }
+
+// TopicResourceDetailsValidationError is the validation error returned by
+// TopicResourceDetails.Validate if the designated constraints aren't met.
+type TopicResourceDetailsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TopicResourceDetailsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TopicResourceDetailsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TopicResourceDetailsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TopicResourceDetailsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TopicResourceDetailsValidationError) ErrorName() string {
+	return "TopicResourceDetailsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e TopicResourceDetailsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTopicResourceDetails.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TopicResourceDetailsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TopicResourceDetailsValidationError{}
+
+// Validate checks the field values on QueueResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QueueResourceDetails) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueueResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueueResourceDetailsMultiError, or nil if none found.
+func (m *QueueResourceDetails) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueueResourceDetails) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	// no validation rules for Url
+
+	if len(errors) > 0 {
+		return QueueResourceDetailsMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueueResourceDetailsMultiError is an error wrapping multiple validation
+// errors returned by QueueResourceDetails.ValidateAll() if the designated
+// constraints aren't met.
+type QueueResourceDetailsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueueResourceDetailsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueueResourceDetailsMultiError) AllErrors() []error { return m }
+
+// QueueResourceDetailsValidationError is the validation error returned by
+// QueueResourceDetails.Validate if the designated constraints aren't met.
+type QueueResourceDetailsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueueResourceDetailsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueueResourceDetailsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueueResourceDetailsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueueResourceDetailsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueueResourceDetailsValidationError) ErrorName() string {
+	return "QueueResourceDetailsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueueResourceDetailsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueueResourceDetails.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueueResourceDetailsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueueResourceDetailsValidationError{}
+
+// Validate checks the field values on CollectionResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CollectionResourceDetails) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CollectionResourceDetails with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CollectionResourceDetailsMultiError, or nil if none found.
+func (m *CollectionResourceDetails) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CollectionResourceDetails) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return CollectionResourceDetailsMultiError(errors)
+	}
+
+	return nil
+}
+
+// CollectionResourceDetailsMultiError is an error wrapping multiple validation
+// errors returned by CollectionResourceDetails.ValidateAll() if the
+// designated constraints aren't met.
+type CollectionResourceDetailsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CollectionResourceDetailsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CollectionResourceDetailsMultiError) AllErrors() []error { return m }
+
+// CollectionResourceDetailsValidationError is the validation error returned by
+// CollectionResourceDetails.Validate if the designated constraints aren't met.
+type CollectionResourceDetailsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CollectionResourceDetailsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CollectionResourceDetailsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CollectionResourceDetailsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CollectionResourceDetailsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CollectionResourceDetailsValidationError) ErrorName() string {
+	return "CollectionResourceDetailsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CollectionResourceDetailsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCollectionResourceDetails.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CollectionResourceDetailsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CollectionResourceDetailsValidationError{}
+
+// Validate checks the field values on SecretResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SecretResourceDetails) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SecretResourceDetails with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SecretResourceDetailsMultiError, or nil if none found.
+func (m *SecretResourceDetails) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SecretResourceDetails) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if len(errors) > 0 {
+		return SecretResourceDetailsMultiError(errors)
+	}
+
+	return nil
+}
+
+// SecretResourceDetailsMultiError is an error wrapping multiple validation
+// errors returned by SecretResourceDetails.ValidateAll() if the designated
+// constraints aren't met.
+type SecretResourceDetailsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SecretResourceDetailsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SecretResourceDetailsMultiError) AllErrors() []error { return m }
+
+// SecretResourceDetailsValidationError is the validation error returned by
+// SecretResourceDetails.Validate if the designated constraints aren't met.
+type SecretResourceDetailsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SecretResourceDetailsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SecretResourceDetailsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SecretResourceDetailsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SecretResourceDetailsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SecretResourceDetailsValidationError) ErrorName() string {
+	return "SecretResourceDetailsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SecretResourceDetailsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSecretResourceDetails.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SecretResourceDetailsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SecretResourceDetailsValidationError{}
+
+// Validate checks the field values on ResourceDetailsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ResourceDetailsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ResourceDetailsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ResourceDetailsRequestMultiError, or nil if none found.
+func (m *ResourceDetailsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ResourceDetailsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetResource()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ResourceDetailsRequestValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ResourceDetailsRequestValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetResource()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ResourceDetailsRequestValidationError{
+				field:  "Resource",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ResourceDetailsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ResourceDetailsRequestMultiError is an error wrapping multiple validation
+// errors returned by ResourceDetailsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ResourceDetailsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ResourceDetailsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ResourceDetailsRequestMultiError) AllErrors() []error { return m }
+
+// ResourceDetailsRequestValidationError is the validation error returned by
+// ResourceDetailsRequest.Validate if the designated constraints aren't met.
+type ResourceDetailsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ResourceDetailsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ResourceDetailsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ResourceDetailsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ResourceDetailsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ResourceDetailsRequestValidationError) ErrorName() string {
+	return "ResourceDetailsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ResourceDetailsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sResourceDetailsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ResourceDetailsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ResourceDetailsRequestValidationError{}
+
+// Validate checks the field values on ResourceDetailsResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
 func (m *ResourceDetailsResponse) Validate() error {
@@ -2089,6 +2884,161 @@ func (m *ResourceDetailsResponse) validate(all bool) error {
 			}
 		}
 
+	case *ResourceDetailsResponse_Bucket:
+
+		if all {
+			switch v := interface{}(m.GetBucket()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Bucket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Bucket",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetBucket()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResourceDetailsResponseValidationError{
+					field:  "Bucket",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ResourceDetailsResponse_Topic:
+
+		if all {
+			switch v := interface{}(m.GetTopic()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Topic",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Topic",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetTopic()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResourceDetailsResponseValidationError{
+					field:  "Topic",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ResourceDetailsResponse_Queue:
+
+		if all {
+			switch v := interface{}(m.GetQueue()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Queue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Queue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetQueue()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResourceDetailsResponseValidationError{
+					field:  "Queue",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ResourceDetailsResponse_Collection:
+
+		if all {
+			switch v := interface{}(m.GetCollection()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Collection",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Collection",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCollection()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResourceDetailsResponseValidationError{
+					field:  "Collection",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *ResourceDetailsResponse_Secret:
+
+		if all {
+			switch v := interface{}(m.GetSecret()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Secret",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ResourceDetailsResponseValidationError{
+						field:  "Secret",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSecret()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ResourceDetailsResponseValidationError{
+					field:  "Secret",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	}
 
 	if len(errors) > 0 {
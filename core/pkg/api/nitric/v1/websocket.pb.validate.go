@@ -0,0 +1,490 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: proto/websocket/v1/websocket.proto
+
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on WebsocketSendRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketSendRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketSendRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketSendRequestMultiError, or nil if none found.
+func (m *WebsocketSendRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketSendRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetSocket()) < 1 {
+		err := WebsocketSendRequestValidationError{
+			field:  "Socket",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetConnectionId()) < 1 {
+		err := WebsocketSendRequestValidationError{
+			field:  "ConnectionId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Data
+
+	if len(errors) > 0 {
+		return WebsocketSendRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketSendRequestMultiError is an error wrapping multiple validation
+// errors returned by WebsocketSendRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketSendRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketSendRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketSendRequestMultiError) AllErrors() []error { return m }
+
+// WebsocketSendRequestValidationError is the validation error returned by
+// WebsocketSendRequest.Validate if the designated constraints aren't met.
+type WebsocketSendRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketSendRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketSendRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketSendRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketSendRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketSendRequestValidationError) ErrorName() string {
+	return "WebsocketSendRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketSendRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketSendRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketSendRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketSendRequestValidationError{}
+
+// Validate checks the field values on WebsocketSendResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketSendResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketSendResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketSendResponseMultiError, or nil if none found.
+func (m *WebsocketSendResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketSendResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return WebsocketSendResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketSendResponseMultiError is an error wrapping multiple validation
+// errors returned by WebsocketSendResponse.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketSendResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketSendResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketSendResponseMultiError) AllErrors() []error { return m }
+
+// WebsocketSendResponseValidationError is the validation error returned by
+// WebsocketSendResponse.Validate if the designated constraints aren't met.
+type WebsocketSendResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketSendResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketSendResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketSendResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketSendResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketSendResponseValidationError) ErrorName() string {
+	return "WebsocketSendResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketSendResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketSendResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketSendResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketSendResponseValidationError{}
+
+// Validate checks the field values on WebsocketCloseRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketCloseRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketCloseRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketCloseRequestMultiError, or nil if none found.
+func (m *WebsocketCloseRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketCloseRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetSocket()) < 1 {
+		err := WebsocketCloseRequestValidationError{
+			field:  "Socket",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetConnectionId()) < 1 {
+		err := WebsocketCloseRequestValidationError{
+			field:  "ConnectionId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return WebsocketCloseRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketCloseRequestMultiError is an error wrapping multiple validation
+// errors returned by WebsocketCloseRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketCloseRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketCloseRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketCloseRequestMultiError) AllErrors() []error { return m }
+
+// WebsocketCloseRequestValidationError is the validation error returned by
+// WebsocketCloseRequest.Validate if the designated constraints aren't met.
+type WebsocketCloseRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketCloseRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketCloseRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketCloseRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketCloseRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketCloseRequestValidationError) ErrorName() string {
+	return "WebsocketCloseRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketCloseRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketCloseRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketCloseRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketCloseRequestValidationError{}
+
+// Validate checks the field values on WebsocketCloseResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *WebsocketCloseResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WebsocketCloseResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// WebsocketCloseResponseMultiError, or nil if none found.
+func (m *WebsocketCloseResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WebsocketCloseResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return WebsocketCloseResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// WebsocketCloseResponseMultiError is an error wrapping multiple validation
+// errors returned by WebsocketCloseResponse.ValidateAll() if the designated
+// constraints aren't met.
+type WebsocketCloseResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WebsocketCloseResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WebsocketCloseResponseMultiError) AllErrors() []error { return m }
+
+// WebsocketCloseResponseValidationError is the validation error returned by
+// WebsocketCloseResponse.Validate if the designated constraints aren't met.
+type WebsocketCloseResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WebsocketCloseResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WebsocketCloseResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WebsocketCloseResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WebsocketCloseResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WebsocketCloseResponseValidationError) ErrorName() string {
+	return "WebsocketCloseResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WebsocketCloseResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWebsocketCloseResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WebsocketCloseResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WebsocketCloseResponseValidationError{}
@@ -0,0 +1,299 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	// ErrUnauthenticated - returned when a request to a secured route has no valid bearer token
+	ErrUnauthenticated = errors.New("request is not authenticated")
+	// ErrForbidden - returned when a request has a valid bearer token without the scopes required by the route
+	ErrForbidden = errors.New("request is missing required scopes")
+)
+
+// Asymmetric signing methods supported for tokens verified against issuer JWKS
+var validMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// JwtDefinition - a JWT security definition declared for an API
+type JwtDefinition struct {
+	Issuer    string
+	Audiences []string
+}
+
+// Requirements - names of security definitions mapped to the scopes required by each
+// A request is authorized if it satisfies any one of the requirements
+type Requirements map[string][]string
+
+type apiSecurity struct {
+	definitions map[string]*JwtDefinition
+	security    Requirements
+}
+
+// Authorizer - validates bearer tokens of API requests against the security declared for the API
+type Authorizer struct {
+	lock sync.RWMutex
+	apis map[string]*apiSecurity
+	keys *keyCache
+}
+
+// DeclareApi - register the security definitions and root level security requirements of an API
+func (a *Authorizer) DeclareApi(name string, definitions map[string]*JwtDefinition, security Requirements) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.apis[name] = &apiSecurity{
+		definitions: definitions,
+		security:    security,
+	}
+}
+
+func (a *Authorizer) api(name string) *apiSecurity {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.apis[name]
+}
+
+// Authorize - validate the bearer token in the given headers for a request to the named API
+// Route requirements take precedence over the root security of the API, when neither is set the request is allowed.
+// Returns the claims of the validated token, or nil if the request did not require authorization.
+func (a *Authorizer) Authorize(ctx context.Context, apiName string, route Requirements, header map[string][]string) (map[string]interface{}, error) {
+	api, requirements := a.requirements(apiName, route)
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	token, err := bearerToken(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+
+	var authErr error = fmt.Errorf("%w: no security definition could validate the token", ErrUnauthenticated)
+	for name, scopes := range requirements {
+		var def *JwtDefinition
+		if api != nil {
+			def = api.definitions[name]
+		}
+
+		if def == nil {
+			// requirements referencing undeclared definitions can never be satisfied
+			continue
+		}
+
+		claims, err := a.validate(ctx, def, token)
+		if err != nil {
+			continue
+		}
+
+		if missing := missingScopes(claims, scopes); len(missing) > 0 {
+			authErr = fmt.Errorf("%w: %s", ErrForbidden, strings.Join(missing, ", "))
+			continue
+		}
+
+		return claims, nil
+	}
+
+	return nil, authErr
+}
+
+// AuthorizeClaims - check the claims of a token already validated by the provider's API gateway against the issuers and scopes required for a request to the named API
+func (a *Authorizer) AuthorizeClaims(apiName string, route Requirements, claims map[string]interface{}) error {
+	api, requirements := a.requirements(apiName, route)
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	var authErr error = fmt.Errorf("%w: no security definition matches the token issuer", ErrUnauthenticated)
+	for name, scopes := range requirements {
+		var def *JwtDefinition
+		if api != nil {
+			def = api.definitions[name]
+		}
+
+		if def == nil || !jwt.MapClaims(claims).VerifyIssuer(def.Issuer, true) {
+			continue
+		}
+
+		if missing := missingScopes(claims, scopes); len(missing) > 0 {
+			authErr = fmt.Errorf("%w: %s", ErrForbidden, strings.Join(missing, ", "))
+			continue
+		}
+
+		return nil
+	}
+
+	return authErr
+}
+
+// Subject - the subject of the bearer token in the given headers if any security definition of the named API validates it, otherwise ""
+func (a *Authorizer) Subject(ctx context.Context, apiName string, header map[string][]string) string {
+	api := a.api(apiName)
+	if api == nil {
+		return ""
+	}
+
+	token, err := bearerToken(header)
+	if err != nil {
+		return ""
+	}
+
+	for _, def := range api.definitions {
+		if claims, err := a.validate(ctx, def, token); err == nil {
+			sub, _ := claims["sub"].(string)
+			return sub
+		}
+	}
+
+	return ""
+}
+
+// requirements - the security of the named API and the requirements that apply to a route, route requirements take precedence over the root security of the API
+func (a *Authorizer) requirements(apiName string, route Requirements) (*apiSecurity, Requirements) {
+	api := a.api(apiName)
+
+	if len(route) == 0 && api != nil {
+		return api, api.security
+	}
+
+	return api, route
+}
+
+func (a *Authorizer) validate(ctx context.Context, def *JwtDefinition, token string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.NewParser(jwt.WithValidMethods(validMethods)).ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+
+		return a.keys.Key(ctx, def.Issuer, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("token has no expiry or has expired")
+	}
+
+	if !claims.VerifyIssuer(def.Issuer, true) {
+		return nil, fmt.Errorf("token issuer does not match %s", def.Issuer)
+	}
+
+	if len(def.Audiences) > 0 && !hasAudience(claims, def.Audiences) {
+		return nil, fmt.Errorf("token audience does not match")
+	}
+
+	return claims, nil
+}
+
+func hasAudience(claims jwt.MapClaims, audiences []string) bool {
+	for _, aud := range audiences {
+		if claims.VerifyAudience(aud, true) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tokenScopes - the scopes granted by a token, from either the space delimited 'scope' claim or the 'scp' claim
+func tokenScopes(claims jwt.MapClaims) map[string]bool {
+	scopes := make(map[string]bool)
+
+	for _, claim := range []string{"scope", "scp"} {
+		switch s := claims[claim].(type) {
+		case string:
+			for _, scope := range strings.Fields(s) {
+				scopes[scope] = true
+			}
+		case []interface{}:
+			for _, scope := range s {
+				if str, ok := scope.(string); ok {
+					scopes[str] = true
+				}
+			}
+		}
+	}
+
+	return scopes
+}
+
+func missingScopes(claims jwt.MapClaims, required []string) []string {
+	granted := tokenScopes(claims)
+	missing := []string{}
+
+	for _, scope := range required {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+
+	return missing
+}
+
+func bearerToken(header map[string][]string) (string, error) {
+	for k, v := range header {
+		if !strings.EqualFold(k, "Authorization") || len(v) == 0 {
+			continue
+		}
+
+		scheme, token, ok := strings.Cut(v[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			return "", fmt.Errorf("authorization header is not a bearer token")
+		}
+
+		return strings.TrimSpace(token), nil
+	}
+
+	return "", fmt.Errorf("missing authorization header")
+}
+
+type AuthorizerOption = func(*Authorizer)
+
+// WithHttpClient - the client used to retrieve issuer OIDC configuration and signing keys
+func WithHttpClient(client *http.Client) AuthorizerOption {
+	return func(a *Authorizer) {
+		a.keys.client = client
+	}
+}
+
+// WithKeyCacheTTL - how long issuer signing keys are cached before being refreshed
+func WithKeyCacheTTL(ttl time.Duration) AuthorizerOption {
+	return func(a *Authorizer) {
+		a.keys.ttl = ttl
+	}
+}
+
+func NewAuthorizer(opts ...AuthorizerOption) *Authorizer {
+	a := &Authorizer{
+		apis: make(map[string]*apiSecurity),
+		keys: newKeyCache(&http.Client{Timeout: 10 * time.Second}, time.Hour),
+	}
+
+	for _, o := range opts {
+		o(a)
+	}
+
+	return a
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testIssuer struct {
+	server         *httptest.Server
+	key            *rsa.PrivateKey
+	kid            string
+	discoveryCalls int32
+	jwksCalls      int32
+}
+
+func newTestIssuer() *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	iss := &testIssuer{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&iss.discoveryCalls, 1)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   iss.server.URL,
+			"jwks_uri": iss.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&iss.jwksCalls, 1)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": iss.kid,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(iss.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(iss.key.E)).Bytes()),
+			}},
+		})
+	})
+
+	iss.server = httptest.NewServer(mux)
+
+	return iss
+}
+
+func (iss *testIssuer) token(claims jwt.MapClaims) string {
+	std := jwt.MapClaims{
+		"iss": iss.server.URL,
+		"aud": "test-audience",
+		"sub": "test-user",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range claims {
+		std[k] = v
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, std)
+	t.Header["kid"] = iss.kid
+
+	signed, err := t.SignedString(iss.key)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return signed
+}
+
+func bearer(token string) map[string][]string {
+	return map[string][]string{
+		"Authorization": {"Bearer " + token},
+	}
+}
+
+var _ = Describe("Authorizer", func() {
+	var iss *testIssuer
+	var authorizer *Authorizer
+
+	BeforeEach(func() {
+		iss = newTestIssuer()
+		authorizer = NewAuthorizer()
+		authorizer.DeclareApi("test-api", map[string]*JwtDefinition{
+			"user": {
+				Issuer:    iss.server.URL,
+				Audiences: []string{"test-audience"},
+			},
+		}, Requirements{
+			"user": {},
+		})
+	})
+
+	AfterEach(func() {
+		iss.server.Close()
+	})
+
+	When("the api has no security", func() {
+		It("should allow requests without a token", func() {
+			claims, err := authorizer.Authorize(context.TODO(), "unsecured-api", nil, map[string][]string{})
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(claims).To(BeNil())
+		})
+	})
+
+	When("the request has no authorization header", func() {
+		It("should return ErrUnauthenticated", func() {
+			_, err := authorizer.Authorize(context.TODO(), "test-api", nil, map[string][]string{})
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the request has a valid token", func() {
+		It("should return the token claims", func() {
+			claims, err := authorizer.Authorize(context.TODO(), "test-api", nil, bearer(iss.token(nil)))
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(claims["sub"]).To(Equal("test-user"))
+		})
+
+		It("should cache the issuer signing keys", func() {
+			for i := 0; i < 3; i++ {
+				_, err := authorizer.Authorize(context.TODO(), "test-api", nil, bearer(iss.token(nil)))
+				Expect(err).ShouldNot(HaveOccurred())
+			}
+
+			Expect(atomic.LoadInt32(&iss.discoveryCalls)).To(Equal(int32(1)))
+			Expect(atomic.LoadInt32(&iss.jwksCalls)).To(Equal(int32(1)))
+		})
+	})
+
+	When("the signing keys are rotated", func() {
+		It("should refresh the keys for an unknown key id", func() {
+			authorizer.keys.minRefresh = 0
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", nil, bearer(iss.token(nil)))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			iss.kid = "key-2"
+			_, err = authorizer.Authorize(context.TODO(), "test-api", nil, bearer(iss.token(nil)))
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(atomic.LoadInt32(&iss.jwksCalls)).To(Equal(int32(2)))
+		})
+	})
+
+	When("the token has expired", func() {
+		It("should return ErrUnauthenticated", func() {
+			token := iss.token(jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()})
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", nil, bearer(token))
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the token is for another audience", func() {
+		It("should return ErrUnauthenticated", func() {
+			token := iss.token(jwt.MapClaims{"aud": "other-audience"})
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", nil, bearer(token))
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the token is signed by an unknown key", func() {
+		It("should return ErrUnauthenticated", func() {
+			other, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			t := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+				"iss": iss.server.URL,
+				"aud": "test-audience",
+				"exp": time.Now().Add(time.Hour).Unix(),
+			})
+			t.Header["kid"] = iss.kid
+			token, err := t.SignedString(other)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, err = authorizer.Authorize(context.TODO(), "test-api", nil, bearer(token))
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the route requires scopes", func() {
+		route := Requirements{"user": {"read", "write"}}
+
+		It("should return ErrForbidden if the token is missing scopes", func() {
+			token := iss.token(jwt.MapClaims{"scope": "read"})
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", route, bearer(token))
+
+			Expect(errors.Is(err, ErrForbidden)).To(BeTrue())
+		})
+
+		It("should accept scopes from the scope claim", func() {
+			token := iss.token(jwt.MapClaims{"scope": "read write"})
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", route, bearer(token))
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should accept scopes from the scp claim", func() {
+			token := iss.token(jwt.MapClaims{"scp": []string{"read", "write"}})
+
+			_, err := authorizer.Authorize(context.TODO(), "test-api", route, bearer(token))
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	When("the route references an undeclared security definition", func() {
+		It("should return ErrUnauthenticated", func() {
+			_, err := authorizer.Authorize(context.TODO(), "test-api", Requirements{"admin": {}}, bearer(iss.token(nil)))
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the token was already validated by the provider's API gateway", func() {
+		route := Requirements{"user": {"read"}}
+
+		It("should accept claims from the declared issuer with the required scopes", func() {
+			err := authorizer.AuthorizeClaims("test-api", route, map[string]interface{}{"iss": iss.server.URL, "scope": "read"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should return ErrForbidden if the claims are missing scopes", func() {
+			err := authorizer.AuthorizeClaims("test-api", route, map[string]interface{}{"iss": iss.server.URL})
+
+			Expect(errors.Is(err, ErrForbidden)).To(BeTrue())
+		})
+
+		It("should return ErrUnauthenticated if the claims are from another issuer", func() {
+			err := authorizer.AuthorizeClaims("test-api", route, map[string]interface{}{"iss": "https://other.example.com", "scope": "read"})
+
+			Expect(errors.Is(err, ErrUnauthenticated)).To(BeTrue())
+		})
+	})
+
+	When("the subject of a token is requested", func() {
+		It("should return the subject of a valid token", func() {
+			Expect(authorizer.Subject(context.TODO(), "test-api", bearer(iss.token(nil)))).To(Equal("test-user"))
+		})
+
+		It("should return an empty subject for a token the api can't validate", func() {
+			unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "test-user"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+			Expect(authorizer.Subject(context.TODO(), "test-api", bearer(unsigned))).To(Equal(""))
+		})
+	})
+})
@@ -0,0 +1,250 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// openIdConfiguration - the subset of an issuer's OIDC discovery document used for token validation
+type openIdConfiguration struct {
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// keySet - the cached signing keys of a single issuer
+type keySet struct {
+	lock      sync.Mutex
+	jwksUri   string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// keyCache - fetches and caches issuer signing keys discovered via OIDC discovery
+type keyCache struct {
+	lock   sync.Mutex
+	client *http.Client
+	// How long fetched keys are used before being refreshed
+	ttl time.Duration
+	// Minimum interval between refreshes triggered by an unknown key ID
+	minRefresh time.Duration
+	sets       map[string]*keySet
+}
+
+func newKeyCache(client *http.Client, ttl time.Duration) *keyCache {
+	return &keyCache{
+		client:     client,
+		ttl:        ttl,
+		minRefresh: time.Minute,
+		sets:       make(map[string]*keySet),
+	}
+}
+
+func (c *keyCache) keySet(issuer string) *keySet {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ks, ok := c.sets[issuer]
+	if !ok {
+		ks = &keySet{}
+		c.sets[issuer] = ks
+	}
+
+	return ks
+}
+
+// Key - retrieve the public key with the given key ID for an issuer
+// keys are refreshed once they expire, or early if the key ID is unknown, allowing for key rotation
+func (c *keyCache) Key(ctx context.Context, issuer string, kid string) (crypto.PublicKey, error) {
+	ks := c.keySet(issuer)
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	age := time.Since(ks.fetchedAt)
+	if ks.keys != nil && age < c.ttl {
+		if key, ok := ks.lookup(kid); ok {
+			return key, nil
+		}
+
+		if age < c.minRefresh {
+			return nil, fmt.Errorf("no signing key found for key id %q", kid)
+		}
+	}
+
+	if err := c.refresh(ctx, issuer, ks); err != nil {
+		return nil, err
+	}
+
+	if key, ok := ks.lookup(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("no signing key found for key id %q", kid)
+}
+
+func (ks *keySet) lookup(kid string) (crypto.PublicKey, bool) {
+	if key, ok := ks.keys[kid]; ok {
+		return key, true
+	}
+
+	// tokens without a key ID can only be matched when the issuer has a single key
+	if kid == "" && len(ks.keys) == 1 {
+		for _, key := range ks.keys {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+func (c *keyCache) refresh(ctx context.Context, issuer string, ks *keySet) error {
+	if ks.jwksUri == "" {
+		conf := &openIdConfiguration{}
+		if err := c.getJson(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", conf); err != nil {
+			return fmt.Errorf("error retrieving openid configuration for issuer %s: %w", issuer, err)
+		}
+
+		if conf.JwksUri == "" {
+			return fmt.Errorf("openid configuration for issuer %s has no jwks_uri", issuer)
+		}
+
+		ks.jwksUri = conf.JwksUri
+	}
+
+	jwks := &jsonWebKeySet{}
+	if err := c.getJson(ctx, ks.jwksUri, jwks); err != nil {
+		return fmt.Errorf("error retrieving signing keys for issuer %s: %w", issuer, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			// skip key types we're unable to use, other keys in the set may still be valid
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	ks.keys = keys
+	ks.fetchedAt = time.Now()
+
+	return nil
+}
+
+func (c *keyCache) getJson(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+)
+
+type resourceKey struct {
+	typ  v1.ResourceType
+	name string
+}
+
+// Collector - records the resources declared and the workers registered by an application,
+// so the application can be described as a deployment spec without being deployed
+type Collector struct {
+	lock      sync.Mutex
+	resources map[resourceKey]*v1.ResourceDeclareRequest
+	policies  []*v1.PolicyResource
+	workers   []*v1.InitRequest
+	// The time of the most recent declaration or registration, zero until the first
+	updated time.Time
+
+	now func() time.Time
+}
+
+// Declare - record a declared resource or policy, later declarations of a resource replace earlier ones
+func (c *Collector) Declare(req *v1.ResourceDeclareRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	req = proto.Clone(req).(*v1.ResourceDeclareRequest)
+
+	if pol := req.GetPolicy(); pol != nil {
+		c.policies = append(c.policies, pol)
+	} else {
+		c.resources[resourceKey{typ: req.GetResource().GetType(), name: req.GetResource().GetName()}] = req
+	}
+
+	c.updated = c.now()
+}
+
+// RegisterWorker - record the InitRequest of a worker
+func (c *Collector) RegisterWorker(req *v1.InitRequest) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.workers = append(c.workers, proto.Clone(req).(*v1.InitRequest))
+	c.updated = c.now()
+}
+
+// Updated - returns the time of the most recent declaration or registration, or zero if nothing has been recorded
+func (c *Collector) Updated() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.updated
+}
+
+func NewCollector() *Collector {
+	return &Collector{
+		resources: make(map[resourceKey]*v1.ResourceDeclareRequest),
+		now:       time.Now,
+	}
+}
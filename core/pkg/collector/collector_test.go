@@ -0,0 +1,253 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+)
+
+func findResource(spec *deploy.Spec, typ v1.ResourceType, name string) *deploy.Resource {
+	for _, r := range spec.Resources {
+		if r.Type == typ && r.Name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+var _ = Describe("Collector", func() {
+	image := &deploy.ExecutionUnit{
+		Source: &deploy.ExecutionUnit_Image{Image: &deploy.ImageSource{Uri: "example/app:latest"}},
+	}
+
+	When("nothing has been recorded", func() {
+		c := NewCollector()
+
+		It("should not have been updated", func() {
+			Expect(c.Updated().IsZero()).To(BeTrue())
+		})
+
+		It("should describe the execution unit alone", func() {
+			spec, err := c.Spec("app", image)
+
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(spec.Resources).To(HaveLen(1))
+			Expect(spec.Resources[0].Name).To(Equal("app"))
+			Expect(spec.Resources[0].GetExecutionUnit().GetImage().GetUri()).To(Equal("example/app:latest"))
+		})
+	})
+
+	When("an application declares resources and registers workers", func() {
+		c := NewCollector()
+
+		c.Declare(&v1.ResourceDeclareRequest{
+			Resource: &v1.Resource{Type: v1.ResourceType_Bucket, Name: "images"},
+			Config:   &v1.ResourceDeclareRequest_Bucket{Bucket: &v1.BucketResource{}},
+		})
+		c.Declare(&v1.ResourceDeclareRequest{
+			Resource: &v1.Resource{Type: v1.ResourceType_Topic, Name: "orders"},
+			Config:   &v1.ResourceDeclareRequest_Topic{Topic: &v1.TopicResource{}},
+		})
+		c.Declare(&v1.ResourceDeclareRequest{
+			Resource: &v1.Resource{Type: v1.ResourceType_Secret, Name: "api-key"},
+			Config:   &v1.ResourceDeclareRequest_Secret{Secret: &v1.SecretResource{}},
+		})
+		c.Declare(&v1.ResourceDeclareRequest{
+			Resource: &v1.Resource{Type: v1.ResourceType_Api, Name: "public"},
+			Config: &v1.ResourceDeclareRequest_Api{Api: &v1.ApiResource{
+				SecurityDefinitions: map[string]*v1.ApiSecurityDefinition{
+					"user": {Definition: &v1.ApiSecurityDefinition_Jwt{Jwt: &v1.ApiSecurityDefinitionJwt{
+						Issuer:    "https://auth.example.com",
+						Audiences: []string{"public"},
+					}}},
+				},
+				Cors: &v1.ApiCorsDefinition{AllowOrigins: []string{"*"}},
+			}},
+		})
+
+		policy := &v1.ResourceDeclareRequest{
+			Resource: &v1.Resource{Type: v1.ResourceType_Policy},
+			Config: &v1.ResourceDeclareRequest_Policy{Policy: &v1.PolicyResource{
+				Principals: []*v1.Resource{{Type: v1.ResourceType_Function}},
+				Actions:    []v1.Action{v1.Action_BucketFileGet, v1.Action_BucketFilePut},
+				Resources:  []*v1.Resource{{Type: v1.ResourceType_Bucket, Name: "images"}},
+			}},
+		}
+		// the same policy declared by two workers
+		c.Declare(policy)
+		c.Declare(policy)
+
+		route := &v1.InitRequest{Worker: &v1.InitRequest_Api{Api: &v1.ApiWorker{
+			Api:     "public",
+			Path:    "/customers/:id<int>",
+			Methods: []string{"GET", "DELETE"},
+			Options: &v1.ApiWorkerOptions{
+				Security: map[string]*v1.ApiWorkerScopes{"user": {Scopes: []string{"customers:read"}}},
+			},
+		}}}
+		// the same route registered by two workers
+		c.RegisterWorker(route)
+		c.RegisterWorker(route)
+		c.RegisterWorker(&v1.InitRequest{Worker: &v1.InitRequest_Api{Api: &v1.ApiWorker{
+			Api:     "public",
+			Path:    "/health",
+			Methods: []string{"GET"},
+			Options: &v1.ApiWorkerOptions{SecurityDisabled: true},
+		}}})
+		c.RegisterWorker(&v1.InitRequest{Worker: &v1.InitRequest_Subscription{Subscription: &v1.SubscriptionWorker{Topic: "orders"}}})
+		c.RegisterWorker(&v1.InitRequest{Worker: &v1.InitRequest_Subscription{Subscription: &v1.SubscriptionWorker{Topic: "undeclared"}}})
+		c.RegisterWorker(&v1.InitRequest{Worker: &v1.InitRequest_Schedule{Schedule: &v1.ScheduleWorker{
+			Key:     "nightly",
+			Cadence: &v1.ScheduleWorker_Rate{Rate: &v1.ScheduleRate{Rate: "2 hours"}},
+		}}})
+
+		spec, err := c.Spec("app", image)
+
+		It("should not return an error", func() {
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should have been updated", func() {
+			Expect(c.Updated().IsZero()).To(BeFalse())
+		})
+
+		It("should count the registered workers", func() {
+			Expect(findResource(spec, v1.ResourceType_Function, "app").GetExecutionUnit().GetWorkers()).To(Equal(int32(6)))
+		})
+
+		It("should include declared resources", func() {
+			Expect(findResource(spec, v1.ResourceType_Bucket, "images").GetBucket()).ToNot(BeNil())
+			Expect(findResource(spec, v1.ResourceType_Secret, "api-key")).ToNot(BeNil())
+		})
+
+		It("should subscribe the execution unit to topics", func() {
+			Expect(findResource(spec, v1.ResourceType_Topic, "orders").GetTopic().GetSubscriptions()[0].GetExecutionUnit()).To(Equal("app"))
+			Expect(findResource(spec, v1.ResourceType_Topic, "undeclared").GetTopic().GetSubscriptions()).To(HaveLen(1))
+		})
+
+		It("should convert schedule rates to cron expressions", func() {
+			schedule := findResource(spec, v1.ResourceType_Schedule, "nightly").GetSchedule()
+
+			Expect(schedule.GetCron()).To(Equal("0 */2 * * *"))
+			Expect(schedule.GetTarget().GetExecutionUnit()).To(Equal("app"))
+		})
+
+		It("should include each distinct policy once, granted to the execution unit", func() {
+			var policies []*deploy.Policy
+			for _, r := range spec.Resources {
+				if r.Type == v1.ResourceType_Policy {
+					policies = append(policies, r.GetPolicy())
+				}
+			}
+
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].Principals[0].Name).To(Equal("app"))
+			Expect(policies[0].Resources[0].Name).To(Equal("images"))
+		})
+
+		It("should generate an OpenAPI document for the api", func() {
+			api := findResource(spec, v1.ResourceType_Api, "public").GetApi()
+			Expect(api.GetCors().GetAllowOrigins()).To(Equal([]string{"*"}))
+
+			doc := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(api.GetOpenapi()), &doc)).To(Succeed())
+
+			paths := doc["paths"].(map[string]interface{})
+			Expect(paths).To(HaveLen(2))
+
+			customer := paths["/customers/{id}"].(map[string]interface{})
+			Expect(customer).To(HaveKey("get"))
+			Expect(customer).To(HaveKey("delete"))
+
+			get := customer["get"].(map[string]interface{})
+			Expect(get["x-nitric-target"]).To(Equal(map[string]interface{}{"name": "app", "type": "function"}))
+			Expect(get["security"]).To(Equal([]interface{}{map[string]interface{}{"user": []interface{}{"customers:read"}}}))
+
+			health := paths["/health"].(map[string]interface{})["get"].(map[string]interface{})
+			Expect(health["security"]).To(Equal([]interface{}{}))
+
+			schemes := doc["components"].(map[string]interface{})["securitySchemes"].(map[string]interface{})
+			Expect(schemes["user"]).To(HaveKeyWithValue("openIdConnectUrl", "https://auth.example.com/.well-known/openid-configuration"))
+		})
+
+		It("should describe the same application with the same spec", func() {
+			again, err := c.Spec("app", image)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(protojson.Format(again)).To(Equal(protojson.Format(spec)))
+		})
+	})
+
+	When("a schedule has an invalid rate", func() {
+		c := NewCollector()
+		c.RegisterWorker(&v1.InitRequest{Worker: &v1.InitRequest_Schedule{Schedule: &v1.ScheduleWorker{
+			Key:     "weekly",
+			Cadence: &v1.ScheduleWorker_Rate{Rate: &v1.ScheduleRate{Rate: "1 week"}},
+		}}})
+
+		It("should return an error", func() {
+			_, err := c.Spec("app", image)
+
+			Expect(err).Should(MatchError(ContainSubstring("schedule weekly")))
+		})
+	})
+
+	When("writing a spec", func() {
+		spec := &deploy.Spec{Resources: []*deploy.Resource{{Name: "app", Type: v1.ResourceType_Function}}}
+
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = os.MkdirTemp("", "collector-spec")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("should write JSON by default", func() {
+			path := filepath.Join(dir, "spec.json")
+			Expect(WriteSpec(path, spec)).To(Succeed())
+
+			b, err := os.ReadFile(path)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			written := &deploy.Spec{}
+			Expect(protojson.Unmarshal(b, written)).To(Succeed())
+			Expect(written.Resources[0].Name).To(Equal("app"))
+		})
+
+		It("should write protobuf for .pb paths", func() {
+			path := filepath.Join(dir, "spec.pb")
+			Expect(WriteSpec(path, spec)).To(Succeed())
+
+			b, err := os.ReadFile(path)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(protojson.Unmarshal(b, &deploy.Spec{})).ToNot(Succeed())
+		})
+	})
+})
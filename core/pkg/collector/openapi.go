@@ -0,0 +1,179 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/routes"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+func securityRequirements(security map[string][]string) openapi3.SecurityRequirements {
+	names := make([]string, 0, len(security))
+	for name := range security {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	requirements := openapi3.SecurityRequirements{}
+	for _, name := range names {
+		requirements = append(requirements, openapi3.SecurityRequirement{name: security[name]})
+	}
+
+	return requirements
+}
+
+func routeSecurity(opts *v1.ApiWorkerOptions) map[string][]string {
+	security := make(map[string][]string)
+	for name, scopes := range opts.GetSecurity() {
+		security[name] = scopes.GetScopes()
+	}
+
+	return security
+}
+
+// apiDocument - generates an OpenAPI document for an api from its declaration and the routes registered for it,
+// operations target the given execution unit with the x-nitric-target extension
+func apiDocument(name string, api *v1.ApiResource, apiRoutes []*v1.ApiWorker, unit string) (string, error) {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.1",
+		Info: &openapi3.Info{
+			Title:   name,
+			Version: "v1",
+		},
+		Paths:      openapi3.Paths{},
+		Components: &openapi3.Components{},
+	}
+
+	for sn, sd := range api.GetSecurityDefinitions() {
+		jwt := sd.GetJwt()
+		if jwt == nil {
+			return "", fmt.Errorf("unsupported security definition %s", sn)
+		}
+
+		if doc.Components.SecuritySchemes == nil {
+			doc.Components.SecuritySchemes = openapi3.SecuritySchemes{}
+		}
+
+		doc.Components.SecuritySchemes[sn] = &openapi3.SecuritySchemeRef{
+			Value: &openapi3.SecurityScheme{
+				Type:             "openIdConnect",
+				OpenIdConnectUrl: strings.TrimSuffix(jwt.GetIssuer(), "/") + "/.well-known/openid-configuration",
+				Extensions: map[string]interface{}{
+					"x-nitric-audiences": jwt.GetAudiences(),
+				},
+			},
+		}
+	}
+
+	rootSecurity := make(map[string][]string)
+	for sn, scopes := range api.GetSecurity() {
+		rootSecurity[sn] = scopes.GetScopes()
+	}
+
+	if len(rootSecurity) > 0 {
+		doc.Security = securityRequirements(rootSecurity)
+	}
+
+	// Order routes so the same application always produces the same document
+	sort.SliceStable(apiRoutes, func(i, j int) bool {
+		return apiRoutes[i].GetPath() < apiRoutes[j].GetPath()
+	})
+
+	operationIds := make(map[string]bool)
+
+	for _, route := range apiRoutes {
+		template, err := routes.Parse(route.GetPath())
+		if err != nil {
+			return "", err
+		}
+
+		path, params := template.OpenApiPath()
+
+		parameters := openapi3.Parameters{}
+		for _, p := range params {
+			schema := openapi3.NewStringSchema()
+			if p.Pattern != "" {
+				schema = schema.WithPattern(p.Pattern)
+			}
+
+			parameters = append(parameters, &openapi3.ParameterRef{
+				Value: openapi3.NewPathParameter(p.Name).WithSchema(schema),
+			})
+		}
+
+		pathItem := doc.Paths.Find(path)
+		if pathItem == nil {
+			pathItem = &openapi3.PathItem{}
+			doc.Paths[path] = pathItem
+		}
+
+		for _, method := range route.GetMethods() {
+			// the same route is registered by every worker handling it
+			if pathItem.GetOperation(method) != nil {
+				continue
+			}
+
+			baseId := strings.Trim(strings.ToLower(nonAlphanumeric.ReplaceAllString(method+"-"+path, "-")), "-")
+			operationId := baseId
+			for i := 2; operationIds[operationId]; i++ {
+				operationId = fmt.Sprintf("%s-%d", baseId, i)
+			}
+			operationIds[operationId] = true
+
+			op := &openapi3.Operation{
+				OperationID: operationId,
+				Parameters:  parameters,
+				Responses:   openapi3.NewResponses(),
+				Extensions: map[string]interface{}{
+					"x-nitric-target": map[string]string{
+						"name": unit,
+						"type": "function",
+					},
+				},
+			}
+
+			if route.GetOptions().GetSecurityDisabled() {
+				op.Security = &openapi3.SecurityRequirements{}
+			} else if security := routeSecurity(route.GetOptions()); len(security) > 0 {
+				requirements := securityRequirements(security)
+				op.Security = &requirements
+			}
+
+			pathItem.SetOperation(method, op)
+		}
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
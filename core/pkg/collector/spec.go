@@ -0,0 +1,246 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+)
+
+// rateToCron - converts a schedule rate, e.g. "5 minutes", to a cron expression
+func rateToCron(rate string) (string, error) {
+	parts := strings.Fields(rate)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid schedule rate %q, expected a number and a unit e.g. 5 minutes", rate)
+	}
+
+	n, err := strconv.Atoi(parts[0])
+	if err != nil || n < 1 {
+		return "", fmt.Errorf("invalid schedule rate %q, expected a positive number", rate)
+	}
+
+	switch strings.TrimSuffix(parts[1], "s") {
+	case "minute":
+		return fmt.Sprintf("*/%d * * * *", n), nil
+	case "hour":
+		return fmt.Sprintf("0 */%d * * *", n), nil
+	case "day":
+		return fmt.Sprintf("0 0 */%d * *", n), nil
+	default:
+		return "", fmt.Errorf("invalid schedule rate %q, supported units are minutes, hours and days", rate)
+	}
+}
+
+// policyName - a name for a policy that is stable across runs of the application
+func policyName(unit string, pol *deploy.Policy) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(pol)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s-%x", unit, sha256.Sum256(b))[:len(unit)+9], nil
+}
+
+func specPolicy(unit string, pol *v1.PolicyResource) *deploy.Policy {
+	dp := &deploy.Policy{
+		Actions: pol.GetActions(),
+	}
+
+	for _, p := range pol.GetPrincipals() {
+		name := p.GetName()
+		// unnamed function principals refer to the declaring application
+		if p.GetType() == v1.ResourceType_Function && name == "" {
+			name = unit
+		}
+
+		dp.Principals = append(dp.Principals, &deploy.Resource{Name: name, Type: p.GetType()})
+	}
+
+	for _, r := range pol.GetResources() {
+		dp.Resources = append(dp.Resources, &deploy.Resource{Name: r.GetName(), Type: r.GetType()})
+	}
+
+	return dp
+}
+
+// Spec - describes the recorded resources and workers as a deployment spec,
+// with the given execution unit running the application and targeted by all of its workers
+func (c *Collector) Spec(unit string, eu *deploy.ExecutionUnit) (*deploy.Spec, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	eu = proto.Clone(eu).(*deploy.ExecutionUnit)
+	if eu.Workers == 0 {
+		eu.Workers = int32(len(c.workers))
+	}
+
+	// Resources referenced by workers are included even if they were not declared
+	declared := make(map[resourceKey]*v1.ResourceDeclareRequest)
+	for k, r := range c.resources {
+		declared[k] = r
+	}
+
+	subscribed := make(map[string]bool)
+	routes := make(map[string][]*v1.ApiWorker)
+	schedules := make(map[string]*deploy.Schedule)
+
+	for _, w := range c.workers {
+		switch {
+		case w.GetApi() != nil:
+			api := w.GetApi()
+			routes[api.GetApi()] = append(routes[api.GetApi()], api)
+
+			if _, ok := declared[resourceKey{typ: v1.ResourceType_Api, name: api.GetApi()}]; !ok {
+				declared[resourceKey{typ: v1.ResourceType_Api, name: api.GetApi()}] = &v1.ResourceDeclareRequest{}
+			}
+		case w.GetSubscription() != nil:
+			topic := w.GetSubscription().GetTopic()
+			subscribed[topic] = true
+
+			if _, ok := declared[resourceKey{typ: v1.ResourceType_Topic, name: topic}]; !ok {
+				declared[resourceKey{typ: v1.ResourceType_Topic, name: topic}] = &v1.ResourceDeclareRequest{}
+			}
+		case w.GetSchedule() != nil:
+			sched := w.GetSchedule()
+
+			cron := sched.GetCron().GetCron()
+			if rate := sched.GetRate(); rate != nil {
+				var err error
+				if cron, err = rateToCron(rate.GetRate()); err != nil {
+					return nil, fmt.Errorf("schedule %s: %w", sched.GetKey(), err)
+				}
+			}
+
+			schedules[sched.GetKey()] = &deploy.Schedule{
+				Cron: cron,
+				Target: &deploy.ScheduleTarget{
+					Target: &deploy.ScheduleTarget_ExecutionUnit{ExecutionUnit: unit},
+				},
+			}
+		}
+	}
+
+	resources := []*deploy.Resource{{
+		Name:   unit,
+		Type:   v1.ResourceType_Function,
+		Config: &deploy.Resource_ExecutionUnit{ExecutionUnit: eu},
+	}}
+
+	for k, req := range declared {
+		res := &deploy.Resource{Name: k.name, Type: k.typ}
+
+		switch k.typ {
+		case v1.ResourceType_Bucket:
+			res.Config = &deploy.Resource_Bucket{Bucket: &deploy.Bucket{}}
+		case v1.ResourceType_Queue:
+			res.Config = &deploy.Resource_Queue{Queue: &deploy.Queue{}}
+		case v1.ResourceType_Collection:
+			res.Config = &deploy.Resource_Collection{Collection: &deploy.Collection{}}
+		case v1.ResourceType_Secret:
+			// secrets have no deployment configuration
+		case v1.ResourceType_Topic:
+			topic := &deploy.Topic{}
+			if subscribed[k.name] {
+				topic.Subscriptions = append(topic.Subscriptions, &deploy.SubscriptionTarget{
+					Target: &deploy.SubscriptionTarget_ExecutionUnit{ExecutionUnit: unit},
+				})
+			}
+			res.Config = &deploy.Resource_Topic{Topic: topic}
+		case v1.ResourceType_Api:
+			doc, err := apiDocument(k.name, req.GetApi(), routes[k.name], unit)
+			if err != nil {
+				return nil, fmt.Errorf("api %s: %w", k.name, err)
+			}
+
+			res.Config = &deploy.Resource_Api{Api: &deploy.Api{
+				Document: &deploy.Api_Openapi{Openapi: doc},
+				Cors:     req.GetApi().GetCors(),
+			}}
+		default:
+			continue
+		}
+
+		resources = append(resources, res)
+	}
+
+	for key, sched := range schedules {
+		resources = append(resources, &deploy.Resource{
+			Name:   key,
+			Type:   v1.ResourceType_Schedule,
+			Config: &deploy.Resource_Schedule{Schedule: sched},
+		})
+	}
+
+	policies := make(map[string]bool)
+	for _, pol := range c.policies {
+		dp := specPolicy(unit, pol)
+
+		name, err := policyName(unit, dp)
+		if err != nil {
+			return nil, err
+		}
+
+		// identical policies may be declared more than once, e.g. by several workers
+		if policies[name] {
+			continue
+		}
+		policies[name] = true
+
+		resources = append(resources, &deploy.Resource{
+			Name:   name,
+			Type:   v1.ResourceType_Policy,
+			Config: &deploy.Resource_Policy{Policy: dp},
+		})
+	}
+
+	// Order resources so the same application always produces the same spec
+	sort.SliceStable(resources[1:], func(i, j int) bool {
+		a, b := resources[i+1], resources[j+1]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Name < b.Name
+	})
+
+	return &deploy.Spec{Resources: resources}, nil
+}
+
+// WriteSpec - writes a spec to the given path, as protobuf if the path ends in .pb otherwise as JSON
+func WriteSpec(path string, spec *deploy.Spec) error {
+	var b []byte
+	var err error
+
+	if strings.HasSuffix(path, ".pb") {
+		b, err = proto.Marshal(spec)
+	} else {
+		b, err = protojson.MarshalOptions{Multiline: true}.Marshal(spec)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0o644)
+}
@@ -0,0 +1,205 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+// Config - the cross-origin resource sharing configuration of an API
+type Config struct {
+	// Origins allowed to make requests, '*' allows any origin
+	AllowOrigins []string
+	// Methods allowed in cross-origin requests, the methods of the matched route are used when empty
+	AllowMethods []string
+	// Request headers allowed in cross-origin requests, '*' allows any header
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// How long in seconds the result of a preflight request may be cached, omitted when zero
+	MaxAge int
+}
+
+// Registry - the CORS configuration of declared APIs
+type Registry struct {
+	lock sync.RWMutex
+	apis map[string]*Config
+}
+
+// DeclareApi - register the CORS configuration of an API, a nil config disables CORS handling for the API
+func (r *Registry) DeclareApi(name string, config *Config) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if config == nil {
+		delete(r.apis, name)
+		return
+	}
+
+	r.apis[name] = config
+}
+
+// Config - retrieve the CORS configuration of an API, nil if none was declared
+func (r *Registry) Config(api string) *Config {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.apis[api]
+}
+
+func header(h map[string][]string, key string) string {
+	for k, v := range h {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return v[0]
+		}
+	}
+
+	return ""
+}
+
+// IsPreflight - whether the request is a CORS preflight request
+func IsPreflight(req *triggers.HttpRequest) bool {
+	return req.Method == http.MethodOptions &&
+		header(req.Header, "Origin") != "" &&
+		header(req.Header, "Access-Control-Request-Method") != ""
+}
+
+// RequestedMethod - the method of the actual request a preflight request is made for
+func RequestedMethod(req *triggers.HttpRequest) string {
+	return strings.ToUpper(header(req.Header, "Access-Control-Request-Method"))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allowedOrigin - the value of the Access-Control-Allow-Origin header for the origin, empty if it isn't allowed
+func (c *Config) allowedOrigin(origin string) string {
+	if origin == "" {
+		return ""
+	}
+
+	if contains(c.AllowOrigins, "*") {
+		// credentialed requests may not use a wildcard origin
+		if c.AllowCredentials {
+			return origin
+		}
+		return "*"
+	}
+
+	if contains(c.AllowOrigins, origin) {
+		return origin
+	}
+
+	return ""
+}
+
+func (c *Config) setOriginHeaders(h *fasthttp.ResponseHeader, allowedOrigin string) {
+	h.Set("Access-Control-Allow-Origin", allowedOrigin)
+	if allowedOrigin != "*" {
+		h.Add("Vary", "Origin")
+	}
+
+	if c.AllowCredentials {
+		h.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+// Preflight - build the response to a preflight request for a route accepting the given methods
+// CORS headers are omitted when the origin, method or headers aren't allowed, causing the browser to reject the request
+func (c *Config) Preflight(req *triggers.HttpRequest, routeMethods []string) *triggers.HttpResponse {
+	h := &fasthttp.ResponseHeader{}
+	resp := &triggers.HttpResponse{
+		Header:     h,
+		StatusCode: http.StatusNoContent,
+	}
+
+	allowedOrigin := c.allowedOrigin(header(req.Header, "Origin"))
+	if allowedOrigin == "" {
+		return resp
+	}
+
+	methods := c.AllowMethods
+	if len(methods) == 0 {
+		methods = routeMethods
+	}
+
+	if !contains(methods, RequestedMethod(req)) {
+		return resp
+	}
+
+	requestedHeaders := header(req.Header, "Access-Control-Request-Headers")
+	allowedHeaders := ""
+	if contains(c.AllowHeaders, "*") {
+		allowedHeaders = "*"
+		// a wildcard is treated literally for credentialed requests, so the requested headers are echoed instead
+		if c.AllowCredentials {
+			allowedHeaders = requestedHeaders
+		}
+	} else {
+		for _, rh := range strings.Split(requestedHeaders, ",") {
+			if rh = strings.TrimSpace(rh); rh != "" && !contains(c.AllowHeaders, rh) {
+				return resp
+			}
+		}
+		allowedHeaders = strings.Join(c.AllowHeaders, ", ")
+	}
+
+	c.setOriginHeaders(h, allowedOrigin)
+	h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+
+	if allowedHeaders != "" {
+		h.Set("Access-Control-Allow-Headers", allowedHeaders)
+	}
+
+	if c.MaxAge > 0 {
+		h.Set("Access-Control-Max-Age", strconv.Itoa(c.MaxAge))
+	}
+
+	return resp
+}
+
+// ApplyHeaders - add CORS headers to the response of a cross-origin request, if its origin is allowed
+func (c *Config) ApplyHeaders(req *triggers.HttpRequest, h *fasthttp.ResponseHeader) {
+	allowedOrigin := c.allowedOrigin(header(req.Header, "Origin"))
+	if allowedOrigin == "" {
+		return
+	}
+
+	c.setOriginHeaders(h, allowedOrigin)
+
+	if len(c.ExposeHeaders) > 0 {
+		h.Set("Access-Control-Expose-Headers", strings.Join(c.ExposeHeaders, ", "))
+	}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		apis: make(map[string]*Config),
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cors
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/valyala/fasthttp"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+func preflight(origin string, method string, headers string) *triggers.HttpRequest {
+	h := map[string][]string{
+		"Origin":                        {origin},
+		"Access-Control-Request-Method": {method},
+	}
+
+	if headers != "" {
+		h["Access-Control-Request-Headers"] = []string{headers}
+	}
+
+	return &triggers.HttpRequest{
+		Method: "OPTIONS",
+		Path:   "/test",
+		Header: h,
+	}
+}
+
+var _ = Describe("Cors", func() {
+	Context("IsPreflight", func() {
+		It("should identify preflight requests", func() {
+			Expect(IsPreflight(preflight("https://example.com", "GET", ""))).To(BeTrue())
+		})
+
+		It("should not treat plain OPTIONS requests as preflight requests", func() {
+			Expect(IsPreflight(&triggers.HttpRequest{
+				Method: "OPTIONS",
+				Header: map[string][]string{"Origin": {"https://example.com"}},
+			})).To(BeFalse())
+		})
+	})
+
+	Context("Preflight", func() {
+		config := &Config{
+			AllowOrigins: []string{"https://example.com"},
+			AllowHeaders: []string{"Content-Type", "Authorization"},
+			MaxAge:       600,
+		}
+
+		When("the origin, method and headers are allowed", func() {
+			It("should return the CORS headers", func() {
+				resp := config.Preflight(preflight("https://example.com", "POST", "content-type"), []string{"GET", "POST"})
+
+				Expect(resp.StatusCode).To(Equal(204))
+				Expect(string(resp.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+				Expect(string(resp.Header.Peek("Access-Control-Allow-Methods"))).To(Equal("GET, POST"))
+				Expect(string(resp.Header.Peek("Access-Control-Allow-Headers"))).To(Equal("Content-Type, Authorization"))
+				Expect(string(resp.Header.Peek("Access-Control-Max-Age"))).To(Equal("600"))
+				Expect(string(resp.Header.Peek("Vary"))).To(Equal("Origin"))
+			})
+		})
+
+		When("the origin is not allowed", func() {
+			It("should omit the CORS headers", func() {
+				resp := config.Preflight(preflight("https://other.com", "GET", ""), []string{"GET"})
+
+				Expect(resp.Header.Peek("Access-Control-Allow-Origin")).To(BeEmpty())
+			})
+		})
+
+		When("the method is not allowed", func() {
+			It("should omit the CORS headers", func() {
+				resp := config.Preflight(preflight("https://example.com", "DELETE", ""), []string{"GET"})
+
+				Expect(resp.Header.Peek("Access-Control-Allow-Origin")).To(BeEmpty())
+			})
+		})
+
+		When("a requested header is not allowed", func() {
+			It("should omit the CORS headers", func() {
+				resp := config.Preflight(preflight("https://example.com", "GET", "X-Custom"), []string{"GET"})
+
+				Expect(resp.Header.Peek("Access-Control-Allow-Origin")).To(BeEmpty())
+			})
+		})
+
+		When("any origin is allowed with credentials", func() {
+			It("should echo the request origin", func() {
+				c := &Config{AllowOrigins: []string{"*"}, AllowCredentials: true}
+
+				resp := c.Preflight(preflight("https://example.com", "GET", ""), []string{"GET"})
+
+				Expect(string(resp.Header.Peek("Access-Control-Allow-Origin"))).To(Equal("https://example.com"))
+				Expect(string(resp.Header.Peek("Access-Control-Allow-Credentials"))).To(Equal("true"))
+			})
+		})
+	})
+
+	Context("ApplyHeaders", func() {
+		It("should add CORS headers to responses for allowed origins", func() {
+			c := &Config{AllowOrigins: []string{"*"}, ExposeHeaders: []string{"X-Request-Id"}}
+			h := &fasthttp.ResponseHeader{}
+
+			c.ApplyHeaders(&triggers.HttpRequest{
+				Method: "GET",
+				Header: map[string][]string{"Origin": {"https://example.com"}},
+			}, h)
+
+			Expect(string(h.Peek("Access-Control-Allow-Origin"))).To(Equal("*"))
+			Expect(string(h.Peek("Access-Control-Expose-Headers"))).To(Equal("X-Request-Id"))
+		})
+	})
+})
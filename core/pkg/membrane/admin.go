@@ -0,0 +1,58 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membrane
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/nitrictech/nitric/core/pkg/metrics"
+)
+
+// adminHandler - Serves endpoints for probing the health, readiness and metrics of the membrane
+func (s *Membrane) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+	mux.Handle("/metrics", metrics.Handler())
+
+	return mux
+}
+
+// healthz - The membrane is healthy as long as its child process is alive
+func (s *Membrane) healthz(w http.ResponseWriter, r *http.Request) {
+	if !s.processManager.UserProcessRunning() {
+		http.Error(w, "child process is not running", http.StatusServiceUnavailable)
+		return
+	}
+
+	_, _ = w.Write([]byte("ok"))
+}
+
+// readyz - The membrane is ready once the minimum number of workers are available, until it begins shutting down
+func (s *Membrane) readyz(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&s.ready) == 0 {
+		http.Error(w, "waiting for minimum workers", http.StatusServiceUnavailable)
+		return
+	}
+
+	if !s.processManager.UserProcessRunning() {
+		http.Error(w, "child process is not running", http.StatusServiceUnavailable)
+		return
+	}
+
+	_, _ = w.Write([]byte("ok"))
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membrane
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	deploy "github.com/nitrictech/nitric/core/pkg/api/nitric/deploy/v1"
+	"github.com/nitrictech/nitric/core/pkg/collector"
+	"github.com/nitrictech/nitric/core/pkg/utils"
+)
+
+// collectOptionsFromEnv - configures the spec written in COLLECT mode, options that are already set are kept
+func collectOptionsFromEnv(options *MembraneOptions) error {
+	if options.SpecOutput == "" {
+		options.SpecOutput = utils.GetEnv("SPEC_OUTPUT", "spec.json")
+	}
+
+	if options.ExecutionUnitName == "" {
+		options.ExecutionUnitName = utils.GetEnv("EXECUTION_UNIT_NAME", "")
+	}
+
+	if options.ExecutionUnitName == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		options.ExecutionUnitName = filepath.Base(wd)
+	}
+
+	if options.ExecutionUnitImage == "" {
+		options.ExecutionUnitImage = utils.GetEnv("EXECUTION_UNIT_IMAGE", "")
+	}
+
+	if options.CollectIdleMilliseconds < 1 {
+		idleEnv := utils.GetEnv("COLLECT_IDLE_MS", "1000")
+		idle, err := strconv.Atoi(idleEnv)
+		if err != nil || idle < 1 {
+			return fmt.Errorf("invalid COLLECT_IDLE_MS env var, expected positive integer value, got %v", idleEnv)
+		}
+		options.CollectIdleMilliseconds = idle
+	}
+
+	return nil
+}
+
+// executionUnit - the execution unit running the child process in the collected spec
+func (s *Membrane) executionUnit() (*deploy.ExecutionUnit, error) {
+	if s.unitImage != "" {
+		return &deploy.ExecutionUnit{
+			Source: &deploy.ExecutionUnit_Image{
+				Image: &deploy.ImageSource{Uri: s.unitImage},
+			},
+		}, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	return &deploy.ExecutionUnit{
+		Source: &deploy.ExecutionUnit_Directory{
+			Directory: &deploy.DirectorySource{Path: wd},
+		},
+	}, nil
+}
+
+// collect - waits for the child process to finish declaring resources and registering workers, then writes its spec.
+// Collection finishes once nothing has been recorded for the idle period, or when the child process exits.
+// If nothing is recorded before the child timeout the spec is written with the execution unit alone.
+func (s *Membrane) collect() error {
+	started := time.Now()
+	childTimeout := time.Duration(s.childTimeoutSeconds) * time.Second
+
+	processErrchan := make(chan error, 1)
+	go func() {
+		processErrchan <- s.processManager.Monitor()
+	}()
+
+	ticker := time.NewTicker(s.collectIdle / 4)
+	defer ticker.Stop()
+
+	s.log("Collecting resources")
+
+collecting:
+	for {
+		select {
+		case processErr := <-processErrchan:
+			if processErr != nil {
+				return fmt.Errorf("child process exited before collection completed: %w", processErr)
+			}
+			break collecting
+		case <-ticker.C:
+			updated := s.collector.Updated()
+			if updated.IsZero() && time.Since(started) >= childTimeout {
+				break collecting
+			}
+
+			if !updated.IsZero() && time.Since(updated) >= s.collectIdle {
+				break collecting
+			}
+		}
+	}
+
+	eu, err := s.executionUnit()
+	if err != nil {
+		return err
+	}
+
+	spec, err := s.collector.Spec(s.unitName, eu)
+	if err != nil {
+		return err
+	}
+
+	if err := collector.WriteSpec(s.specOutput, spec); err != nil {
+		return fmt.Errorf("could not write spec: %w", err)
+	}
+
+	s.log(fmt.Sprintf("Wrote spec with %d resources to %s", len(spec.Resources), s.specOutput))
+
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package membrane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+	"github.com/nitrictech/nitric/core/pkg/utils"
+	"github.com/nitrictech/nitric/core/pkg/worker"
+)
+
+// parseEventPaths - parses a mapping of topics and schedules to child paths, e.g. "orders=/hooks/orders,nightly-report=/cron/report"
+func parseEventPaths(mapping string) (map[string]string, error) {
+	paths := map[string]string{}
+
+	for _, entry := range strings.Split(mapping, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		topic, path, ok := strings.Cut(entry, "=")
+		topic = strings.TrimSpace(topic)
+		path = strings.TrimSpace(path)
+		if !ok || topic == "" || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid EVENT_PATHS entry %q, expected topic=/path", entry)
+		}
+
+		paths[topic] = path
+	}
+
+	return paths, nil
+}
+
+// deadLetterToTopic - publishes events the child process failed to handle to the given topic
+func deadLetterToTopic(plugin events.EventService, topic string) worker.DeadLetterHandler {
+	return func(ctx context.Context, trigger *triggers.Event, cause error) error {
+		payload := map[string]interface{}{}
+		if err := json.Unmarshal(trigger.Payload, &payload); err != nil {
+			// Retain payloads that aren't JSON objects as is
+			payload = map[string]interface{}{"data": string(trigger.Payload)}
+		}
+
+		return plugin.Publish(ctx, topic, 0, &events.NitricEvent{
+			ID:          trigger.ID,
+			PayloadType: fmt.Sprintf("nitric.deadletter.%s", trigger.Topic),
+			Payload: map[string]interface{}{
+				"topic":   trigger.Topic,
+				"error":   cause.Error(),
+				"payload": payload,
+			},
+		})
+	}
+}
+
+// httpWorkerOptionsFromEnv - configures event delivery to the child process in HTTP_PROXY mode
+func httpWorkerOptionsFromEnv(eventsPlugin events.EventService) ([]worker.HttpWorkerOption, error) {
+	opts := []worker.HttpWorkerOption{
+		worker.WithDefaultEventPath(utils.GetEnv("EVENT_DEFAULT_PATH", worker.DefaultEventPath)),
+	}
+
+	paths, err := parseEventPaths(utils.GetEnv("EVENT_PATHS", ""))
+	if err != nil {
+		return nil, err
+	}
+	for topic, path := range paths {
+		opts = append(opts, worker.WithEventPath(topic, path))
+	}
+
+	attemptsEnv := utils.GetEnv("EVENT_RETRY_ATTEMPTS", "1")
+	attempts, err := strconv.Atoi(attemptsEnv)
+	if err != nil || attempts < 1 {
+		return nil, fmt.Errorf("invalid EVENT_RETRY_ATTEMPTS env var, expected positive integer value, got %v", attemptsEnv)
+	}
+
+	backoffEnv := utils.GetEnv("EVENT_RETRY_BACKOFF_MS", "500")
+	backoff, err := strconv.Atoi(backoffEnv)
+	if err != nil || backoff < 0 {
+		return nil, fmt.Errorf("invalid EVENT_RETRY_BACKOFF_MS env var, expected non-negative integer value, got %v", backoffEnv)
+	}
+
+	maxBackoffEnv := utils.GetEnv("EVENT_RETRY_MAX_BACKOFF_MS", "30000")
+	maxBackoff, err := strconv.Atoi(maxBackoffEnv)
+	if err != nil || maxBackoff < 0 {
+		return nil, fmt.Errorf("invalid EVENT_RETRY_MAX_BACKOFF_MS env var, expected non-negative integer value, got %v", maxBackoffEnv)
+	}
+
+	opts = append(opts, worker.WithEventRetries(worker.RetryPolicy{
+		MaxAttempts:    attempts,
+		InitialBackoff: time.Duration(backoff) * time.Millisecond,
+		MaxBackoff:     time.Duration(maxBackoff) * time.Millisecond,
+	}))
+
+	cloudEvents, err := strconv.ParseBool(utils.GetEnv("EVENT_CLOUDEVENTS", "false"))
+	if err != nil {
+		return nil, err
+	}
+	if cloudEvents {
+		opts = append(opts, worker.WithCloudEvents())
+	}
+
+	if topic := utils.GetEnv("EVENT_DEAD_LETTER_TOPIC", ""); topic != "" {
+		if eventsPlugin == nil {
+			return nil, fmt.Errorf("EVENT_DEAD_LETTER_TOPIC requires an events plugin")
+		}
+		opts = append(opts, worker.WithDeadLetter(deadLetterToTopic(eventsPlugin, topic)))
+	}
+
+	return opts, nil
+}
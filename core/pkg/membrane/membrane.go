@@ -18,34 +18,54 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net"
+	"net/http"
+	"os"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 
 	grpc2 "github.com/nitrictech/nitric/core/pkg/adapters/grpc"
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/collector"
+	"github.com/nitrictech/nitric/core/pkg/cors"
+	"github.com/nitrictech/nitric/core/pkg/metrics"
 	"github.com/nitrictech/nitric/core/pkg/plugins/document"
 	"github.com/nitrictech/nitric/core/pkg/plugins/events"
 	"github.com/nitrictech/nitric/core/pkg/plugins/gateway"
 	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
 	"github.com/nitrictech/nitric/core/pkg/plugins/secret"
 	"github.com/nitrictech/nitric/core/pkg/plugins/storage"
+	"github.com/nitrictech/nitric/core/pkg/plugins/websocket"
 	"github.com/nitrictech/nitric/core/pkg/pm"
+	"github.com/nitrictech/nitric/core/pkg/policy"
 	"github.com/nitrictech/nitric/core/pkg/providers/common"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
+	"github.com/nitrictech/nitric/core/pkg/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/utils"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
 
 type MembraneOptions struct {
 	ServiceAddress string
+	// The address to serve health, readiness and metrics endpoints on, disabled if empty
+	AdminAddress string
 	// The address the child will be listening on
 	ChildAddress string
+	// A serialized FileDescriptorSet describing the child's gRPC services, enables JSON transcoding in GRPC_PROXY mode
+	GrpcDescriptorSet string
 	// The command that will be used to invoke the child process
 	ChildCommand []string
 	// Commands that will be started before all others
@@ -54,6 +74,17 @@ type MembraneOptions struct {
 	// The total time to wait for the child process to be available in seconds
 	ChildTimeoutSeconds int
 
+	// The time to wait for in-flight triggers to complete on shutdown in seconds
+	ShutdownGracePeriodSeconds int
+
+	// The time the child process has to exit after SIGTERM before it is killed on shutdown in seconds,
+	// defaults to what is left of the shutdown grace period if less than 1
+	ChildStopTimeoutSeconds int
+
+	// The time triggers wait for a worker at its declared maximum concurrency to have capacity in milliseconds,
+	// triggers are rejected immediately if less than 1
+	WorkerQueueTimeoutMilliseconds int
+
 	DocumentPlugin  document.DocumentService
 	EventsPlugin    events.EventService
 	StoragePlugin   storage.StorageService
@@ -61,15 +92,41 @@ type MembraneOptions struct {
 	GatewayPlugin   gateway.GatewayService
 	SecretPlugin    secret.SecretService
 	ResourcesPlugin common.ResourceService
+	// Defaults to the gateway plugin if it manages websocket connections itself
+	WebsocketPlugin websocket.WebsocketService
 
 	CreateTracerProvider func(ctx context.Context) (*sdktrace.TracerProvider, error)
+	CreateMeterProvider  func(ctx context.Context) (*sdkmetric.MeterProvider, error)
 
 	SuppressLogs            bool
 	TolerateMissingServices bool
+	// Skip validating the bearer tokens of requests to secured API routes,
+	// e.g. when tokens are already validated by the API gateway in front of the membrane
+	DisableTokenValidation bool
+	// Addresses or CIDR ranges of proxies trusted to identify clients in the X-Forwarded-For header when rate limiting,
+	// clients are identified by the address of the connection when empty
+	TrustedProxies []string
+	// Deny access to resources that have not been declared, and actions that have not been granted by declared policies,
+	// e.g. to catch missing permissions during local development before deploying
+	StrictMode bool
 
 	// The operating mode of the membrane
 	Mode *Mode
 
+	// The path the deployment spec is written to in COLLECT mode, as protobuf if it ends in .pb otherwise as JSON
+	SpecOutput string
+	// The name of the execution unit running the child process in the spec written in COLLECT mode
+	ExecutionUnitName string
+	// The image of the execution unit in the spec written in COLLECT mode,
+	// the working directory is used as the source of the execution unit when empty
+	ExecutionUnitImage string
+	// How long the child process must go without declaring resources or registering workers
+	// before its spec is written in COLLECT mode in milliseconds
+	CollectIdleMilliseconds int
+
+	// Configures event delivery to the child process in HTTP_PROXY mode, defaults to configuration from the environment
+	HttpWorkerOptions []worker.HttpWorkerOption
+
 	// Supply your own worker pool
 	Pool worker.WorkerPool
 }
@@ -80,8 +137,14 @@ type Membrane struct {
 	// proxyAddress string
 	// Address & port to bind the membrane service interfaces to
 	serviceAddress string
+	// Address & port to bind the membrane health, readiness and metrics endpoints to
+	adminAddress string
 	// The address the child will be listening on
 	childAddress string
+	// Path to the FileDescriptorSet used for JSON transcoding in GRPC_PROXY mode
+	grpcDescriptorSet string
+	// Event delivery options for the worker used in HTTP_PROXY mode
+	httpWorkerOpts []worker.HttpWorkerOption
 
 	// The URL (including protocol, the child process can be reached on)
 	childUrl string
@@ -89,17 +152,25 @@ type Membrane struct {
 	processManager       pm.ProcessManager
 	tracerProvider       *sdktrace.TracerProvider
 	createTracerProvider func(ctx context.Context) (*sdktrace.TracerProvider, error)
+	meterProvider        *sdkmetric.MeterProvider
+	createMeterProvider  func(ctx context.Context) (*sdkmetric.MeterProvider, error)
+	logExporter          *telemetry.LogExporter
 
 	childTimeoutSeconds int
 
+	shutdownGracePeriod time.Duration
+	childStopTimeout    time.Duration
+	workerQueueTimeout  time.Duration
+
 	// Configured plugins
-	documentPlugin document.DocumentService
-	eventsPlugin   events.EventService
-	storagePlugin  storage.StorageService
-	gatewayPlugin  gateway.GatewayService
-	queuePlugin    queue.QueueService
-	secretPlugin   secret.SecretService
-	resourcePlugin common.ResourceService
+	documentPlugin  document.DocumentService
+	eventsPlugin    events.EventService
+	storagePlugin   storage.StorageService
+	gatewayPlugin   gateway.GatewayService
+	queuePlugin     queue.QueueService
+	secretPlugin    secret.SecretService
+	resourcePlugin  common.ResourceService
+	websocketPlugin websocket.WebsocketService
 
 	// Tolerate if provider specific plugins aren't available for some services.
 	// Not this does not include the gateway service
@@ -108,10 +179,33 @@ type Membrane struct {
 	// Suppress println statements in the membrane server
 	suppressLogs bool
 
+	// Validates tokens of requests to secured API routes, nil if validation is disabled
+	authorizer *auth.Authorizer
+
+	// CORS configuration of declared APIs, applied by gateways that support it
+	cors *cors.Registry
+
+	// Rate limits of declared APIs and routes, enforced by gateways that support them
+	limiter *ratelimit.Limiter
+
+	// Declared resources and policies enforced by plugin servers, nil unless running in strict mode
+	enforcer *policy.Enforcer
+
+	// Records declared resources and registered workers, nil unless running in COLLECT mode
+	collector   *collector.Collector
+	specOutput  string
+	unitName    string
+	unitImage   string
+	collectIdle time.Duration
+
 	// Handler operating mode, e.g. FaaS or HTTP Proxy. Governs how incoming triggers are translated.
 	mode Mode
 
-	grpcServer *grpc.Server
+	grpcServer  *grpc.Server
+	adminServer *http.Server
+
+	// Set to non-zero once the minimum number of workers are available, until shutdown begins
+	ready int32
 
 	// Worker pool
 	pool worker.WorkerPool
@@ -124,31 +218,50 @@ func (s *Membrane) log(msg string) {
 }
 
 func (s *Membrane) createSecretServer() v1.SecretServiceServer {
-	return grpc2.NewSecretServer(s.secretPlugin)
+	return grpc2.NewSecretServer(s.enforcer.SecretService(s.secretPlugin))
 }
 
 // Create a new Nitric Document Server
 func (s *Membrane) createDocumentServer() v1.DocumentServiceServer {
-	return grpc2.NewDocumentServer(s.documentPlugin)
+	return grpc2.NewDocumentServer(s.enforcer.DocumentService(s.documentPlugin))
 }
 
 // Create a new Nitric events Server
 func (s *Membrane) createEventsServer() v1.EventServiceServer {
-	return grpc2.NewEventServiceServer(s.eventsPlugin)
+	return grpc2.NewEventServiceServer(s.enforcer.EventService(s.eventsPlugin))
 }
 
 // Create a new Nitric Topic Server
 func (s *Membrane) createTopicServer() v1.TopicServiceServer {
-	return grpc2.NewTopicServiceServer(s.eventsPlugin)
+	return grpc2.NewTopicServiceServer(s.enforcer.EventService(s.eventsPlugin))
 }
 
 // Create a new Nitric Storage Server
 func (s *Membrane) createStorageServer() v1.StorageServiceServer {
-	return grpc2.NewStorageServiceServer(s.storagePlugin)
+	return grpc2.NewStorageServiceServer(s.enforcer.StorageService(s.storagePlugin))
 }
 
 func (s *Membrane) createQueueServer() v1.QueueServiceServer {
-	return grpc2.NewQueueServiceServer(s.queuePlugin)
+	return grpc2.NewQueueServiceServer(s.enforcer.QueueService(s.queuePlugin))
+}
+
+func (s *Membrane) createWebsocketServer() v1.WebsocketServiceServer {
+	return grpc2.NewWebsocketServiceServer(s.websocketPlugin)
+}
+
+// newGrpcProxyWorker creates the worker used in GRPC_PROXY mode, enabling JSON transcoding when a descriptor set is configured
+func (s *Membrane) newGrpcProxyWorker() (worker.Worker, error) {
+	opts := []worker.GrpcProxyWorkerOption{}
+
+	if s.grpcDescriptorSet != "" {
+		files, err := worker.LoadDescriptorSet(s.grpcDescriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, worker.WithTranscoding(files))
+	}
+
+	return worker.NewGrpcProxyWorker(s.childAddress, opts...)
 }
 
 // Start the membrane
@@ -158,6 +271,8 @@ func (s *Membrane) Start() error {
 	}
 
 	var opts []grpc.ServerOption
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
 
 	if s.createTracerProvider != nil {
 		tp, err := s.createTracerProvider(context.Background())
@@ -169,18 +284,44 @@ func (s *Membrane) Start() error {
 		if tp != nil {
 			s.log(fmt.Sprintf("traceProvider connected"))
 			otel.SetTracerProvider(tp)
+			s.tracerProvider = tp
 		}
 
 		interceptorOpts := []otelgrpc.Option{
 			otelgrpc.WithPropagators(propagation.TraceContext{}),
 		}
 
-		opts = append(opts,
-			grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor(interceptorOpts...)),
-			grpc.StreamInterceptor(otelgrpc.StreamServerInterceptor(interceptorOpts...)),
-		)
+		unaryInterceptors = append(unaryInterceptors, otelgrpc.UnaryServerInterceptor(interceptorOpts...))
+		streamInterceptors = append(streamInterceptors, otelgrpc.StreamServerInterceptor(interceptorOpts...))
+	}
+
+	if s.createMeterProvider != nil {
+		mp, err := s.createMeterProvider(context.Background())
+		if err != nil {
+			s.log(fmt.Sprintf("meterProvider %v", err))
+			return err
+		}
+
+		if mp != nil {
+			s.log(fmt.Sprintf("meterProvider connected"))
+			global.SetMeterProvider(mp)
+			s.meterProvider = mp
+		}
+
+		unaryInterceptors = append(unaryInterceptors, telemetry.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, telemetry.StreamServerInterceptor())
 	}
 
+	if s.adminAddress != "" {
+		unaryInterceptors = append(unaryInterceptors, metrics.UnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, metrics.StreamServerInterceptor())
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+
 	s.grpcServer = grpc.NewServer(opts...)
 
 	// Load & Register the GRPC service plugins
@@ -202,12 +343,22 @@ func (s *Membrane) Start() error {
 	secretServer := s.createSecretServer()
 	v1.RegisterSecretServiceServer(s.grpcServer, secretServer)
 
-	resourceServer := grpc2.NewResourcesServiceServer(grpc2.WithResourcePlugin(s.resourcePlugin))
+	websocketServer := s.createWebsocketServer()
+	v1.RegisterWebsocketServiceServer(s.grpcServer, websocketServer)
+
+	resourceServer := grpc2.NewResourcesServiceServer(
+		grpc2.WithResourcePlugin(s.resourcePlugin),
+		grpc2.WithApiAuthorizer(s.authorizer),
+		grpc2.WithApiCors(s.cors),
+		grpc2.WithApiRateLimiter(s.limiter),
+		grpc2.WithPolicyEnforcer(s.enforcer),
+		grpc2.WithResourceCollector(s.collector),
+	)
 	v1.RegisterResourceServiceServer(s.grpcServer, resourceServer)
 
 	// FaaS server MUST start before the child process
-	if s.mode == Mode_Faas {
-		faasServer := grpc2.NewFaasServer(s.pool)
+	if s.mode == Mode_Faas || s.mode == Mode_Collect {
+		faasServer := grpc2.NewFaasServer(s.pool, grpc2.WithAuthorizer(s.authorizer), grpc2.WithRateLimiter(s.limiter), grpc2.WithQueueTimeout(s.workerQueueTimeout), grpc2.WithCollector(s.collector))
 		v1.RegisterFaasServiceServer(s.grpcServer, faasServer)
 	}
 	lis, err := net.Listen("tcp", s.serviceAddress)
@@ -226,19 +377,42 @@ func (s *Membrane) Start() error {
 		}
 	})()
 
+	if s.adminAddress != "" {
+		adminLis, err := net.Listen("tcp", s.adminAddress)
+		if err != nil {
+			return fmt.Errorf("could not listen on configured admin address: %w", err)
+		}
+
+		s.adminServer = &http.Server{Handler: s.adminHandler()}
+
+		go (func() {
+			s.log(fmt.Sprintf("Admin endpoints listening on: %s", s.adminAddress))
+			err := s.adminServer.Serve(adminLis)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.log(fmt.Sprintf("admin serve %v", err))
+			}
+		})()
+	}
+
 	// Start our child process
 	// This will block until our child process is ready to accept incoming connections
 	if err := s.processManager.StartUserProcess(); err != nil {
 		return err
 	}
 
+	if s.mode == Mode_Collect {
+		return s.collect()
+	}
+
 	// If we aren't in FaaS mode
 	// We need to manually register our worker for now
 	if s.mode != Mode_Faas {
 		var wrkr worker.Worker
 		var workerErr error
 		if s.mode == Mode_HttpProxy {
-			wrkr, workerErr = worker.NewHttpWorker(s.childAddress)
+			wrkr, workerErr = worker.NewHttpWorker(s.childAddress, s.httpWorkerOpts...)
+		} else if s.mode == Mode_GrpcProxy {
+			wrkr, workerErr = s.newGrpcProxyWorker()
 		}
 
 		if workerErr == nil {
@@ -258,6 +432,8 @@ func (s *Membrane) Start() error {
 		return err
 	}
 
+	atomic.StoreInt32(&s.ready, 1)
+
 	gatewayErrchan := make(chan error)
 	poolErrchan := make(chan error)
 
@@ -298,13 +474,92 @@ func (s *Membrane) Start() error {
 	return exitErr
 }
 
+// The time allowed for each shutdown step after draining, so a slow step can't delay the others,
+// and the least time the child process is given to exit
+const shutdownStepTimeout = time.Second
+
+// childStopTimeoutSince - returns the time the child process has to exit after SIGTERM,
+// using what is left of the grace period when no timeout is configured
+func (s *Membrane) childStopTimeoutSince(shutdownStart time.Time) time.Duration {
+	if s.childStopTimeout > 0 {
+		return s.childStopTimeout
+	}
+
+	if remaining := s.shutdownGracePeriod - time.Since(shutdownStart); remaining > shutdownStepTimeout {
+		return remaining
+	}
+
+	return shutdownStepTimeout
+}
+
+// Stop the membrane, draining in-flight triggers before stopping the child process
 func (s *Membrane) Stop() {
+	shutdownStart := time.Now()
+
+	// Report as not ready so no new traffic is routed to this instance
+	atomic.StoreInt32(&s.ready, 0)
+
+	// Stop handing out new triggers and wait for in-flight triggers to complete
+	s.log("Draining workers")
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), s.shutdownGracePeriod)
+	if err := s.pool.Drain(drainCtx); err != nil {
+		s.log(fmt.Sprintf("error draining workers: %v", err))
+	}
+	cancelDrain()
+
+	// Some gateways (e.g. lambda) may block on stop, so don't wait beyond the grace period
+	gatewayStopped := make(chan error, 1)
+	go func() {
+		if s.gatewayPlugin == nil {
+			// No gateway is started in COLLECT mode
+			gatewayStopped <- nil
+			return
+		}
+		gatewayStopped <- s.gatewayPlugin.Stop()
+	}()
+
+	select {
+	case err := <-gatewayStopped:
+		if err != nil {
+			s.log(fmt.Sprintf("error stopping gateway: %v", err))
+		}
+	case <-time.After(shutdownStepTimeout):
+		s.log("timed out waiting for gateway to stop")
+	}
+
+	// Flush telemetry before the collector pre-process is stopped
+	telemetryCtx, cancelTelemetry := context.WithTimeout(context.Background(), shutdownStepTimeout)
 	if s.tracerProvider != nil {
-		_ = s.tracerProvider.Shutdown(context.Background())
+		_ = s.tracerProvider.ForceFlush(telemetryCtx)
+		_ = s.tracerProvider.Shutdown(telemetryCtx)
+	}
+
+	if s.meterProvider != nil {
+		_ = s.meterProvider.ForceFlush(telemetryCtx)
+		_ = s.meterProvider.Shutdown(telemetryCtx)
+	}
+	cancelTelemetry()
+
+	processCtx, cancelProcess := context.WithTimeout(context.Background(), s.childStopTimeoutSince(shutdownStart))
+	s.processManager.StopAll(processCtx)
+	cancelProcess()
+
+	// Stopped after the child process so its final output is exported
+	if s.logExporter != nil {
+		log.SetOutput(os.Stderr)
+
+		logsCtx, cancelLogs := context.WithTimeout(context.Background(), shutdownStepTimeout)
+		_ = s.logExporter.Shutdown(logsCtx)
+		cancelLogs()
+	}
+
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+
+	if s.adminServer != nil {
+		_ = s.adminServer.Close()
 	}
-	_ = s.gatewayPlugin.Stop()
-	s.grpcServer.Stop()
-	s.processManager.StopAll()
 }
 
 // Create a new Membrane server
@@ -314,10 +569,24 @@ func New(options *MembraneOptions) (*Membrane, error) {
 		options.ServiceAddress = utils.GetEnv("SERVICE_ADDRESS", "127.0.0.1:50051")
 	}
 
+	if options.AdminAddress == "" {
+		options.AdminAddress = utils.GetEnv("ADMIN_ADDRESS", "")
+	}
+
 	if options.ChildAddress == "" {
 		options.ChildAddress = utils.GetEnv("CHILD_ADDRESS", "127.0.0.1:8080")
 	}
 
+	if options.GrpcDescriptorSet == "" {
+		options.GrpcDescriptorSet = utils.GetEnv("GRPC_DESCRIPTOR_SET", "")
+	}
+
+	if options.ResourcesPlugin == nil {
+		if rs, ok := options.GatewayPlugin.(common.ResourceService); ok {
+			options.ResourcesPlugin = rs
+		}
+	}
+
 	if !options.TolerateMissingServices {
 		tolerateMissing, err := strconv.ParseBool(utils.GetEnv("TOLERATE_MISSING_SERVICES", "false"))
 		if err != nil {
@@ -326,6 +595,28 @@ func New(options *MembraneOptions) (*Membrane, error) {
 		options.TolerateMissingServices = tolerateMissing
 	}
 
+	if !options.DisableTokenValidation {
+		disableValidation, err := strconv.ParseBool(utils.GetEnv("DISABLE_TOKEN_VALIDATION", "false"))
+		if err != nil {
+			return nil, err
+		}
+		options.DisableTokenValidation = disableValidation
+	}
+
+	if options.TrustedProxies == nil {
+		if proxies := utils.GetEnv("TRUSTED_PROXIES", ""); proxies != "" {
+			options.TrustedProxies = strings.Split(proxies, ",")
+		}
+	}
+
+	if !options.StrictMode {
+		strictMode, err := strconv.ParseBool(utils.GetEnv("STRICT_MODE", "false"))
+		if err != nil {
+			return nil, err
+		}
+		options.StrictMode = strictMode
+	}
+
 	if options.Mode == nil {
 		mode, err := ModeFromString(utils.GetEnv("MEMBRANE_MODE", "FAAS"))
 		if err != nil {
@@ -334,14 +625,63 @@ func New(options *MembraneOptions) (*Membrane, error) {
 		options.Mode = &mode
 	}
 
+	if *options.Mode == Mode_Collect {
+		if err := collectOptionsFromEnv(options); err != nil {
+			return nil, err
+		}
+	}
+
+	if *options.Mode == Mode_HttpProxy && options.HttpWorkerOptions == nil {
+		httpWorkerOpts, err := httpWorkerOptionsFromEnv(options.EventsPlugin)
+		if err != nil {
+			return nil, err
+		}
+		options.HttpWorkerOptions = httpWorkerOpts
+	}
+
 	if options.ChildTimeoutSeconds < 1 {
 		options.ChildTimeoutSeconds = 10
 	}
 
-	if options.GatewayPlugin == nil {
+	if options.ShutdownGracePeriodSeconds < 1 {
+		// Default to leave time for the remaining shutdown steps within the 10 second termination window given by most serverless platforms (e.g. Cloud Run)
+		gracePeriodEnv := utils.GetEnv("SHUTDOWN_GRACE_PERIOD", "5")
+		gracePeriod, err := strconv.Atoi(gracePeriodEnv)
+		if err != nil || gracePeriod < 1 {
+			return nil, fmt.Errorf("invalid SHUTDOWN_GRACE_PERIOD env var, expected positive integer value, got %v", gracePeriodEnv)
+		}
+		options.ShutdownGracePeriodSeconds = gracePeriod
+	}
+
+	if options.ChildStopTimeoutSeconds < 1 {
+		stopTimeoutEnv := utils.GetEnv("CHILD_STOP_TIMEOUT", "0")
+		stopTimeout, err := strconv.Atoi(stopTimeoutEnv)
+		if err != nil || stopTimeout < 0 {
+			return nil, fmt.Errorf("invalid CHILD_STOP_TIMEOUT env var, expected non-negative integer value, got %v", stopTimeoutEnv)
+		}
+		options.ChildStopTimeoutSeconds = stopTimeout
+	}
+
+	if options.WorkerQueueTimeoutMilliseconds < 1 {
+		queueTimeoutEnv := utils.GetEnv("WORKER_QUEUE_TIMEOUT_MS", "0")
+		queueTimeout, err := strconv.Atoi(queueTimeoutEnv)
+		if err != nil || queueTimeout < 0 {
+			return nil, fmt.Errorf("invalid WORKER_QUEUE_TIMEOUT_MS env var, expected non-negative integer value, got %v", queueTimeoutEnv)
+		}
+		options.WorkerQueueTimeoutMilliseconds = queueTimeout
+	}
+
+	// Triggers are not handled while collecting, so no gateway is needed
+	if options.GatewayPlugin == nil && *options.Mode != Mode_Collect {
 		return nil, errors.New("missing gateway plugin, Gateway plugin must not be nil")
 	}
 
+	if options.WebsocketPlugin == nil {
+		if ws, ok := options.GatewayPlugin.(websocket.WebsocketService); ok {
+			options.WebsocketPlugin = ws
+		}
+	}
+
 	if !options.TolerateMissingServices {
 		if options.EventsPlugin == nil || options.StoragePlugin == nil || options.DocumentPlugin == nil || options.QueuePlugin == nil {
 			return nil, errors.New("missing membrane plugins, if you meant to load with missing plugins set options.TolerateMissingServices to true")
@@ -365,38 +705,120 @@ func New(options *MembraneOptions) (*Membrane, error) {
 		options.Pool = worker.NewProcessPool(&worker.ProcessPoolOptions{
 			MinWorkers: minWorkers,
 			MaxWorkers: maxWorkers,
+			// Saturated workers wait for capacity up to the queue timeout themselves
+			QueueWhenSaturated: options.WorkerQueueTimeoutMilliseconds > 0,
 		})
 	}
 
 	bin := utils.GetEnv("OTELCOL_BIN", "/usr/bin/otelcol-contrib")
 	config := utils.GetEnv("OTELCOL_CONFIG", "/etc/otelcol/config.yaml")
+	collectorAvailable := fileExists(bin) && fileExists(config)
 	createTracerProvider := options.CreateTracerProvider
+	createMeterProvider := options.CreateMeterProvider
 
-	if createTracerProvider != nil && fileExists(bin) && fileExists(config) {
-		log.Default().Println("Tracing is enabled")
-
+	// Telemetry is exported through the local collector when available, otherwise directly to a configured OTLP endpoint
+	if collectorAvailable && (createTracerProvider != nil || createMeterProvider != nil) {
 		options.PreCommands = [][]string{
 			{
 				bin, "--config", config,
 			},
 		}
+	}
+
+	if createTracerProvider != nil && (collectorAvailable || telemetry.EndpointConfigured(telemetry.Signal_Traces)) {
+		log.Default().Println("Tracing is enabled")
+	} else {
+		log.Default().Printf("Tracing is disabled %v %v %v", createTracerProvider != nil, fileExists(bin), fileExists(config))
+		createTracerProvider = nil
+	}
 
+	if createMeterProvider != nil && (collectorAvailable || telemetry.EndpointConfigured(telemetry.Signal_Metrics)) {
+		log.Default().Println("Metrics export is enabled")
+	} else {
+		createMeterProvider = nil
+	}
+
+	if createTracerProvider != nil || createMeterProvider != nil {
 		options.Pool = &worker.InstrumentedWorkerPool{
 			WorkerPool: options.Pool,
 			Wrapper:    worker.InstrumentedWorkerFn,
 		}
-	} else {
-		log.Default().Printf("Tracing is disabled %v %v %v", createTracerProvider != nil, fileExists(bin), fileExists(config))
-		createTracerProvider = nil
+	}
+
+	var authorizer *auth.Authorizer
+	if !options.DisableTokenValidation {
+		authorizer = auth.NewAuthorizer()
+	}
+
+	var enforcer *policy.Enforcer
+	if options.StrictMode {
+		log.Default().Println("Strict mode is enabled, access to undeclared resources will be denied")
+		enforcer = policy.NewEnforcer()
+	}
+
+	pmOpts := []pm.ProcessManagerOption{}
+
+	var logExporter *telemetry.LogExporter
+	if telemetry.EndpointConfigured(telemetry.Signal_Logs) {
+		exporter, err := telemetry.NewLogExporter(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Default().Println("Log export is enabled")
+
+		logExporter = exporter
+		log.SetOutput(io.MultiWriter(os.Stderr, logExporter.Writer("membrane")))
+
+		pmOpts = append(pmOpts, pm.WithUserOutput(
+			io.MultiWriter(os.Stdout, logExporter.Writer("stdout")),
+			io.MultiWriter(os.Stderr, logExporter.Writer("stderr")),
+		))
+	}
+
+	if options.AdminAddress != "" {
+		// Wrapped last so metrics are recorded against the underlying worker types
+		options.Pool = &worker.InstrumentedWorkerPool{
+			WorkerPool: options.Pool,
+			Wrapper:    worker.MeteredWorkerFn,
+		}
+	}
+
+	var specCollector *collector.Collector
+	if *options.Mode == Mode_Collect {
+		specCollector = collector.NewCollector()
+	}
+
+	trustedProxies, err := ratelimit.ParseTrustedProxies(options.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	limiter := ratelimit.NewLimiter(ratelimit.WithAuthorizer(authorizer), ratelimit.WithTrustedProxies(trustedProxies))
+	if rl, ok := options.GatewayPlugin.(gateway.RateLimitedGatewayService); ok {
+		rl.SetRateLimiter(limiter)
+	}
+
+	corsRegistry := cors.NewRegistry()
+	if cg, ok := options.GatewayPlugin.(gateway.CorsGatewayService); ok {
+		cg.SetCors(corsRegistry)
 	}
 
 	return &Membrane{
 		serviceAddress:          options.ServiceAddress,
+		adminAddress:            options.AdminAddress,
 		childAddress:            options.ChildAddress,
+		grpcDescriptorSet:       options.GrpcDescriptorSet,
+		httpWorkerOpts:          options.HttpWorkerOptions,
 		childUrl:                fmt.Sprintf("http://%s", options.ChildAddress),
-		processManager:          pm.NewProcessManager(options.ChildCommand, options.PreCommands),
+		processManager:          pm.NewProcessManager(options.ChildCommand, options.PreCommands, pmOpts...),
 		createTracerProvider:    createTracerProvider,
+		createMeterProvider:     createMeterProvider,
+		logExporter:             logExporter,
 		childTimeoutSeconds:     options.ChildTimeoutSeconds,
+		shutdownGracePeriod:     time.Duration(options.ShutdownGracePeriodSeconds) * time.Second,
+		childStopTimeout:        time.Duration(options.ChildStopTimeoutSeconds) * time.Second,
+		workerQueueTimeout:      time.Duration(options.WorkerQueueTimeoutMilliseconds) * time.Millisecond,
 		documentPlugin:          options.DocumentPlugin,
 		eventsPlugin:            options.EventsPlugin,
 		storagePlugin:           options.StoragePlugin,
@@ -404,7 +826,17 @@ func New(options *MembraneOptions) (*Membrane, error) {
 		gatewayPlugin:           options.GatewayPlugin,
 		secretPlugin:            options.SecretPlugin,
 		resourcePlugin:          options.ResourcesPlugin,
+		websocketPlugin:         options.WebsocketPlugin,
 		suppressLogs:            options.SuppressLogs,
+		authorizer:              authorizer,
+		cors:                    corsRegistry,
+		limiter:                 limiter,
+		enforcer:                enforcer,
+		collector:               specCollector,
+		specOutput:              options.SpecOutput,
+		unitName:                options.ExecutionUnitName,
+		unitImage:               options.ExecutionUnitImage,
+		collectIdle:             time.Duration(options.CollectIdleMilliseconds) * time.Millisecond,
 		tolerateMissingServices: options.TolerateMissingServices,
 		mode:                    *options.Mode,
 		pool:                    options.Pool,
@@ -20,6 +20,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -140,6 +141,28 @@ var _ = Describe("Membrane", func() {
 			})
 		})
 
+		When("The child stop timeout env var is invalid", func() {
+			BeforeEach(func() {
+				os.Setenv("CHILD_STOP_TIMEOUT", "-1")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CHILD_STOP_TIMEOUT")
+			})
+
+			It("Should fail to create", func() {
+				m, err := membrane.New(&membrane.MembraneOptions{
+					SuppressLogs:            true,
+					GatewayPlugin:           &MockGateway{},
+					TolerateMissingServices: true,
+					Pool:                    pool,
+				})
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("CHILD_STOP_TIMEOUT"))
+				Expect(m).To(BeNil())
+			})
+		})
+
 		Context("Tolerate Missing Services is disabled", func() {
 			When("Only the gateway plugin is present", func() {
 				mockGateway := &MockGateway{}
@@ -292,4 +315,45 @@ var _ = Describe("Membrane", func() {
 			})
 		})
 	})
+
+	Context("Collecting a spec", func() {
+		When("The child process exits after starting", func() {
+			var mb *membrane.Membrane
+			var specOutput string
+
+			BeforeEach(func() {
+				os.Args = []string{}
+				dir, err := os.MkdirTemp("", "membrane-collect")
+				Expect(err).ShouldNot(HaveOccurred())
+				specOutput = filepath.Join(dir, "spec.json")
+				mode := membrane.Mode_Collect
+
+				mb, err = membrane.New(&membrane.MembraneOptions{
+					Mode:                    &mode,
+					ChildCommand:            []string{"true"},
+					ServiceAddress:          fmt.Sprintf(":%d", 9006),
+					SpecOutput:              specOutput,
+					ExecutionUnitName:       "app",
+					ExecutionUnitImage:      "example/app:latest",
+					TolerateMissingServices: true,
+					SuppressLogs:            true,
+					Pool:                    pool,
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				mb.Stop()
+				os.RemoveAll(filepath.Dir(specOutput))
+			})
+
+			It("Should write the spec without a gateway plugin", func() {
+				Expect(mb.Start()).To(Succeed())
+
+				b, err := os.ReadFile(specOutput)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(b)).To(ContainSubstring("example/app:latest"))
+			})
+		})
+	})
 })
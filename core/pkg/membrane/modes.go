@@ -27,9 +27,13 @@ const (
 	Mode_Faas Mode = iota
 	// Mode_HttpProxy is designed for integration of monoliths into a nitric application
 	Mode_HttpProxy
+	// Mode_GrpcProxy forwards gRPC, gRPC-Web and (optionally) transcoded JSON requests to a gRPC server child process
+	Mode_GrpcProxy
+	// Mode_Collect records the resources declared and workers registered by the child process, writes them as a deployment spec and exits
+	Mode_Collect
 )
 
-var modes = [...]string{"FAAS", "HTTP_PROXY"}
+var modes = [...]string{"FAAS", "HTTP_PROXY", "GRPC_PROXY", "COLLECT"}
 
 func (m Mode) String() string {
 	return modes[m]
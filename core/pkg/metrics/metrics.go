@@ -0,0 +1,107 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "nitric"
+
+var (
+	// Registry - The registry all membrane metrics are registered with
+	Registry = prometheus.NewRegistry()
+
+	// HttpRequests - Count of http triggers handled by workers
+	HttpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "http_requests_total",
+		Help:      "Total number of http triggers handled by workers.",
+	}, []string{"api", "route", "method", "status"})
+
+	// HttpRequestDuration - Latency of http triggers handled by workers
+	HttpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of http triggers handled by workers.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"api", "route", "method"})
+
+	// Events - Count of event triggers handled by workers
+	Events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "events_total",
+		Help:      "Total number of event triggers handled by workers.",
+	}, []string{"topic", "status"})
+
+	// EventDuration - Latency of event triggers handled by workers
+	EventDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "event_duration_seconds",
+		Help:      "Latency of event triggers handled by workers.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	// TriggersInFlight - Number of triggers currently being handled by workers
+	TriggersInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "worker",
+		Name:      "triggers_in_flight",
+		Help:      "Number of triggers currently being handled by workers.",
+	}, []string{"type"})
+
+	// RateLimitedRequests - Count of http requests checked against rate limits
+	RateLimitedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ratelimit",
+		Name:      "requests_total",
+		Help:      "Total number of http requests checked against rate limits.",
+	}, []string{"api", "route", "outcome"})
+
+	// PluginCalls - Count of calls made to the membrane's gRPC services (and the plugins behind them)
+	PluginCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "plugin",
+		Name:      "calls_total",
+		Help:      "Total number of calls made to membrane services.",
+	}, []string{"service", "method", "code"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HttpRequests,
+		HttpRequestDuration,
+		Events,
+		EventDuration,
+		TriggersInFlight,
+		RateLimitedRequests,
+		PluginCalls,
+	)
+}
+
+// Handler - Returns a http handler exposing all membrane metrics in the prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
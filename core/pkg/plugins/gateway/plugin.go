@@ -17,6 +17,8 @@ package gateway
 import (
 	"fmt"
 
+	"github.com/nitrictech/nitric/core/pkg/cors"
+	"github.com/nitrictech/nitric/core/pkg/ratelimit"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 	"github.com/nitrictech/nitric/core/pkg/worker"
 )
@@ -48,6 +50,20 @@ type GatewayService interface {
 	Stop() error
 }
 
+// RateLimitedGatewayService - a gateway enforcing the rate limits of APIs and routes before handing requests to workers
+type RateLimitedGatewayService interface {
+	GatewayService
+	// SetRateLimiter - set the limiter requests are counted against
+	SetRateLimiter(limiter *ratelimit.Limiter)
+}
+
+// CorsGatewayService - a gateway answering preflight requests and adding CORS headers to every response of APIs with a CORS configuration
+type CorsGatewayService interface {
+	GatewayService
+	// SetCors - set the registry CORS configurations are looked up in
+	SetCors(registry *cors.Registry)
+}
+
 type UnimplementedGatewayPlugin struct {
 	GatewayService
 }
@@ -26,4 +26,6 @@ type NitricTask struct {
 	LeaseID     string                 `json:"leaseId,omitempty" log:"LeaseID"`
 	PayloadType string                 `json:"payloadType,omitempty" log:"PayLoadType"`
 	Payload     map[string]interface{} `json:"payload,omitempty"`
+	// W3C trace context the task was sent with, populated on receive
+	TraceContext map[string]string `json:"traceContext,omitempty"`
 }
@@ -15,7 +15,9 @@
 package pm
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
@@ -27,6 +29,11 @@ import (
 type process struct {
 	Command []string
 	cmd     *exec.Cmd
+	stdout  io.Writer
+	stderr  io.Writer
+	// Closed once the process has exited
+	exited  chan struct{}
+	exitErr error
 }
 
 type pMgr struct {
@@ -39,18 +46,40 @@ type ProcessManager interface {
 	StartPreProcesses() error
 	StartUserProcess() error
 	Monitor() error
-	StopAll()
+	// UserProcessRunning - Whether the user process is running, always true if no user process is configured
+	UserProcessRunning() bool
+	// StopAll - Sends SIGTERM to all processes, then SIGKILL to any still running when the context is done
+	StopAll(ctx context.Context)
 }
 
-func NewProcessManager(userCommand []string, preCommands [][]string) ProcessManager {
+type ProcessManagerOption = func(*pMgr)
+
+// WithUserOutput - Writes the user process stdout and stderr to the given writers, in place of the membrane's own
+func WithUserOutput(stdout io.Writer, stderr io.Writer) ProcessManagerOption {
+	return func(m *pMgr) {
+		if stdout != nil {
+			m.userProcess.stdout = stdout
+		}
+
+		if stderr != nil {
+			m.userProcess.stderr = stderr
+		}
+	}
+}
+
+func NewProcessManager(userCommand []string, preCommands [][]string, opts ...ProcessManagerOption) ProcessManager {
 	m := &pMgr{
-		userProcess:    &process{Command: userCommand},
+		userProcess:    &process{Command: userCommand, stdout: os.Stdout, stderr: os.Stderr},
 		preProcesses:   []*process{},
 		monitorErrChan: make(chan error),
 	}
 
 	for _, p := range preCommands {
-		m.preProcesses = append(m.preProcesses, &process{Command: p})
+		m.preProcesses = append(m.preProcesses, &process{Command: p, stdout: os.Stdout, stderr: os.Stderr})
+	}
+
+	for _, opt := range opts {
+		opt(m)
 	}
 
 	return m
@@ -60,6 +89,10 @@ func (pm *pMgr) StartUserProcess() error {
 	return pm.userProcess.start()
 }
 
+func (pm *pMgr) UserProcessRunning() bool {
+	return pm.userProcess.running()
+}
+
 func (pm *pMgr) StartPreProcesses() error {
 	for i := range pm.preProcesses {
 		if err := pm.preProcesses[i].start(); err != nil {
@@ -70,14 +103,15 @@ func (pm *pMgr) StartPreProcesses() error {
 	return nil
 }
 
-func (pm *pMgr) StopAll() {
-	err := pm.userProcess.stop()
+func (pm *pMgr) StopAll(ctx context.Context) {
+	// Stop the user process first, pre processes (e.g. telemetry collectors) may still be receiving its output
+	err := pm.userProcess.stop(ctx)
 	if err != nil {
 		fmt.Println(err)
 	}
 
 	for _, p := range pm.preProcesses {
-		err := p.stop()
+		err := p.stop(ctx)
 		if err != nil {
 			fmt.Println(err)
 		}
@@ -90,9 +124,10 @@ func (pm *pMgr) Monitor() error {
 			continue
 		}
 
-		go func(c *exec.Cmd) {
-			pm.monitorErrChan <- c.Wait()
-		}(p.cmd)
+		go func(p *process) {
+			<-p.exited
+			pm.monitorErrChan <- p.exitErr
+		}(p)
 	}
 
 	return <-pm.monitorErrChan
@@ -106,20 +141,48 @@ func (p *process) start() error {
 	}
 
 	p.cmd = exec.Command(p.Command[0], p.Command[1:]...)
-	p.cmd.Stdout = os.Stdout
-	p.cmd.Stderr = os.Stderr
+	p.cmd.Stdout = p.stdout
+	p.cmd.Stderr = p.stderr
 
 	log.Default().Printf("Starting: %s", p.Command[0])
 
-	return errors.WithMessagef(p.cmd.Start(), "there was an error starting the process %s", p.Command[0])
+	if err := p.cmd.Start(); err != nil {
+		return errors.WithMessagef(err, "there was an error starting the process %s", p.Command[0])
+	}
+
+	p.exited = make(chan struct{})
+	go func() {
+		p.exitErr = p.cmd.Wait()
+		close(p.exited)
+	}()
+
+	return nil
 }
 
-func (p *process) stop() error {
-	if p == nil || p.cmd == nil {
+func (p *process) running() bool {
+	if len(p.Command) == 0 {
+		return true
+	}
+
+	if p.exited == nil {
+		// not yet started
+		return false
+	}
+
+	select {
+	case <-p.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *process) stop(ctx context.Context) error {
+	if p == nil || p.cmd == nil || p.exited == nil {
 		return nil
 	}
 
-	err := p.cmd.Process.Signal(syscall.Signal(0))
+	err := p.cmd.Process.Signal(syscall.SIGTERM)
 	if err != nil {
 		if errors.Is(err, os.ErrProcessDone) {
 			return nil
@@ -128,12 +191,19 @@ func (p *process) stop() error {
 		return err
 	}
 
-	err = p.cmd.Process.Signal(syscall.SIGTERM)
-	if err != nil {
-		if !errors.Is(err, os.ErrProcessDone) {
-			return err
-		}
+	select {
+	case <-p.exited:
+		return nil
+	case <-ctx.Done():
+		log.Default().Printf("%s did not exit in time, killing", p.Command[0])
 	}
 
+	err = p.cmd.Process.Kill()
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return err
+	}
+
+	<-p.exited
+
 	return nil
 }
@@ -0,0 +1,109 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+// Resource - a resource declared by the application
+type Resource struct {
+	Type common.ResourceType
+	Name string
+}
+
+// Enforcer - the resources declared by the application and the actions granted on them by its policies,
+// used to deny access to anything else
+type Enforcer struct {
+	lock     sync.RWMutex
+	declared map[Resource]bool
+	granted  map[Resource]map[v1.Action]bool
+}
+
+// DeclareResource - register a resource declared by the application
+func (e *Enforcer) DeclareResource(res Resource) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.declared[res] = true
+}
+
+// DeclarePolicy - grant actions on resources, the resources must also be declared for the actions to be allowed
+func (e *Enforcer) DeclarePolicy(resources []Resource, actions []v1.Action) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, res := range resources {
+		if e.granted[res] == nil {
+			e.granted[res] = make(map[v1.Action]bool)
+		}
+
+		for _, action := range actions {
+			e.granted[res][action] = true
+		}
+	}
+}
+
+func permissionDenied(res Resource, action v1.Action, msg string) error {
+	return errors.ErrorsWithScope("Policy.Enforce", map[string]interface{}{
+		"type":   res.Type,
+		"name":   res.Name,
+		"action": action.String(),
+	})(codes.PermissionDenied, msg, nil)
+}
+
+// Enforce - returns a PermissionDenied error unless the resource has been declared and the action granted on it
+func (e *Enforcer) Enforce(res Resource, action v1.Action) error {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	if !e.declared[res] {
+		return permissionDenied(res, action, fmt.Sprintf("%s %s has not been declared", res.Type, res.Name))
+	}
+
+	if !e.granted[res][action] {
+		return permissionDenied(res, action, fmt.Sprintf("%s has not been granted on %s %s", action, res.Type, res.Name))
+	}
+
+	return nil
+}
+
+// EnforceAny - returns a PermissionDenied error unless the action has been granted on a declared resource of the given type,
+// for actions that are not performed on a single resource, e.g. listing topics
+func (e *Enforcer) EnforceAny(typ common.ResourceType, action v1.Action) error {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	for res, actions := range e.granted {
+		if res.Type == typ && e.declared[res] && actions[action] {
+			return nil
+		}
+	}
+
+	return permissionDenied(Resource{Type: typ}, action, fmt.Sprintf("%s has not been granted on any %s", action, typ))
+}
+
+func NewEnforcer() *Enforcer {
+	return &Enforcer{
+		declared: make(map[Resource]bool),
+		granted:  make(map[Resource]map[v1.Action]bool),
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+var _ = Describe("Enforcer", func() {
+	images := Resource{Type: common.ResourceType_Bucket, Name: "images"}
+
+	When("a resource has been declared with a policy", func() {
+		enforcer := NewEnforcer()
+		enforcer.DeclareResource(images)
+		enforcer.DeclarePolicy([]Resource{images}, []v1.Action{v1.Action_BucketFileGet})
+
+		It("should allow granted actions", func() {
+			Expect(enforcer.Enforce(images, v1.Action_BucketFileGet)).To(Succeed())
+		})
+
+		It("should deny actions that have not been granted", func() {
+			err := enforcer.Enforce(images, v1.Action_BucketFilePut)
+
+			Expect(errors.Code(err)).To(Equal(codes.PermissionDenied))
+			Expect(err.Error()).To(ContainSubstring("BucketFilePut has not been granted on bucket images"))
+		})
+
+		It("should allow actions granted on any resource of the type", func() {
+			Expect(enforcer.EnforceAny(common.ResourceType_Bucket, v1.Action_BucketFileGet)).To(Succeed())
+			Expect(errors.Code(enforcer.EnforceAny(common.ResourceType_Topic, v1.Action_TopicList))).To(Equal(codes.PermissionDenied))
+		})
+	})
+
+	When("a policy grants actions on an undeclared resource", func() {
+		enforcer := NewEnforcer()
+		enforcer.DeclarePolicy([]Resource{images}, []v1.Action{v1.Action_BucketFileGet})
+
+		It("should deny access to the resource", func() {
+			err := enforcer.Enforce(images, v1.Action_BucketFileGet)
+
+			Expect(errors.Code(err)).To(Equal(codes.PermissionDenied))
+			Expect(err.Error()).To(ContainSubstring("bucket images has not been declared"))
+		})
+	})
+
+	When("the policy is declared before the resource", func() {
+		enforcer := NewEnforcer()
+		enforcer.DeclarePolicy([]Resource{images}, []v1.Action{v1.Action_BucketFileGet})
+		enforcer.DeclareResource(images)
+
+		It("should allow granted actions", func() {
+			Expect(enforcer.Enforce(images, v1.Action_BucketFileGet)).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,291 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/plugins/document"
+	"github.com/nitrictech/nitric/core/pkg/plugins/events"
+	"github.com/nitrictech/nitric/core/pkg/plugins/queue"
+	"github.com/nitrictech/nitric/core/pkg/plugins/secret"
+	"github.com/nitrictech/nitric/core/pkg/plugins/storage"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+func bucket(name string) Resource {
+	return Resource{Type: common.ResourceType_Bucket, Name: name}
+}
+
+func topic(name string) Resource {
+	return Resource{Type: common.ResourceType_Topic, Name: name}
+}
+
+func queueResource(name string) Resource {
+	return Resource{Type: common.ResourceType_Queue, Name: name}
+}
+
+func secretResource(name string) Resource {
+	return Resource{Type: common.ResourceType_Secret, Name: name}
+}
+
+// collection - returns the declared collection of a collection or sub collection,
+// sub collections are covered by the policies of their root collection
+func collection(c *document.Collection) Resource {
+	for c.Parent != nil && c.Parent.Collection != nil {
+		c = c.Parent.Collection
+	}
+
+	return Resource{Type: common.ResourceType_Collection, Name: c.Name}
+}
+
+type storageService struct {
+	plugin   storage.StorageService
+	enforcer *Enforcer
+}
+
+func (s *storageService) Read(ctx context.Context, bucketName string, key string) ([]byte, error) {
+	if err := s.enforcer.Enforce(bucket(bucketName), v1.Action_BucketFileGet); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.Read(ctx, bucketName, key)
+}
+
+func (s *storageService) Write(ctx context.Context, bucketName string, key string, object []byte) error {
+	if err := s.enforcer.Enforce(bucket(bucketName), v1.Action_BucketFilePut); err != nil {
+		return err
+	}
+
+	return s.plugin.Write(ctx, bucketName, key, object)
+}
+
+func (s *storageService) Delete(ctx context.Context, bucketName string, key string) error {
+	if err := s.enforcer.Enforce(bucket(bucketName), v1.Action_BucketFileDelete); err != nil {
+		return err
+	}
+
+	return s.plugin.Delete(ctx, bucketName, key)
+}
+
+func (s *storageService) ListFiles(ctx context.Context, bucketName string) ([]*storage.FileInfo, error) {
+	if err := s.enforcer.Enforce(bucket(bucketName), v1.Action_BucketFileList); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.ListFiles(ctx, bucketName)
+}
+
+func (s *storageService) PreSignUrl(ctx context.Context, bucketName string, key string, operation storage.Operation, expiry uint32) (string, error) {
+	action := v1.Action_BucketFileGet
+	if operation == storage.WRITE {
+		action = v1.Action_BucketFilePut
+	}
+
+	if err := s.enforcer.Enforce(bucket(bucketName), action); err != nil {
+		return "", err
+	}
+
+	return s.plugin.PreSignUrl(ctx, bucketName, key, operation, expiry)
+}
+
+// StorageService - wraps a storage plugin to deny access to buckets without the declared permissions
+func (e *Enforcer) StorageService(plugin storage.StorageService) storage.StorageService {
+	if e == nil || plugin == nil {
+		return plugin
+	}
+
+	return &storageService{plugin: plugin, enforcer: e}
+}
+
+type documentService struct {
+	plugin   document.DocumentService
+	enforcer *Enforcer
+}
+
+func (s *documentService) enforceKey(key *document.Key, action v1.Action) error {
+	// invalid keys are left to be rejected by the plugin
+	if key == nil || key.Collection == nil {
+		return nil
+	}
+
+	return s.enforcer.Enforce(collection(key.Collection), action)
+}
+
+func (s *documentService) Get(ctx context.Context, key *document.Key) (*document.Document, error) {
+	if err := s.enforceKey(key, v1.Action_CollectionDocumentRead); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.Get(ctx, key)
+}
+
+func (s *documentService) Set(ctx context.Context, key *document.Key, content map[string]interface{}) error {
+	if err := s.enforceKey(key, v1.Action_CollectionDocumentWrite); err != nil {
+		return err
+	}
+
+	return s.plugin.Set(ctx, key, content)
+}
+
+func (s *documentService) Delete(ctx context.Context, key *document.Key) error {
+	if err := s.enforceKey(key, v1.Action_CollectionDocumentDelete); err != nil {
+		return err
+	}
+
+	return s.plugin.Delete(ctx, key)
+}
+
+func (s *documentService) Query(ctx context.Context, c *document.Collection, expressions []document.QueryExpression, limit int, pagingToken map[string]string) (*document.QueryResult, error) {
+	if c != nil {
+		if err := s.enforcer.Enforce(collection(c), v1.Action_CollectionQuery); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.plugin.Query(ctx, c, expressions, limit, pagingToken)
+}
+
+func (s *documentService) QueryStream(ctx context.Context, c *document.Collection, expressions []document.QueryExpression, limit int) document.DocumentIterator {
+	if c != nil {
+		if err := s.enforcer.Enforce(collection(c), v1.Action_CollectionQuery); err != nil {
+			return func() (*document.Document, error) {
+				return nil, err
+			}
+		}
+	}
+
+	return s.plugin.QueryStream(ctx, c, expressions, limit)
+}
+
+// DocumentService - wraps a document plugin to deny access to collections without the declared permissions
+func (e *Enforcer) DocumentService(plugin document.DocumentService) document.DocumentService {
+	if e == nil || plugin == nil {
+		return plugin
+	}
+
+	return &documentService{plugin: plugin, enforcer: e}
+}
+
+type eventService struct {
+	plugin   events.EventService
+	enforcer *Enforcer
+}
+
+func (s *eventService) Publish(ctx context.Context, topicName string, delay int, event *events.NitricEvent) error {
+	if err := s.enforcer.Enforce(topic(topicName), v1.Action_TopicEventPublish); err != nil {
+		return err
+	}
+
+	return s.plugin.Publish(ctx, topicName, delay, event)
+}
+
+func (s *eventService) ListTopics(ctx context.Context) ([]string, error) {
+	if err := s.enforcer.EnforceAny(common.ResourceType_Topic, v1.Action_TopicList); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.ListTopics(ctx)
+}
+
+// EventService - wraps an events plugin to deny access to topics without the declared permissions
+func (e *Enforcer) EventService(plugin events.EventService) events.EventService {
+	if e == nil || plugin == nil {
+		return plugin
+	}
+
+	return &eventService{plugin: plugin, enforcer: e}
+}
+
+type queueService struct {
+	plugin   queue.QueueService
+	enforcer *Enforcer
+}
+
+func (s *queueService) Send(ctx context.Context, queueName string, task queue.NitricTask) error {
+	if err := s.enforcer.Enforce(queueResource(queueName), v1.Action_QueueSend); err != nil {
+		return err
+	}
+
+	return s.plugin.Send(ctx, queueName, task)
+}
+
+func (s *queueService) SendBatch(ctx context.Context, queueName string, tasks []queue.NitricTask) (*queue.SendBatchResponse, error) {
+	if err := s.enforcer.Enforce(queueResource(queueName), v1.Action_QueueSend); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.SendBatch(ctx, queueName, tasks)
+}
+
+func (s *queueService) Receive(ctx context.Context, options queue.ReceiveOptions) ([]queue.NitricTask, error) {
+	if err := s.enforcer.Enforce(queueResource(options.QueueName), v1.Action_QueueReceive); err != nil {
+		return nil, err
+	}
+
+	return s.plugin.Receive(ctx, options)
+}
+
+func (s *queueService) Complete(ctx context.Context, queueName string, leaseId string) error {
+	if err := s.enforcer.Enforce(queueResource(queueName), v1.Action_QueueReceive); err != nil {
+		return err
+	}
+
+	return s.plugin.Complete(ctx, queueName, leaseId)
+}
+
+// QueueService - wraps a queue plugin to deny access to queues without the declared permissions
+func (e *Enforcer) QueueService(plugin queue.QueueService) queue.QueueService {
+	if e == nil || plugin == nil {
+		return plugin
+	}
+
+	return &queueService{plugin: plugin, enforcer: e}
+}
+
+type secretService struct {
+	plugin   secret.SecretService
+	enforcer *Enforcer
+}
+
+func (s *secretService) Put(ctx context.Context, sec *secret.Secret, value []byte) (*secret.SecretPutResponse, error) {
+	if sec != nil {
+		if err := s.enforcer.Enforce(secretResource(sec.Name), v1.Action_SecretPut); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.plugin.Put(ctx, sec, value)
+}
+
+func (s *secretService) Access(ctx context.Context, version *secret.SecretVersion) (*secret.SecretAccessResponse, error) {
+	if version != nil && version.Secret != nil {
+		if err := s.enforcer.Enforce(secretResource(version.Secret.Name), v1.Action_SecretAccess); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.plugin.Access(ctx, version)
+}
+
+// SecretService - wraps a secret plugin to deny access to secrets without the declared permissions
+func (e *Enforcer) SecretService(plugin secret.SecretService) secret.SecretService {
+	if e == nil || plugin == nil {
+		return plugin
+	}
+
+	return &secretService{plugin: plugin, enforcer: e}
+}
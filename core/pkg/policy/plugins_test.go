@@ -0,0 +1,145 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mock_document "github.com/nitrictech/nitric/core/mocks/document"
+	mock_storage "github.com/nitrictech/nitric/core/mocks/storage"
+	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
+	"github.com/nitrictech/nitric/core/pkg/plugins/document"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors"
+	"github.com/nitrictech/nitric/core/pkg/plugins/errors/codes"
+	"github.com/nitrictech/nitric/core/pkg/plugins/storage"
+	"github.com/nitrictech/nitric/core/pkg/providers/common"
+)
+
+var _ = Describe("Enforced plugins", func() {
+	When("the enforcer is nil", func() {
+		ctrl := gomock.NewController(GinkgoT())
+		plugin := mock_storage.NewMockStorageService(ctrl)
+
+		It("should return the plugin unchanged", func() {
+			var enforcer *Enforcer
+
+			Expect(enforcer.StorageService(plugin)).To(BeIdenticalTo(plugin))
+		})
+	})
+
+	When("the plugin is nil", func() {
+		It("should return nil", func() {
+			Expect(NewEnforcer().StorageService(nil)).To(BeNil())
+		})
+	})
+
+	Context("Storage", func() {
+		When("reading from a bucket with a granted action", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			plugin := mock_storage.NewMockStorageService(ctrl)
+			enforcer := NewEnforcer()
+			enforcer.DeclareResource(Resource{Type: common.ResourceType_Bucket, Name: "images"})
+			enforcer.DeclarePolicy([]Resource{{Type: common.ResourceType_Bucket, Name: "images"}}, []v1.Action{v1.Action_BucketFileGet})
+
+			It("should call the plugin", func() {
+				defer ctrl.Finish()
+
+				plugin.EXPECT().Read(gomock.Any(), "images", "cat.png").Return([]byte("meow"), nil)
+
+				out, err := enforcer.StorageService(plugin).Read(context.TODO(), "images", "cat.png")
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(out).To(Equal([]byte("meow")))
+			})
+		})
+
+		When("writing to a bucket without a granted action", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			plugin := mock_storage.NewMockStorageService(ctrl)
+			enforcer := NewEnforcer()
+			enforcer.DeclareResource(Resource{Type: common.ResourceType_Bucket, Name: "images"})
+			enforcer.DeclarePolicy([]Resource{{Type: common.ResourceType_Bucket, Name: "images"}}, []v1.Action{v1.Action_BucketFileGet})
+
+			It("should deny access without calling the plugin", func() {
+				defer ctrl.Finish()
+
+				err := enforcer.StorageService(plugin).Write(context.TODO(), "images", "cat.png", []byte("meow"))
+
+				Expect(errors.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+
+			It("should deny signing urls for writes", func() {
+				defer ctrl.Finish()
+
+				_, err := enforcer.StorageService(plugin).PreSignUrl(context.TODO(), "images", "cat.png", storage.WRITE, 60)
+
+				Expect(errors.Code(err)).To(Equal(codes.PermissionDenied))
+			})
+		})
+	})
+
+	Context("Documents", func() {
+		When("reading from a sub collection of a granted collection", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			plugin := mock_document.NewMockDocumentService(ctrl)
+			enforcer := NewEnforcer()
+			enforcer.DeclareResource(Resource{Type: common.ResourceType_Collection, Name: "customers"})
+			enforcer.DeclarePolicy([]Resource{{Type: common.ResourceType_Collection, Name: "customers"}}, []v1.Action{v1.Action_CollectionDocumentRead})
+
+			key := &document.Key{
+				Collection: &document.Collection{
+					Name: "orders",
+					Parent: &document.Key{
+						Collection: &document.Collection{Name: "customers"},
+						Id:         "customer-1",
+					},
+				},
+				Id: "order-1",
+			}
+
+			It("should call the plugin", func() {
+				defer ctrl.Finish()
+
+				plugin.EXPECT().Get(gomock.Any(), key).Return(&document.Document{Key: key}, nil)
+
+				doc, err := enforcer.DocumentService(plugin).Get(context.TODO(), key)
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(doc.Key).To(Equal(key))
+			})
+		})
+
+		When("querying an undeclared collection as a stream", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			plugin := mock_document.NewMockDocumentService(ctrl)
+			enforcer := NewEnforcer()
+
+			It("should return an iterator that fails with permission denied", func() {
+				defer ctrl.Finish()
+
+				iter := enforcer.DocumentService(plugin).QueryStream(context.TODO(), &document.Collection{Name: "customers"}, nil, 0)
+				_, err := iter()
+
+				Expect(errors.Code(err)).To(Equal(codes.PermissionDenied))
+				Expect(fmt.Sprint(err)).To(ContainSubstring("collection customers has not been declared"))
+			})
+		})
+	})
+})
@@ -22,7 +22,12 @@ import (
 type ResourceType = string
 
 const (
-	ResourceType_Api = "api"
+	ResourceType_Api        = "api"
+	ResourceType_Bucket     = "bucket"
+	ResourceType_Topic      = "topic"
+	ResourceType_Queue      = "queue"
+	ResourceType_Collection = "collection"
+	ResourceType_Secret     = "secret"
 )
 
 type DetailsResponse[T any] struct {
@@ -36,6 +41,28 @@ type ApiDetails struct {
 	URL string
 }
 
+type BucketDetails struct {
+	Name string
+	URL  string
+}
+
+type TopicDetails struct {
+	Name string
+}
+
+type QueueDetails struct {
+	Name string
+	URL  string
+}
+
+type CollectionDetails struct {
+	Name string
+}
+
+type SecretDetails struct {
+	Name string
+}
+
 // ResourceService - Base resource service interface for providers
 type ResourceService interface {
 	// Details - The details endpoint
@@ -49,3 +76,24 @@ var _ ResourceService = &UnimplementResourceService{}
 func (*UnimplementResourceService) Details(ctx context.Context, typ ResourceType, name string) (*DetailsResponse[any], error) {
 	return nil, fmt.Errorf("Unimplemented")
 }
+
+type apiDetailsOverride struct {
+	ResourceService
+	apis ResourceService
+}
+
+func (r *apiDetailsOverride) Details(ctx context.Context, typ ResourceType, name string) (*DetailsResponse[any], error) {
+	if typ == ResourceType_Api {
+		return r.apis.Details(ctx, typ, name)
+	}
+
+	return r.ResourceService.Details(ctx, typ, name)
+}
+
+// WithApiDetails - report API details from apis, e.g. a gateway serving APIs itself, and all other details from resources
+func WithApiDetails(resources ResourceService, apis ResourceService) ResourceService {
+	return &apiDetailsOverride{
+		ResourceService: resources,
+		apis:            apis,
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"container/list"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+type KeyType int
+
+// The clients requests are counted against
+const (
+	// KeyGlobal - a single bucket shared by all clients
+	KeyGlobal KeyType = iota
+	// KeyIp - a bucket per client IP address
+	KeyIp
+	// KeySubject - a bucket per subject of a validated bearer token, falling back to the client IP
+	KeySubject
+	// KeyHeader - a bucket per value of a request header, falling back to the client IP
+	KeyHeader
+)
+
+// Limit - a token bucket rate limit
+type Limit struct {
+	// Sustained number of requests allowed per second
+	RequestsPerSecond float64
+	// Maximum number of requests allowed in a burst, defaults to RequestsPerSecond
+	Burst int
+	Key   KeyType
+	// The header identifying clients when Key is KeyHeader
+	Header string
+}
+
+func (l *Limit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+
+	return math.Max(1, l.RequestsPerSecond)
+}
+
+// clientIp - returns the address of the client. X-Forwarded-For is only used for requests from trusted proxies,
+// walking back from the nearest proxy to the first address not added by a trusted proxy.
+func clientIp(req *triggers.HttpRequest, remoteIp string, trustedProxies []*net.IPNet) string {
+	if !trusted(net.ParseIP(remoteIp), trustedProxies) {
+		return remoteIp
+	}
+
+	addrs := []string{}
+	for k, v := range req.Header {
+		if strings.EqualFold(k, "X-Forwarded-For") {
+			for _, value := range v {
+				addrs = append(addrs, strings.Split(value, ",")...)
+			}
+		}
+	}
+
+	client := remoteIp
+	for i := len(addrs) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(addrs[i]))
+		if ip == nil {
+			break
+		}
+
+		client = ip.String()
+		if !trusted(ip, trustedProxies) {
+			break
+		}
+	}
+
+	return client
+}
+
+func trusted(ip net.IP, trustedProxies []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range trustedProxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseTrustedProxies - parses addresses and CIDR ranges of proxies trusted to identify clients in the X-Forwarded-For header
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(proxies))
+
+	for _, p := range proxies {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		if !strings.Contains(p, "/") {
+			ip := net.ParseIP(p)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy address: %s", p)
+			}
+
+			bits := 8 * net.IPv4len
+			if ip.To4() == nil {
+				bits = 8 * net.IPv6len
+			}
+
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range: %w", err)
+		}
+
+		nets = append(nets, n)
+	}
+
+	return nets, nil
+}
+
+func header(req *triggers.HttpRequest, name string) string {
+	for k, v := range req.Header {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+
+	return ""
+}
+
+// client - identifies the client a request is from
+type client struct {
+	ip string
+	// resolved when a limit is keyed by subject, as it may require validating the request's token
+	subject func() string
+}
+
+// clientKey - returns the key of the bucket the given client is counted against
+func (l *Limit) clientKey(req *triggers.HttpRequest, c *client) string {
+	switch l.Key {
+	case KeyIp:
+		return "ip:" + c.ip
+	case KeySubject:
+		if sub := c.subject(); sub != "" {
+			return "sub:" + sub
+		}
+	case KeyHeader:
+		if value := header(req, l.Header); value != "" {
+			return "header:" + value
+		}
+	default:
+		return ""
+	}
+
+	return "ip:" + c.ip
+}
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// The least recently used clients are evicted once a limit has this many buckets
+const maxBuckets = 10000
+
+// buckets - the token buckets of a single limit, keyed by client
+type buckets struct {
+	limit Limit
+
+	lock    sync.Mutex
+	buckets map[string]*list.Element
+	// Buckets ordered from most to least recently used
+	lru *list.List
+}
+
+func newBuckets(limit Limit) *buckets {
+	return &buckets{
+		limit:   limit,
+		buckets: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+func (b *buckets) refill(bkt *bucket, now time.Time) {
+	elapsed := now.Sub(bkt.last).Seconds()
+	bkt.tokens = math.Min(b.limit.burst(), bkt.tokens+elapsed*b.limit.RequestsPerSecond)
+	bkt.last = now
+}
+
+// bucket - returns the refilled bucket of the given client, the buckets must be locked by the caller
+func (b *buckets) bucket(key string, now time.Time) *bucket {
+	if e, ok := b.buckets[key]; ok {
+		b.lru.MoveToFront(e)
+
+		bkt := e.Value.(*bucket)
+		b.refill(bkt, now)
+
+		return bkt
+	}
+
+	if b.lru.Len() >= maxBuckets {
+		// An evicted client starts again with a full bucket, so the least recently used client is the cheapest to forget
+		oldest := b.lru.Back()
+		b.lru.Remove(oldest)
+		delete(b.buckets, oldest.Value.(*bucket).key)
+	}
+
+	bkt := &bucket{key: key, tokens: b.limit.burst(), last: now}
+	b.buckets[key] = b.lru.PushFront(bkt)
+
+	return bkt
+}
+
+// retryAfter - how long to wait for a bucket to have a token
+func (b *buckets) retryAfter(bkt *bucket) time.Duration {
+	wait := (1 - bkt.tokens) / b.limit.RequestsPerSecond
+	return time.Duration(wait * float64(time.Second))
+}
@@ -0,0 +1,293 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/metrics"
+	"github.com/nitrictech/nitric/core/pkg/routes"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+type route struct {
+	api      string
+	path     string
+	methods  []string
+	template *routes.Template
+	// nil if the route has no limit of its own
+	buckets *buckets
+}
+
+func (r *route) matches(req *triggers.HttpRequest) bool {
+	if req.Api != "" && req.Api != r.api {
+		return false
+	}
+
+	hasMethod := false
+	for _, m := range r.methods {
+		if m == req.Method {
+			hasMethod = true
+			break
+		}
+	}
+
+	if !hasMethod {
+		return false
+	}
+
+	_, ok := r.template.Match(req.Path)
+
+	return ok
+}
+
+// Limiter - the rate limits of declared APIs and routes
+type Limiter struct {
+	lock   sync.RWMutex
+	apis   map[string]*buckets
+	routes map[string]*route
+
+	// Validates bearer tokens so limits keyed by subject only trust validated tokens, nil if tokens aren't validated
+	authorizer *auth.Authorizer
+	// Proxies trusted to identify clients in the X-Forwarded-For header
+	trustedProxies []*net.IPNet
+
+	now func() time.Time
+}
+
+func routeKey(api string, path string, methods []string) string {
+	return api + " " + strings.Join(methods, ",") + " " + path
+}
+
+// DeclareApi - register the rate limit applied to all requests to an API, a nil limit removes any limit of the API
+func (l *Limiter) DeclareApi(name string, limit *Limit) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if limit == nil || limit.RequestsPerSecond <= 0 {
+		delete(l.apis, name)
+		return
+	}
+
+	l.apis[name] = newBuckets(*limit)
+}
+
+// DeclareRoute - register a route and the rate limit applied to requests to it, a nil limit leaves only the limit of its API.
+// Routes are registered without a limit so requests can be attributed to their API before they are handed to a worker.
+func (l *Limiter) DeclareRoute(api string, path string, methods []string, limit *Limit) error {
+	template, err := routes.Parse(path)
+	if err != nil {
+		return err
+	}
+
+	r := &route{
+		api:      api,
+		path:     path,
+		methods:  methods,
+		template: template,
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := routeKey(api, path, methods)
+
+	if limit != nil && limit.RequestsPerSecond > 0 {
+		if existing, ok := l.routes[key]; ok && existing.buckets != nil && existing.buckets.limit == *limit {
+			// Keep counting against the same buckets when another worker declares the same route
+			r.buckets = existing.buckets
+		} else {
+			r.buckets = newBuckets(*limit)
+		}
+	}
+
+	l.routes[key] = r
+
+	return nil
+}
+
+// route - returns the most specific declared route matching the request, using the same precedence as the worker pool
+func (l *Limiter) route(req *triggers.HttpRequest) *route {
+	var match *route
+
+	for _, r := range l.routes {
+		if !r.matches(req) {
+			continue
+		}
+
+		if match == nil {
+			match = r
+			continue
+		}
+
+		if c := r.template.Compare(match.template); c > 0 || (c == 0 && r.path < match.path) {
+			match = r
+		}
+	}
+
+	return match
+}
+
+func record(api string, route string, allowed bool) {
+	outcome := "allowed"
+	if !allowed {
+		outcome = "limited"
+	}
+
+	metrics.RateLimitedRequests.WithLabelValues(api, route, outcome).Inc()
+}
+
+// subject - the subject of the request's bearer token, if it has been or can be validated
+func (l *Limiter) subject(ctx context.Context, api string, req *triggers.HttpRequest) string {
+	// Claims are set on requests by gateways whose provider already validated the token
+	if sub, ok := req.Claims["sub"].(string); ok {
+		return sub
+	}
+
+	if l.authorizer == nil {
+		return ""
+	}
+
+	return l.authorizer.Subject(ctx, api, req.Header)
+}
+
+// limited - a limit a request is counted against
+type limited struct {
+	buckets *buckets
+	route   string
+}
+
+// limits - returns the API of the request and the limits of its route and API, in the order their buckets are locked
+func (l *Limiter) limits(req *triggers.HttpRequest) (string, []limited) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	api := req.Api
+	r := l.route(req)
+	if r != nil {
+		api = r.api
+	}
+
+	limits := []limited{}
+	if r != nil && r.buckets != nil {
+		limits = append(limits, limited{buckets: r.buckets, route: r.path})
+	}
+
+	if b, ok := l.apis[api]; ok {
+		limits = append(limits, limited{buckets: b, route: "*"})
+	}
+
+	return api, limits
+}
+
+// Allow - counts the request against the limits of its route and API,
+// returning false and how long the client should wait before retrying if either limit has been exceeded.
+// A request that is limited isn't counted against either limit.
+func (l *Limiter) Allow(ctx context.Context, req *triggers.HttpRequest, remoteIp string) (time.Duration, bool) {
+	api, limits := l.limits(req)
+	if len(limits) == 0 {
+		return 0, true
+	}
+
+	var sub *string
+	c := &client{
+		ip: clientIp(req, remoteIp, l.trustedProxies),
+		subject: func() string {
+			if sub == nil {
+				s := l.subject(ctx, api, req)
+				sub = &s
+			}
+
+			return *sub
+		},
+	}
+
+	// Resolved before any buckets are locked, as resolving the subject may validate the request's token
+	keys := make([]string, len(limits))
+	for i, lim := range limits {
+		keys[i] = lim.buckets.limit.clientKey(req, c)
+	}
+
+	now := l.now()
+
+	// Both buckets are held until tokens are taken, always locking the route before the API
+	bkts := make([]*bucket, len(limits))
+	for i, lim := range limits {
+		lim.buckets.lock.Lock()
+		defer lim.buckets.lock.Unlock()
+
+		bkts[i] = lim.buckets.bucket(keys[i], now)
+	}
+
+	var retryAfter time.Duration
+	allowed := true
+	for i, lim := range limits {
+		if bkts[i].tokens < 1 {
+			allowed = false
+			record(api, lim.route, false)
+
+			if wait := lim.buckets.retryAfter(bkts[i]); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	if !allowed {
+		return retryAfter, false
+	}
+
+	for i, lim := range limits {
+		bkts[i].tokens--
+		record(api, lim.route, true)
+	}
+
+	return 0, true
+}
+
+// LimiterOption - configures a Limiter
+type LimiterOption = func(*Limiter)
+
+// WithAuthorizer - key limits by the subject of bearer tokens validated by the given authorizer
+func WithAuthorizer(authorizer *auth.Authorizer) LimiterOption {
+	return func(l *Limiter) {
+		l.authorizer = authorizer
+	}
+}
+
+// WithTrustedProxies - identify clients of requests from the given proxies by the X-Forwarded-For header
+func WithTrustedProxies(proxies []*net.IPNet) LimiterOption {
+	return func(l *Limiter) {
+		l.trustedProxies = proxies
+	}
+}
+
+func NewLimiter(opts ...LimiterOption) *Limiter {
+	l := &Limiter{
+		apis:   make(map[string]*buckets),
+		routes: make(map[string]*route),
+		now:    time.Now,
+	}
+
+	for _, o := range opts {
+		o(l)
+	}
+
+	return l
+}
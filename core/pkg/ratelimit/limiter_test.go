@@ -0,0 +1,304 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+func unsignedToken(sub string) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{Subject: sub}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	return token
+}
+
+var _ = Describe("Limiter", func() {
+	var limiter *Limiter
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Unix(0, 0)
+		limiter = NewLimiter()
+		limiter.now = func() time.Time { return now }
+	})
+
+	get := func(path string) *triggers.HttpRequest {
+		return &triggers.HttpRequest{
+			Method: "GET",
+			Path:   path,
+			Header: map[string][]string{},
+		}
+	}
+
+	When("an API has a global limit", func() {
+		BeforeEach(func() {
+			limiter.DeclareApi("test", &Limit{RequestsPerSecond: 1, Burst: 2})
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, nil)).To(Succeed())
+		})
+
+		It("should allow requests up to the burst", func() {
+			_, ok := limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Allow(context.TODO(), get("/users/2"), "10.0.0.2")
+			Expect(ok).To(BeTrue())
+
+			retryAfter, ok := limiter.Allow(context.TODO(), get("/users/3"), "10.0.0.3")
+			Expect(ok).To(BeFalse())
+			Expect(retryAfter).To(Equal(time.Second))
+		})
+
+		It("should refill the bucket over time", func() {
+			limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+
+			now = now.Add(500 * time.Millisecond)
+			retryAfter, ok := limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeFalse())
+			Expect(retryAfter).To(Equal(500 * time.Millisecond))
+
+			now = now.Add(500 * time.Millisecond)
+			_, ok = limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should not limit requests to other routes", func() {
+			limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+
+			_, ok := limiter.Allow(context.TODO(), get("/other"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	When("a route has a per IP limit", func() {
+		BeforeEach(func() {
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, &Limit{RequestsPerSecond: 1, Key: KeyIp})).To(Succeed())
+		})
+
+		It("should limit each client separately", func() {
+			_, ok := limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeFalse())
+
+			_, ok = limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.2")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should ignore X-Forwarded-For from untrusted clients", func() {
+			req := get("/users/1")
+			req.Header["X-Forwarded-For"] = []string{"1.1.1.1"}
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+
+			req = get("/users/1")
+			req.Header["X-Forwarded-For"] = []string{"2.2.2.2"}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should use the first address not added by a trusted proxy", func() {
+			proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "2.2.2.2"})
+			Expect(err).ShouldNot(HaveOccurred())
+			limiter.trustedProxies = proxies
+
+			req := get("/users/1")
+			req.Header["X-Forwarded-For"] = []string{"1.1.1.1, 2.2.2.2"}
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+
+			req = get("/users/1")
+			req.Header["X-Forwarded-For"] = []string{"9.9.9.9, 1.1.1.1", "2.2.2.2"}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.2")
+			Expect(ok).To(BeFalse())
+
+			req = get("/users/1")
+			req.Header["X-Forwarded-For"] = []string{"3.3.3.3, 2.2.2.2"}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should not limit the route for other APIs", func() {
+			limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+
+			req := get("/users/1")
+			req.Api = "other"
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	When("a route has a per subject limit", func() {
+		BeforeEach(func() {
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, &Limit{RequestsPerSecond: 1, Key: KeySubject})).To(Succeed())
+		})
+
+		It("should limit each subject of validated claims separately", func() {
+			req := get("/users/1")
+			req.Claims = map[string]interface{}{"sub": "alice"}
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeFalse())
+
+			req.Claims = map[string]interface{}{"sub": "bob"}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should limit by client IP for tokens that aren't validated", func() {
+			req := get("/users/1")
+			req.Header["Authorization"] = []string{"Bearer " + unsignedToken("alice")}
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+
+			req.Header["Authorization"] = []string{"Bearer " + unsignedToken("bob")}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should not hold up other clients while a token is validated", func() {
+			// An issuer that doesn't respond until released, as if its signing keys were slow to fetch
+			release := make(chan struct{})
+			requested := make(chan struct{}, 1)
+			issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case requested <- struct{}{}:
+				default:
+				}
+
+				select {
+				case <-release:
+				case <-r.Context().Done():
+				}
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer issuer.Close()
+			defer close(release)
+
+			authorizer := auth.NewAuthorizer()
+			authorizer.DeclareApi("test", map[string]*auth.JwtDefinition{
+				"user": {Issuer: issuer.URL},
+			}, auth.Requirements{"user": {}})
+
+			limiter = NewLimiter(WithAuthorizer(authorizer))
+			limiter.DeclareApi("test", &Limit{RequestsPerSecond: 10, Key: KeySubject})
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, &Limit{RequestsPerSecond: 10, Key: KeySubject})).To(Succeed())
+
+			header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"key-1"}`))
+			payload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice"}`))
+			slow := get("/users/1")
+			slow.Header["Authorization"] = []string{"Bearer " + header + "." + payload + ".c2ln"}
+
+			go func() {
+				_, _ = limiter.Allow(context.TODO(), slow, "10.0.0.1")
+			}()
+			Eventually(requested, time.Second).Should(Receive())
+
+			By("allowing requests from other clients while the slow token is validated")
+			allowed := make(chan bool)
+			go func() {
+				_, ok := limiter.Allow(context.TODO(), get("/users/2"), "10.0.0.2")
+				allowed <- ok
+			}()
+
+			Eventually(allowed, time.Second).Should(Receive(BeTrue()))
+		})
+	})
+
+	When("a route has a per header limit", func() {
+		BeforeEach(func() {
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, &Limit{RequestsPerSecond: 1, Key: KeyHeader, Header: "X-Api-Key"})).To(Succeed())
+		})
+
+		It("should limit each header value separately", func() {
+			req := get("/users/1")
+			req.Header["x-api-key"] = []string{"one"}
+			_, ok := limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.2")
+			Expect(ok).To(BeFalse())
+
+			req.Header["x-api-key"] = []string{"two"}
+			_, ok = limiter.Allow(context.TODO(), req, "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	When("several routes match a request", func() {
+		BeforeEach(func() {
+			Expect(limiter.DeclareRoute("test", "/users/*rest", []string{"GET"}, &Limit{RequestsPerSecond: 1})).To(Succeed())
+			Expect(limiter.DeclareRoute("test", "/users/me", []string{"GET"}, nil)).To(Succeed())
+		})
+
+		It("should apply the limit of the most specific route", func() {
+			limiter.Allow(context.TODO(), get("/users/me"), "10.0.0.1")
+			_, ok := limiter.Allow(context.TODO(), get("/users/me"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	When("both the route and its API have a limit", func() {
+		BeforeEach(func() {
+			limiter.DeclareApi("test", &Limit{RequestsPerSecond: 1, Burst: 1})
+			Expect(limiter.DeclareRoute("test", "/users/:id", []string{"GET"}, &Limit{RequestsPerSecond: 0.001, Burst: 2, Key: KeyIp})).To(Succeed())
+		})
+
+		It("should not count a request limited by the API against the route", func() {
+			_, ok := limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+
+			_, ok = limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeFalse())
+
+			// The API has refilled, while the route still has the token left by the limited request
+			now = now.Add(time.Second)
+			_, ok = limiter.Allow(context.TODO(), get("/users/1"), "10.0.0.1")
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("buckets", func() {
+	It("should evict the least recently used client when full", func() {
+		now := time.Unix(0, 0)
+		b := newBuckets(Limit{RequestsPerSecond: 1})
+
+		b.bucket("first", now).tokens--
+		b.bucket("second", now).tokens--
+		for i := 0; i < maxBuckets-2; i++ {
+			b.bucket(fmt.Sprintf("client-%d", i), now)
+		}
+
+		// Using the first client leaves the second as the least recently used
+		b.bucket("first", now)
+		b.bucket("new", now)
+
+		Expect(b.lru.Len()).To(Equal(maxBuckets))
+		Expect(b.bucket("first", now).tokens).To(Equal(0.0))
+		Expect(b.buckets).ToNot(HaveKey("second"))
+	})
+})
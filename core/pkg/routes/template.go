@@ -0,0 +1,211 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nitrictech/nitric/core/pkg/utils"
+)
+
+type segmentKind int
+
+// Segment kinds in ascending order of specificity
+const (
+	segmentWildcard segmentKind = iota
+	segmentParam
+	segmentConstrainedParam
+	segmentStatic
+)
+
+// Named constraints for typed path params, e.g. /users/:id<int>
+var paramTypes = map[string]*regexp.Regexp{
+	"int":   regexp.MustCompile(`^-?[0-9]+$`),
+	"uint":  regexp.MustCompile(`^[0-9]+$`),
+	"alpha": regexp.MustCompile(`^[a-zA-Z]+$`),
+	"uuid":  regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+}
+
+type routeSegment struct {
+	kind       segmentKind
+	value      string
+	constraint *regexp.Regexp
+}
+
+// Template - a parsed route path.
+//
+// Supported segments are:
+//   - static segments, e.g. /users
+//   - params, e.g. /users/:id
+//   - typed params, e.g. /users/:id<int>, see paramTypes for the available types
+//   - regex constrained params, e.g. /users/:id<[a-z]{3}[0-9]+>
+//   - a greedy wildcard as the final segment, e.g. /files/*path, matching one or more remaining segments
+//
+// Leading and trailing slashes are ignored on both the template and the request path.
+type Template struct {
+	segments []routeSegment
+}
+
+func parseRouteSegment(s string) (routeSegment, error) {
+	switch {
+	case strings.HasPrefix(s, "*"):
+		return routeSegment{kind: segmentWildcard, value: s[1:]}, nil
+	case strings.HasPrefix(s, ":"):
+		name, constraint, constrained := strings.Cut(s[1:], "<")
+		if name == "" {
+			return routeSegment{}, fmt.Errorf("path param %q must be named", s)
+		}
+
+		if !constrained {
+			return routeSegment{kind: segmentParam, value: name}, nil
+		}
+
+		if !strings.HasSuffix(constraint, ">") {
+			return routeSegment{}, fmt.Errorf("path param %q has an unterminated constraint", s)
+		}
+		constraint = strings.TrimSuffix(constraint, ">")
+
+		if re, ok := paramTypes[constraint]; ok {
+			return routeSegment{kind: segmentConstrainedParam, value: name, constraint: re}, nil
+		}
+
+		// Constraints must match the whole segment
+		re, err := regexp.Compile("^(?:" + constraint + ")$")
+		if err != nil {
+			return routeSegment{}, fmt.Errorf("path param %q has an invalid constraint: %w", s, err)
+		}
+
+		return routeSegment{kind: segmentConstrainedParam, value: name, constraint: re}, nil
+	default:
+		return routeSegment{kind: segmentStatic, value: s}, nil
+	}
+}
+
+// Parse - parses the given route path, returning an error if it is not a valid template
+func Parse(path string) (*Template, error) {
+	parts := utils.SplitPath(path)
+	segments := make([]routeSegment, 0, len(parts))
+
+	for i, p := range parts {
+		seg, err := parseRouteSegment(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if seg.kind == segmentWildcard && i != len(parts)-1 {
+			return nil, fmt.Errorf("wildcard %q must be the final segment of path %s", p, path)
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return &Template{segments: segments}, nil
+}
+
+// Match - returns the path params of the given request path, or false if the path does not match this template
+func (t *Template) Match(path string) (map[string]string, bool) {
+	requestSegments := utils.SplitPath(path)
+	params := make(map[string]string)
+
+	for i, seg := range t.segments {
+		if seg.kind == segmentWildcard {
+			// Wildcards must consume at least one segment
+			if i >= len(requestSegments) {
+				return nil, false
+			}
+
+			if seg.value != "" {
+				params[seg.value] = strings.Join(requestSegments[i:], "/")
+			}
+
+			return params, true
+		}
+
+		if i >= len(requestSegments) {
+			return nil, false
+		}
+
+		switch seg.kind {
+		case segmentStatic:
+			if seg.value != requestSegments[i] {
+				return nil, false
+			}
+		case segmentConstrainedParam:
+			if !seg.constraint.MatchString(requestSegments[i]) {
+				return nil, false
+			}
+			params[seg.value] = requestSegments[i]
+		default:
+			params[seg.value] = requestSegments[i]
+		}
+	}
+
+	if len(requestSegments) != len(t.segments) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+// Compare - orders templates by specificity, returning a positive value when t is more specific than o.
+//
+// Segments are compared left to right, static segments being the most specific followed by constrained params,
+// params and finally wildcards. When all shared segments are equal the template with more segments is more specific.
+func (t *Template) Compare(o *Template) int {
+	for i := 0; i < len(t.segments) && i < len(o.segments); i++ {
+		if d := int(t.segments[i].kind) - int(o.segments[i].kind); d != 0 {
+			return d
+		}
+	}
+
+	return len(t.segments) - len(o.segments)
+}
+
+// Param - a path param of a template
+type Param struct {
+	Name string
+	// The regular expression values of the param must match, empty if the param is unconstrained
+	Pattern string
+}
+
+// OpenApiPath - returns the template as an OpenAPI path template, e.g. /users/{id}, along with its path params.
+// Wildcards are represented as a single param, unnamed wildcards are named "wildcard".
+func (t *Template) OpenApiPath() (string, []Param) {
+	parts := make([]string, 0, len(t.segments))
+	params := []Param{}
+
+	for _, seg := range t.segments {
+		if seg.kind == segmentStatic {
+			parts = append(parts, seg.value)
+			continue
+		}
+
+		param := Param{Name: seg.value}
+		if param.Name == "" {
+			param.Name = "wildcard"
+		}
+
+		if seg.constraint != nil {
+			param.Pattern = seg.constraint.String()
+		}
+
+		parts = append(parts, "{"+param.Name+"}")
+		params = append(params, param)
+	}
+
+	return "/" + strings.Join(parts, "/"), params
+}
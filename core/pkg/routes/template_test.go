@@ -0,0 +1,149 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template", func() {
+	Context("Parse", func() {
+		When("a wildcard is not the final segment", func() {
+			It("should return an error", func() {
+				_, err := Parse("/files/*path/info")
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("a param constraint is not a valid regex", func() {
+			It("should return an error", func() {
+				_, err := Parse("/users/:id<[a-z>")
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("a param constraint is unterminated", func() {
+			It("should return an error", func() {
+				_, err := Parse("/users/:id<int")
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Match", func() {
+		When("the request path has a trailing slash", func() {
+			It("should match", func() {
+				t, _ := Parse("/users/:id")
+				params, ok := t.Match("/users/1/")
+				Expect(ok).To(BeTrue())
+				Expect(params).To(Equal(map[string]string{"id": "1"}))
+			})
+		})
+
+		When("the path has a wildcard", func() {
+			t, _ := Parse("/files/*path")
+
+			It("should capture all remaining segments", func() {
+				params, ok := t.Match("/files/a/b/c.txt")
+				Expect(ok).To(BeTrue())
+				Expect(params).To(Equal(map[string]string{"path": "a/b/c.txt"}))
+			})
+
+			It("should not match without remaining segments", func() {
+				_, ok := t.Match("/files")
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the path has a typed param", func() {
+			t, _ := Parse("/users/:id<int>")
+
+			It("should match values of the type", func() {
+				params, ok := t.Match("/users/42")
+				Expect(ok).To(BeTrue())
+				Expect(params).To(Equal(map[string]string{"id": "42"}))
+			})
+
+			It("should not match values of another type", func() {
+				_, ok := t.Match("/users/me")
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the path has a regex constrained param", func() {
+			t, _ := Parse("/orders/:ref<ord-[0-9]+>")
+
+			It("should match values matching the whole expression", func() {
+				_, ok := t.Match("/orders/ord-123")
+				Expect(ok).To(BeTrue())
+			})
+
+			It("should not match values partially matching the expression", func() {
+				_, ok := t.Match("/orders/ord-123x")
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Compare", func() {
+		mustParse := func(p string) *Template {
+			t, err := Parse(p)
+			Expect(err).ShouldNot(HaveOccurred())
+			return t
+		}
+
+		It("should prefer static segments over params", func() {
+			Expect(mustParse("/users/me").Compare(mustParse("/users/:id"))).To(BeNumerically(">", 0))
+		})
+
+		It("should prefer constrained params over params", func() {
+			Expect(mustParse("/users/:id<int>").Compare(mustParse("/users/:id"))).To(BeNumerically(">", 0))
+		})
+
+		It("should prefer params over wildcards", func() {
+			Expect(mustParse("/users/:id").Compare(mustParse("/users/*rest"))).To(BeNumerically(">", 0))
+		})
+
+		It("should compare earlier segments first", func() {
+			Expect(mustParse("/users/:id/*rest").Compare(mustParse("/:type/me/profile"))).To(BeNumerically(">", 0))
+		})
+	})
+
+	Context("OpenApiPath", func() {
+		It("should convert params to path templates", func() {
+			t, err := Parse("/users/:id<int>/files/*path")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			path, params := t.OpenApiPath()
+
+			Expect(path).To(Equal("/users/{id}/files/{path}"))
+			Expect(params).To(Equal([]Param{
+				{Name: "id", Pattern: "^-?[0-9]+$"},
+				{Name: "path"},
+			}))
+		})
+
+		It("should return the root path for an empty template", func() {
+			t, err := Parse("/")
+			Expect(err).ShouldNot(HaveOccurred())
+
+			path, params := t.OpenApiPath()
+
+			Expect(path).To(Equal("/"))
+			Expect(params).To(BeEmpty())
+		})
+	})
+})
@@ -53,3 +53,38 @@ func ToTraceContext(ctx context.Context) *pb.TraceContext {
 
 	return &pb.TraceContext{Values: hc}
 }
+
+// ToAttributes - returns the W3C trace context of ctx as message attributes (traceparent, tracestate),
+// so it can be carried across asynchronous hops such as topics and queues
+func ToAttributes(ctx context.Context) map[string]string {
+	mc := propagation.MapCarrier{}
+
+	propagation.TraceContext{}.Inject(ctx, mc)
+
+	return mc
+}
+
+// FromAttributes - restores W3C trace context from message attributes written by ToAttributes.
+// ctx is returned unchanged if the attributes contain no valid trace context.
+func FromAttributes(ctx context.Context, attributes map[string]string) context.Context {
+	var mc propagation.MapCarrier = attributes
+
+	return propagation.TraceContext{}.Extract(ctx, mc)
+}
+
+// TraceContextAttributes - returns only the W3C trace context entries of the given attributes, or nil if there are none
+func TraceContextAttributes(attributes map[string]string) map[string]string {
+	var tc map[string]string
+
+	for _, field := range (propagation.TraceContext{}).Fields() {
+		if v, ok := attributes[field]; ok && v != "" {
+			if tc == nil {
+				tc = map[string]string{}
+			}
+
+			tc[field] = v
+		}
+	}
+
+	return tc
+}
@@ -0,0 +1,321 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nitrictech/nitric/core/pkg/span"
+)
+
+const (
+	maxLogBatchSize  = 512
+	maxLogQueueSize  = 2048
+	logFlushInterval = time.Second
+	logExportTimeout = 10 * time.Second
+)
+
+// LogExporter - Exports lines written to its writers as OTLP log records over gRPC
+type LogExporter struct {
+	conn     *grpc.ClientConn
+	client   collogspb.LogsServiceClient
+	headers  metadata.MD
+	resource *resourcepb.Resource
+
+	records  chan *logspb.LogRecord
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// dialTarget - returns the gRPC target and whether the connection should be insecure for an OTLP endpoint
+func dialTarget(endpoint string) (string, bool) {
+	insecureEnv := strings.ToLower(getSignalEnv(Signal_Logs, "INSECURE", "false")) == "true"
+
+	if u, err := url.Parse(endpoint); err == nil && u.Host != "" {
+		switch u.Scheme {
+		case "http":
+			return u.Host, true
+		case "https":
+			return u.Host, insecureEnv
+		}
+	}
+
+	return endpoint, insecureEnv
+}
+
+// parseHeaders - parses OTEL_EXPORTER_OTLP_HEADERS formatted values (key1=value1,key2=value2)
+func parseHeaders(headers string) metadata.MD {
+	md := metadata.MD{}
+
+	for _, header := range strings.Split(headers, ",") {
+		key, value, found := strings.Cut(header, "=")
+		if !found {
+			continue
+		}
+
+		key, _ = url.QueryUnescape(strings.TrimSpace(key))
+		value, _ = url.QueryUnescape(strings.TrimSpace(value))
+
+		if key != "" {
+			md.Append(key, value)
+		}
+	}
+
+	return md
+}
+
+func toAnyValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case attribute.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case attribute.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
+
+func toResource(res *resource.Resource) *resourcepb.Resource {
+	attrs := []*commonpb.KeyValue{}
+
+	for _, kv := range res.Attributes() {
+		attrs = append(attrs, &commonpb.KeyValue{
+			Key:   string(kv.Key),
+			Value: toAnyValue(kv.Value),
+		})
+	}
+
+	return &resourcepb.Resource{Attributes: attrs}
+}
+
+// NewLogExporter - Creates a new log exporter using the OTEL_EXPORTER_OTLP_LOGS_* or OTEL_EXPORTER_OTLP_* configuration
+func NewLogExporter(res *resource.Resource) (*LogExporter, error) {
+	endpoint := getSignalEnv(Signal_Logs, "ENDPOINT", "")
+	if endpoint == "" {
+		return nil, fmt.Errorf("no OTLP endpoint configured for logs")
+	}
+
+	target, insecureConn := dialTarget(endpoint)
+
+	creds := credentials.NewTLS(&tls.Config{})
+	if insecureConn {
+		creds = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial OTLP logs endpoint %s: %w", target, err)
+	}
+
+	if res == nil {
+		res = resource.Default()
+	}
+
+	e := &LogExporter{
+		conn:     conn,
+		client:   collogspb.NewLogsServiceClient(conn),
+		headers:  parseHeaders(getSignalEnv(Signal_Logs, "HEADERS", "")),
+		resource: toResource(res),
+		records:  make(chan *logspb.LogRecord, maxLogQueueSize),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go e.run()
+
+	return e, nil
+}
+
+// Writer - returns a writer that exports each line written to it as a log record from the given stream (e.g. stdout)
+func (e *LogExporter) Writer(stream string) io.Writer {
+	severity := logspb.SeverityNumber_SEVERITY_NUMBER_INFO
+	if stream == "stderr" {
+		severity = logspb.SeverityNumber_SEVERITY_NUMBER_ERROR
+	}
+
+	attrs := []*commonpb.KeyValue{{
+		Key:   "log.iostream",
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: stream}},
+	}}
+
+	return newLineWriter(func(line string) {
+		now := uint64(time.Now().UnixNano())
+
+		e.emit(&logspb.LogRecord{
+			TimeUnixNano:         now,
+			ObservedTimeUnixNano: now,
+			SeverityNumber:       severity,
+			Body:                 &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: line}},
+			Attributes:           attrs,
+		})
+	})
+}
+
+// emit - queues a record for export, records are dropped rather than blocking the writer when the queue is full
+func (e *LogExporter) emit(record *logspb.LogRecord) {
+	select {
+	case <-e.stop:
+	case e.records <- record:
+	default:
+	}
+}
+
+func (e *LogExporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(logFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*logspb.LogRecord, 0, maxLogBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		e.export(batch)
+		batch = make([]*logspb.LogRecord, 0, maxLogBatchSize)
+	}
+
+	for {
+		select {
+		case record := <-e.records:
+			batch = append(batch, record)
+			if len(batch) >= maxLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-e.stop:
+			// Export anything left in the queue before exiting
+			for {
+				select {
+				case record := <-e.records:
+					batch = append(batch, record)
+					if len(batch) >= maxLogBatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (e *LogExporter) export(records []*logspb.LogRecord) {
+	ctx, cancel := context.WithTimeout(context.Background(), logExportTimeout)
+	defer cancel()
+
+	if len(e.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, e.headers)
+	}
+
+	_, err := e.client.Export(ctx, &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    "membrane/pkg/telemetry",
+					Version: span.MembraneVersion,
+				},
+				LogRecords: records,
+			}},
+		}},
+	})
+	if err != nil {
+		// Written to stderr directly, to avoid feeding export failures back into the exporter
+		fmt.Fprintf(os.Stderr, "failed to export %d log records: %v\n", len(records), err)
+	}
+}
+
+// Shutdown - Exports any remaining records and closes the connection to the endpoint
+func (e *LogExporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() {
+		close(e.stop)
+	})
+
+	var err error
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if closeErr := e.conn.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// lineWriter - buffers written bytes and calls emit for each complete line
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  []byte
+	emit func(line string)
+}
+
+func newLineWriter(emit func(line string)) *lineWriter {
+	return &lineWriter{emit: emit}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := strings.TrimRight(string(w.buf[:i]), "\r")
+		w.buf = w.buf[i+1:]
+
+		if line != "" {
+			w.emit(line)
+		}
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Logs", func() {
+	Context("lineWriter", func() {
+		var lines []string
+		var writer *lineWriter
+
+		BeforeEach(func() {
+			lines = []string{}
+			writer = newLineWriter(func(line string) {
+				lines = append(lines, line)
+			})
+		})
+
+		When("writing complete lines", func() {
+			It("should emit each line", func() {
+				n, err := writer.Write([]byte("first\r\nsecond\n"))
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(n).To(Equal(len("first\r\nsecond\n")))
+				Expect(lines).To(Equal([]string{"first", "second"}))
+			})
+		})
+
+		When("a line is split across writes", func() {
+			It("should buffer until the line is complete", func() {
+				_, _ = writer.Write([]byte("partial "))
+				Expect(lines).To(BeEmpty())
+
+				_, _ = writer.Write([]byte("line\n\n"))
+				Expect(lines).To(Equal([]string{"partial line"}))
+			})
+		})
+	})
+
+	Context("parseHeaders", func() {
+		It("should parse comma separated key value pairs", func() {
+			md := parseHeaders("api-key=secret, x-tenant=a%20b,invalid")
+
+			Expect(md.Get("api-key")).To(Equal([]string{"secret"}))
+			Expect(md.Get("x-tenant")).To(Equal([]string{"a b"}))
+			Expect(md.Len()).To(Equal(2))
+		})
+	})
+
+	Context("dialTarget", func() {
+		It("should use an insecure connection for http endpoints", func() {
+			target, insecure := dialTarget("http://collector:4317")
+
+			Expect(target).To(Equal("collector:4317"))
+			Expect(insecure).To(BeTrue())
+		})
+
+		It("should use TLS for https endpoints", func() {
+			target, insecure := dialTarget("https://collector:4317")
+
+			Expect(target).To(Equal("collector:4317"))
+			Expect(insecure).To(BeFalse())
+		})
+	})
+
+	Context("EndpointConfigured", func() {
+		AfterEach(func() {
+			os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+			os.Unsetenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT")
+			os.Unsetenv("OTEL_LOGS_EXPORTER")
+		})
+
+		It("should not be configured by default", func() {
+			Expect(EndpointConfigured(Signal_Logs)).To(BeFalse())
+		})
+
+		It("should be configured by a signal specific endpoint", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_LOGS_ENDPOINT", "http://collector:4317")
+
+			Expect(EndpointConfigured(Signal_Logs)).To(BeTrue())
+			Expect(EndpointConfigured(Signal_Metrics)).To(BeFalse())
+		})
+
+		It("should be configured by the generic endpoint", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+
+			Expect(EndpointConfigured(Signal_Metrics)).To(BeTrue())
+		})
+
+		It("should be disabled when the exporter is none", func() {
+			os.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4317")
+			os.Setenv("OTEL_LOGS_EXPORTER", "none")
+
+			Expect(EndpointConfigured(Signal_Logs)).To(BeFalse())
+		})
+	})
+})
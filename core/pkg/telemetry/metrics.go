@@ -0,0 +1,128 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetry
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/unit"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/nitrictech/nitric/core/pkg/span"
+)
+
+var (
+	// Instruments are created against the global meter provider,
+	// which delegates to the configured provider once it has been set
+	meter = global.Meter("membrane/pkg/telemetry", metric.WithInstrumentationVersion(span.MembraneVersion))
+
+	triggerDuration, _ = meter.SyncFloat64().Histogram(
+		"nitric.worker.trigger.duration",
+		instrument.WithUnit(unit.Milliseconds),
+		instrument.WithDescription("Latency of triggers handled by workers"),
+	)
+	triggerErrors, _ = meter.SyncInt64().Counter(
+		"nitric.worker.trigger.errors",
+		instrument.WithDescription("Number of triggers that workers failed to handle"),
+	)
+	pluginCallDuration, _ = meter.SyncFloat64().Histogram(
+		"nitric.plugin.call.duration",
+		instrument.WithUnit(unit.Milliseconds),
+		instrument.WithDescription("Latency of calls made to membrane services"),
+	)
+	pluginCallErrors, _ = meter.SyncInt64().Counter(
+		"nitric.plugin.call.errors",
+		instrument.WithDescription("Number of calls made to membrane services that returned an error"),
+	)
+)
+
+// Attribute keys for trigger metrics
+const (
+	TriggerTypeKey = attribute.Key("nitric.trigger.type")
+	TriggerNameKey = attribute.Key("nitric.trigger.name")
+)
+
+func millisecondsSince(start time.Time) float64 {
+	return float64(time.Since(start)) / float64(time.Millisecond)
+}
+
+// RecordTrigger - Records the latency and outcome of a trigger handled by a worker
+func RecordTrigger(ctx context.Context, start time.Time, err error, attrs ...attribute.KeyValue) {
+	triggerDuration.Record(ctx, millisecondsSince(start), attrs...)
+
+	if err != nil {
+		triggerErrors.Add(ctx, 1, attrs...)
+	}
+}
+
+// methodAttributes - splits a full gRPC method name (e.g. /nitric.storage.v1.StorageService/Read) into rpc attributes
+func methodAttributes(fullMethod string, err error) []attribute.KeyValue {
+	service, method := "unknown", strings.TrimPrefix(fullMethod, "/")
+
+	if i := strings.LastIndex(method, "/"); i >= 0 {
+		service, method = method[:i], method[i+1:]
+	}
+
+	return []attribute.KeyValue{
+		semconv.RPCSystemKey.String("grpc"),
+		semconv.RPCServiceKey.String(service),
+		semconv.RPCMethodKey.String(method),
+		semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))),
+	}
+}
+
+func recordCall(ctx context.Context, fullMethod string, start time.Time, err error) {
+	attrs := methodAttributes(fullMethod, err)
+
+	pluginCallDuration.Record(ctx, millisecondsSince(start), attrs...)
+
+	if err != nil {
+		pluginCallErrors.Add(ctx, 1, attrs...)
+	}
+}
+
+// UnaryServerInterceptor - Records the duration and errors of unary calls made to the membrane's gRPC services
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		recordCall(ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor - Records the duration and errors of streaming calls made to the membrane's gRPC services
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		recordCall(ss.Context(), info.FullMethod, start, err)
+
+		return err
+	}
+}
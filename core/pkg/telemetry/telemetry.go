@@ -0,0 +1,52 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetry provides OpenTelemetry metrics and log export for the membrane.
+// Exporters are configured using the standard OTEL_EXPORTER_OTLP_* environment variables.
+package telemetry
+
+import (
+	"strings"
+
+	"github.com/nitrictech/nitric/core/pkg/utils"
+)
+
+type Signal string
+
+const (
+	Signal_Traces  Signal = "TRACES"
+	Signal_Metrics Signal = "METRICS"
+	Signal_Logs    Signal = "LOGS"
+)
+
+// getSignalEnv - returns the signal specific value of an OTEL_EXPORTER_OTLP_* env var, falling back to the generic value
+// e.g. OTEL_EXPORTER_OTLP_LOGS_ENDPOINT then OTEL_EXPORTER_OTLP_ENDPOINT
+func getSignalEnv(signal Signal, name string, defaultValue string) string {
+	if v := utils.GetEnv("OTEL_EXPORTER_OTLP_"+string(signal)+"_"+name, ""); v != "" {
+		return v
+	}
+
+	return utils.GetEnv("OTEL_EXPORTER_OTLP_"+name, defaultValue)
+}
+
+// EndpointConfigured - Whether an OTLP endpoint has been configured for the given signal,
+// allowing it to be exported directly rather than through a local collector
+func EndpointConfigured(signal Signal) bool {
+	exporter := strings.ToLower(utils.GetEnv("OTEL_"+string(signal)+"_EXPORTER", "otlp"))
+	if exporter == "none" {
+		return false
+	}
+
+	return getSignalEnv(signal, "ENDPOINT", "") != ""
+}
@@ -14,14 +14,29 @@
 
 package triggers
 
+import "time"
+
 // Event - A nitric event that has come from a trigger source
 type Event struct {
 	ID         string
 	Topic      string
 	Payload    []byte
 	Attributes map[string]string
+	// Schedule - the event was raised by a schedule, rather than published to a topic
+	Schedule bool
+	// Time - when the event occurred at its source, zero if the source doesn't record it
+	Time time.Time
 }
 
 func (*Event) GetTriggerType() TriggerType {
 	return TriggerType_Subscription
 }
+
+// SourceType - the kind of source that raised the event
+func (e *Event) SourceType() TriggerType {
+	if e.Schedule {
+		return TriggerType_Schedule
+	}
+
+	return TriggerType_Subscription
+}
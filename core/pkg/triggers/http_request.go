@@ -15,26 +15,37 @@
 package triggers
 
 import (
+	"io"
 	"strings"
 
 	"github.com/valyala/fasthttp"
 )
 
+// MaxBufferedBodySize - request bodies up to this size are buffered, larger bodies and bodies of unknown length are streamed
+const MaxBufferedBodySize = 4 * 1024 * 1024
+
 // HttpRequest - Storage information that captures a HTTP Request
 type HttpRequest struct {
 	Header map[string][]string
 	// The original body stream
 	Body []byte
+	// Set in place of Body when the request body is too large to buffer and must be streamed to the worker
+	BodyStream io.Reader
 	// The original method
 	Method string
-	// The original path
+	// The original path, relative to the base path of the API when it is mapped to one
 	Path string
+	// The API this request was addressed to, requests without an API may be handled by any API's routes
+	Api string
 	// URL
 	URL string
 	// URL query parameters
 	Query map[string][]string
 	// Path parameters
 	Params map[string]string
+	// Claims of the validated bearer token, if the route is secured.
+	// Gateways set these when the token was already validated by the provider's API gateway.
+	Claims map[string]interface{}
 }
 
 func (*HttpRequest) GetTriggerType() TriggerType {
@@ -73,12 +84,21 @@ func FromHttpRequest(rc *fasthttp.RequestCtx) *HttpRequest {
 		queryArgs[k] = append(queryArgs[k], string(val))
 	})
 
-	return &HttpRequest{
+	req := &HttpRequest{
 		Header: headerCopy,
-		Body:   rc.Request.Body(),
 		Method: string(rc.Method()),
 		URL:    rc.URI().String(),
 		Path:   string(rc.URI().PathOriginal()),
 		Query:  queryArgs,
 	}
+
+	// Servers with StreamRequestBody enabled provide every body as a stream, so only large or chunked bodies are kept as one
+	contentLength := rc.Request.Header.ContentLength()
+	if rc.Request.IsBodyStream() && (contentLength < 0 || contentLength > MaxBufferedBodySize) {
+		req.BodyStream = rc.RequestBodyStream()
+	} else {
+		req.Body = rc.Request.Body()
+	}
+
+	return req
 }
@@ -16,6 +16,7 @@ package triggers
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/valyala/fasthttp"
 
@@ -28,6 +29,10 @@ type HttpResponse struct {
 	Header *fasthttp.ResponseHeader
 	// The original body stream
 	Body []byte
+	// Set in place of Body when the worker streams the response body, must be closed by the reader
+	BodyStream io.ReadCloser
+	// Returns the trailers written after BodyStream, only called once BodyStream has been read to the end
+	Trailer func() map[string][]string
 	// The original method
 	StatusCode int
 }
@@ -21,8 +21,10 @@ const (
 	TriggerType_Subscription TriggerType = iota
 	TriggerType_Request
 	TriggerType_Custom
+	TriggerType_Websocket
+	TriggerType_Schedule
 )
 
 func (e TriggerType) String() string {
-	return []string{"SUBSCRIPTION", "REQUEST", "CUSTOM"}[e]
+	return []string{"SUBSCRIPTION", "REQUEST", "CUSTOM", "WEBSOCKET", "SCHEDULE"}[e]
 }
@@ -0,0 +1,50 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggers
+
+// WebsocketEventType - the type of event that occurred on a websocket connection
+type WebsocketEventType int
+
+const (
+	WebsocketEventType_Connect WebsocketEventType = iota
+	WebsocketEventType_Disconnect
+	WebsocketEventType_Message
+)
+
+func (e WebsocketEventType) String() string {
+	return []string{"CONNECT", "DISCONNECT", "MESSAGE"}[e]
+}
+
+// WebsocketEvent - An event that occurred on a connection to a websocket
+type WebsocketEvent struct {
+	// The name of the socket the connection belongs to
+	Socket       string
+	Event        WebsocketEventType
+	ConnectionID string
+	// Query params of the connection request, only set for connect events
+	Query map[string][]string
+	// The message data, only set for message events
+	Body []byte
+}
+
+func (*WebsocketEvent) GetTriggerType() TriggerType {
+	return TriggerType_Websocket
+}
+
+// WebsocketResponse - The result of handling a websocket event
+type WebsocketResponse struct {
+	// Whether the event was handled successfully, an unsuccessful connect event rejects the connection
+	Success bool
+}
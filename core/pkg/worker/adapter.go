@@ -24,4 +24,10 @@ import (
 type Adapter interface {
 	HandleEvent(ctx context.Context, trigger *triggers.Event) error
 	HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error)
+	HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error)
+	// Drain - Stop accepting new triggers and block until in-flight triggers have completed
+	// or the given context is done
+	Drain(ctx context.Context) error
+	// Saturated - Returns true when the adapter is handling as many triggers as it can at once
+	Saturated() bool
 }
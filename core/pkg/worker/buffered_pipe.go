@@ -0,0 +1,117 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// The maximum size of a response body buffered for a reader before the body is failed
+const maxBufferedResponseBody = 16 * 1024 * 1024
+
+var errBodyBufferFull = fmt.Errorf("response body exceeded %d buffered bytes, the reader is too slow", maxBufferedResponseBody)
+
+// bufferedPipe - a pipe whose writes never block, buffering written data until it is read.
+// Chunks for every trigger arrive on a worker's single stream, so a slow reader mustn't block writes.
+type bufferedPipe struct {
+	lock  sync.Mutex
+	ready *sync.Cond
+	buf   bytes.Buffer
+	limit int
+	// Set once writing has finished, io.EOF if the body is complete
+	writeErr error
+	// Set once the reader has been closed
+	readerClosed bool
+}
+
+var _ io.ReadCloser = &bufferedPipe{}
+
+func newBufferedPipe(limit int) *bufferedPipe {
+	p := &bufferedPipe{limit: limit}
+	p.ready = sync.NewCond(&p.lock)
+
+	return p
+}
+
+// Read - Reads buffered data, blocking until data is written or writing has finished
+func (p *bufferedPipe) Read(b []byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for p.buf.Len() == 0 && p.writeErr == nil && !p.readerClosed {
+		p.ready.Wait()
+	}
+
+	if p.readerClosed {
+		return 0, io.ErrClosedPipe
+	}
+
+	if p.buf.Len() > 0 {
+		return p.buf.Read(b)
+	}
+
+	return 0, p.writeErr
+}
+
+// Close - Closes the reader, discarding buffered data and failing subsequent writes
+func (p *bufferedPipe) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.readerClosed = true
+	p.buf = bytes.Buffer{}
+	p.ready.Broadcast()
+
+	return nil
+}
+
+// write - Buffers data for the reader, failing if the reader has been closed or too much data is buffered
+func (p *bufferedPipe) write(data []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.readerClosed || p.writeErr != nil {
+		return io.ErrClosedPipe
+	}
+
+	if p.buf.Len()+len(data) > p.limit {
+		return errBodyBufferFull
+	}
+
+	p.buf.Write(data)
+	p.ready.Broadcast()
+
+	return nil
+}
+
+// closeWrite - Finishes writing, the reader receives err once buffered data has been read, or io.EOF if err is nil
+func (p *bufferedPipe) closeWrite(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.writeErr != nil {
+		return
+	}
+
+	if err == nil {
+		err = io.EOF
+	}
+
+	p.writeErr = err
+	p.ready.Broadcast()
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("bufferedPipe", func() {
+	When("writing before the data is read", func() {
+		It("should buffer the data without blocking", func() {
+			p := newBufferedPipe(16)
+
+			Expect(p.write([]byte("hello "))).To(Succeed())
+			Expect(p.write([]byte("world"))).To(Succeed())
+			p.closeWrite(nil)
+
+			body, err := io.ReadAll(p)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(string(body)).To(Equal("hello world"))
+		})
+	})
+
+	When("reading before data is written", func() {
+		It("should block until data is written", func() {
+			p := newBufferedPipe(16)
+
+			read := make(chan string, 1)
+			go func() {
+				b := make([]byte, 16)
+				n, _ := p.Read(b)
+				read <- string(b[:n])
+			}()
+
+			Consistently(read, 50*time.Millisecond).ShouldNot(Receive())
+
+			Expect(p.write([]byte("hello"))).To(Succeed())
+			Eventually(read).Should(Receive(Equal("hello")))
+		})
+	})
+
+	When("more data is written than the limit", func() {
+		It("should fail the write", func() {
+			p := newBufferedPipe(4)
+
+			Expect(p.write([]byte("hello"))).To(Equal(errBodyBufferFull))
+		})
+	})
+
+	When("writing is closed with an error", func() {
+		It("should return the error once buffered data has been read", func() {
+			p := newBufferedPipe(16)
+			failure := errors.New("worker stream closed")
+
+			Expect(p.write([]byte("hello"))).To(Succeed())
+			p.closeWrite(failure)
+
+			body, err := io.ReadAll(p)
+			Expect(string(body)).To(Equal("hello"))
+			Expect(err).To(Equal(failure))
+		})
+	})
+
+	When("the reader is closed", func() {
+		It("should fail subsequent writes", func() {
+			p := newBufferedPipe(16)
+			Expect(p.Close()).To(Succeed())
+
+			Expect(p.write([]byte("hello"))).To(Equal(io.ErrClosedPipe))
+		})
+	})
+})
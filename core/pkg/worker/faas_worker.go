@@ -32,6 +32,11 @@ func (s *FaasWorker) HandlesEvent(trigger *triggers.Event) bool {
 	return true
 }
 
+// HandlesWebsocketEvent - websocket events are only delivered to workers registered for the socket
+func (s *FaasWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
 // NewFaasWorker - Create a new FaaS worker
 func NewFaasWorker(adapter Adapter) *FaasWorker {
 	return &FaasWorker{
@@ -21,25 +21,110 @@ import (
 	"log"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/valyala/fasthttp"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	v1 "github.com/nitrictech/nitric/core/pkg/api/nitric/v1"
 	"github.com/nitrictech/nitric/core/pkg/span"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 )
 
+// The maximum size of each chunk of a streamed request body
+const bodyChunkSize = 64 * 1024
+
 type GrpcAdapter struct {
 	stream v1.FaasService_TriggerStreamServer
+	// gRPC streams do not support concurrent sends
+	sendLock sync.Mutex
 	// Response channels for this worker
 	responseQueueLock sync.Locker
 	responseQueue     map[string]chan *v1.TriggerResponse
+	// Response bodies being streamed from this worker, guarded by responseQueueLock
+	bodyStreams map[string]*bodyStream
+	// The worker accepts streamed request bodies
+	bodyStreaming bool
+	// Tracks triggers being handled, refusing new triggers once draining has begun
+	inFlight inFlightTracker
+	// Holds a slot for each trigger being handled, nil when the worker has no concurrency limit
+	slots chan struct{}
+	// How long a trigger waits for a free slot before it is rejected
+	queueTimeout time.Duration
 }
 
 var _ Adapter = &GrpcAdapter{}
 
+// bodyStream - a response body being streamed from the worker as BodyChunk messages
+type bodyStream struct {
+	pipe *bufferedPipe
+}
+
+type GrpcAdapterOption = func(*GrpcAdapter)
+
+// WithBodyStreaming - Streams large request bodies to the worker as BodyChunk messages, the worker must declare support in its InitRequest
+func WithBodyStreaming(enabled bool) GrpcAdapterOption {
+	return func(s *GrpcAdapter) {
+		s.bodyStreaming = enabled
+	}
+}
+
+// WithMaxConcurrency - Limits the number of triggers sent to the worker at once, unlimited if max is 0
+func WithMaxConcurrency(max int) GrpcAdapterOption {
+	return func(s *GrpcAdapter) {
+		if max > 0 {
+			s.slots = make(chan struct{}, max)
+		} else {
+			s.slots = nil
+		}
+	}
+}
+
+// WithQueueTimeout - Sets how long triggers wait for the worker to have capacity before being rejected with ErrSaturated
+func WithQueueTimeout(timeout time.Duration) GrpcAdapterOption {
+	return func(s *GrpcAdapter) {
+		s.queueTimeout = timeout
+	}
+}
+
+// Saturated - Returns true when the worker is handling its maximum number of concurrent triggers
+func (s *GrpcAdapter) Saturated() bool {
+	return s.slots != nil && len(s.slots) >= cap(s.slots)
+}
+
+// acquire - Waits for the worker to have capacity for another trigger, returning a func to release it once handled
+func (s *GrpcAdapter) acquire(ctx context.Context) (func(), error) {
+	if s.slots == nil {
+		return func() {}, nil
+	}
+
+	release := func() { <-s.slots }
+
+	select {
+	case s.slots <- struct{}{}:
+		return release, nil
+	default:
+	}
+
+	if s.queueTimeout <= 0 {
+		return nil, ErrSaturated
+	}
+
+	timer := time.NewTimer(s.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case s.slots <- struct{}{}:
+		return release, nil
+	case <-timer.C:
+		return nil, ErrSaturated
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // newTicket - Generates a request/response ID and response channel
 // for the requesting thread to wait on
 func (s *GrpcAdapter) newTicket() (string, chan *v1.TriggerResponse) {
@@ -70,10 +155,135 @@ func (s *GrpcAdapter) resolveTicket(ID string) (chan *v1.TriggerResponse, error)
 	return s.responseQueue[ID], nil
 }
 
+// openBodyStream - Registers a response body stream for the given ID, the reader is returned with the response
+func (s *GrpcAdapter) openBodyStream(ID string) *bodyStream {
+	s.responseQueueLock.Lock()
+	defer s.responseQueueLock.Unlock()
+
+	if s.bodyStreams == nil {
+		s.bodyStreams = make(map[string]*bodyStream)
+	}
+
+	bs := &bodyStream{pipe: newBufferedPipe(maxBufferedResponseBody)}
+	s.bodyStreams[ID] = bs
+
+	return bs
+}
+
+// closeBodyStream - Removes the response body stream for the given ID, returning nil if it does not exist
+func (s *GrpcAdapter) closeBodyStream(ID string) *bodyStream {
+	s.responseQueueLock.Lock()
+	defer s.responseQueueLock.Unlock()
+
+	bs := s.bodyStreams[ID]
+	delete(s.bodyStreams, ID)
+
+	return bs
+}
+
+func (s *GrpcAdapter) getBodyStream(ID string) *bodyStream {
+	s.responseQueueLock.Lock()
+	defer s.responseQueueLock.Unlock()
+
+	return s.bodyStreams[ID]
+}
+
+// writeBodyChunk - Buffers a chunk for the response body stream it belongs to.
+// This never blocks, so a slow reader doesn't hold up the other triggers handled by the worker.
+func (s *GrpcAdapter) writeBodyChunk(ID string, chunk *v1.BodyChunk) {
+	bs := s.getBodyStream(ID)
+	if bs == nil {
+		// The reader has already gone away (e.g. the client disconnected)
+		return
+	}
+
+	if len(chunk.GetData()) > 0 {
+		if err := bs.pipe.write(chunk.GetData()); err != nil {
+			// The reader was closed or fell too far behind, discard the remaining chunks
+			s.closeBodyStream(ID)
+			bs.pipe.closeWrite(err)
+			return
+		}
+	}
+
+	if chunk.GetLast() {
+		// Left registered until the reader is closed, so the trigger stays pending while the gateway writes the body
+		bs.pipe.closeWrite(nil)
+	}
+}
+
+// pending - Returns the number of triggers awaiting a response from the worker
+func (s *GrpcAdapter) pending() int {
+	s.responseQueueLock.Lock()
+	defer s.responseQueueLock.Unlock()
+
+	return len(s.responseQueue) + len(s.bodyStreams)
+}
+
+// Drain - Stops accepting new triggers and waits for all outstanding tickets to be resolved
+func (s *GrpcAdapter) Drain(ctx context.Context) error {
+	if err := s.inFlight.drain(ctx); err != nil {
+		return err
+	}
+
+	// Response bodies may still be streaming after their trigger has been handled
+
+	ticker := time.NewTicker(time.Duration(5) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if s.pending() == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d triggers still in flight: %w", s.pending(), ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 func (gwb *GrpcAdapter) send(msg *v1.ServerMessage) error {
+	gwb.sendLock.Lock()
+	defer gwb.sendLock.Unlock()
+
 	return gwb.stream.Send(msg)
 }
 
+// sendBody - Streams a request body to the worker as BodyChunk messages, stopping early if done is closed
+func (gwb *GrpcAdapter) sendBody(ID string, body io.Reader, done <-chan struct{}) {
+	buf := make([]byte, bodyChunkSize)
+
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		n, err := io.ReadFull(body, buf)
+		last := err != nil
+
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			log.Default().Printf("error reading request body: %v", err)
+		}
+
+		sendErr := gwb.send(&v1.ServerMessage{
+			Id: ID,
+			Content: &v1.ServerMessage_BodyChunk{
+				BodyChunk: &v1.BodyChunk{
+					Data: buf[:n],
+					Last: last,
+				},
+			},
+		})
+		if sendErr != nil || last {
+			return
+		}
+	}
+}
+
 func (gwb *GrpcAdapter) Start(errchan chan error) {
 	for {
 		msg, err := gwb.stream.Recv()
@@ -84,13 +294,15 @@ func (gwb *GrpcAdapter) Start(errchan chan error) {
 			}
 			log.Printf("received error %v", err)
 
+			gwb.abortBodyStreams(err)
+
 			errchan <- err
 			return
 		}
 
 		if msg.GetInitRequest() != nil {
 			log.Default().Println("Received init request from worker")
-			err = gwb.stream.Send(&v1.ServerMessage{
+			err = gwb.send(&v1.ServerMessage{
 				Content: &v1.ServerMessage_InitResponse{
 					InitResponse: &v1.InitResponse{},
 				},
@@ -101,32 +313,114 @@ func (gwb *GrpcAdapter) Start(errchan chan error) {
 			continue
 		}
 
+		if chunk := msg.GetBodyChunk(); chunk != nil {
+			gwb.writeBodyChunk(msg.GetId(), chunk)
+			continue
+		}
+
 		// Load the response channel and delete its map key reference
 		val, err := gwb.resolveTicket(msg.GetId())
 		if err != nil {
 			err = errors.WithMessage(err, "Fatal: FaaS Worker in bad state closing stream: "+msg.GetId())
 			log.Default().Println(err.Error())
+			gwb.abortBodyStreams(err)
 			errchan <- err
 			return
 		}
 		// For now assume this is a trigger response...
 		response := msg.GetTriggerResponse()
+
+		// The body stream must be registered before the response is handed over, as chunks may follow immediately
+		if response.GetStreamedBody() && response.GetHttp() != nil {
+			gwb.openBodyStream(msg.GetId())
+		}
+
 		// Write the response the the waiting recipient
 		val <- response
 	}
 }
 
+// abortBodyStreams - Fails any response bodies still being streamed when the worker stream is closed
+func (gwb *GrpcAdapter) abortBodyStreams(err error) {
+	gwb.responseQueueLock.Lock()
+	defer gwb.responseQueueLock.Unlock()
+
+	for ID, bs := range gwb.bodyStreams {
+		bs.pipe.closeWrite(err)
+		delete(gwb.bodyStreams, ID)
+	}
+}
+
 func (s *GrpcAdapter) HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	if err := s.inFlight.start(); err != nil {
+		return nil, err
+	}
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		s.inFlight.done()
+		return nil, err
+	}
+
+	// Closed to stop sending a streamed request body once the trigger has been handled
+	var stopBody chan struct{}
+	finish := func() {
+		if stopBody != nil {
+			close(stopBody)
+		}
+		release()
+		s.inFlight.done()
+	}
+
+	// A streamed response is still being handled until the gateway has closed its body
+	streamed := false
+	defer func() {
+		if !streamed {
+			finish()
+		}
+	}()
+
+	var claims *structpb.Struct
+	if trigger.Claims != nil {
+		var err error
+		if claims, err = structpb.NewStruct(trigger.Claims); err != nil {
+			return nil, errors.WithMessage(err, "error converting token claims")
+		}
+	}
+
 	// Generate an ID here
 	ID, returnChan := s.newTicket()
 
+	body := trigger.Body
+	streamBody := trigger.BodyStream != nil && s.bodyStreaming
+
+	if trigger.BodyStream != nil && !s.bodyStreaming {
+		// The worker can't receive chunks, so the body must be buffered
+		var err error
+		if body, err = io.ReadAll(io.LimitReader(trigger.BodyStream, triggers.MaxBufferedBodySize+1)); err != nil {
+			_, _ = s.resolveTicket(ID)
+			return nil, errors.WithMessage(err, "error reading request body")
+		}
+
+		if len(body) > triggers.MaxBufferedBodySize {
+			_, _ = s.resolveTicket(ID)
+			return &triggers.HttpResponse{
+				Header:     &fasthttp.ResponseHeader{},
+				StatusCode: http.StatusRequestEntityTooLarge,
+				Body:       []byte(fmt.Sprintf("request body exceeds %d bytes, the worker does not support streamed bodies", triggers.MaxBufferedBodySize)),
+			}, nil
+		}
+	}
+
 	var mimeType string = ""
 	if trigger.Header != nil && len(trigger.Header["Content-Type"]) > 0 {
 		mimeType = trigger.Header["Content-Type"][0]
 	}
 
-	if mimeType == "" {
-		mimeType = http.DetectContentType(trigger.Body)
+	if mimeType == "" && streamBody {
+		mimeType = "application/octet-stream"
+	} else if mimeType == "" {
+		mimeType = http.DetectContentType(body)
 	}
 
 	headers := make(map[string]*v1.HeaderValue)
@@ -156,9 +450,10 @@ func (s *GrpcAdapter) HandleHttpRequest(ctx context.Context, trigger *triggers.H
 	}
 
 	triggerRequest := &v1.TriggerRequest{
-		Data:         trigger.Body,
+		Data:         body,
 		MimeType:     mimeType,
 		TraceContext: span.ToTraceContext(ctx),
+		StreamedBody: streamBody,
 		Context: &v1.TriggerRequest_Http{
 			Http: &v1.HttpTriggerContext{
 				Path:           trigger.Path,
@@ -168,6 +463,7 @@ func (s *GrpcAdapter) HandleHttpRequest(ctx context.Context, trigger *triggers.H
 				Headers:        headers,
 				HeadersOld:     headersOld,
 				PathParams:     trigger.Params,
+				Claims:         claims,
 			},
 		},
 	}
@@ -181,12 +477,19 @@ func (s *GrpcAdapter) HandleHttpRequest(ctx context.Context, trigger *triggers.H
 	}
 
 	// send the message
-	err := s.send(message)
+	err = s.send(message)
 	if err != nil {
 		// There was an error enqueuing the message
+		// the ticket will never be resolved so remove it
+		_, _ = s.resolveTicket(ID)
 		return nil, err
 	}
 
+	if streamBody {
+		stopBody = make(chan struct{})
+		go s.sendBody(ID, trigger.BodyStream, stopBody)
+	}
+
 	// wait for the response
 	triggerResponse := <-returnChan
 
@@ -217,10 +520,35 @@ func (s *GrpcAdapter) HandleHttpRequest(ctx context.Context, trigger *triggers.H
 		Header:     fasthttpHeader,
 	}
 
+	if triggerResponse.GetStreamedBody() {
+		if bs := s.getBodyStream(ID); bs != nil {
+			response.Body = nil
+			response.BodyStream = &doneOnClose{
+				ReadCloser: bs.pipe,
+				done: func() {
+					s.closeBodyStream(ID)
+					finish()
+				},
+			}
+			streamed = true
+		}
+	}
+
 	return response, nil
 }
 
 func (s *GrpcAdapter) HandleEvent(ctx context.Context, trigger *triggers.Event) error {
+	if err := s.inFlight.start(); err != nil {
+		return err
+	}
+	defer s.inFlight.done()
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	// Generate an ID here
 	ID, returnChan := s.newTicket()
 	triggerRequest := &v1.TriggerRequest{
@@ -244,9 +572,11 @@ func (s *GrpcAdapter) HandleEvent(ctx context.Context, trigger *triggers.Event)
 	}
 
 	// send the message
-	err := s.send(message)
+	err = s.send(message)
 	if err != nil {
 		// There was an error enqueuing the message
+		// the ticket will never be resolved so remove it
+		_, _ = s.resolveTicket(ID)
 		return err
 	}
 
@@ -269,10 +599,89 @@ func (s *GrpcAdapter) HandleEvent(ctx context.Context, trigger *triggers.Event)
 	return fmt.Errorf("Error occurred handling the event")
 }
 
-func NewGrpcAdapter(stream v1.FaasService_TriggerStreamServer) *GrpcAdapter {
-	return &GrpcAdapter{
+var websocketEvents = map[triggers.WebsocketEventType]v1.WebsocketEvent{
+	triggers.WebsocketEventType_Connect:    v1.WebsocketEvent_Connect,
+	triggers.WebsocketEventType_Disconnect: v1.WebsocketEvent_Disconnect,
+	triggers.WebsocketEventType_Message:    v1.WebsocketEvent_Message,
+}
+
+func (s *GrpcAdapter) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	if err := s.inFlight.start(); err != nil {
+		return nil, err
+	}
+	defer s.inFlight.done()
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	query := make(map[string]*v1.QueryValue)
+	for k, v := range trigger.Query {
+		query[k] = &v1.QueryValue{
+			Value: v,
+		}
+	}
+
+	// Generate an ID here
+	ID, returnChan := s.newTicket()
+	triggerRequest := &v1.TriggerRequest{
+		Data:         trigger.Body,
+		MimeType:     http.DetectContentType(trigger.Body),
+		TraceContext: span.ToTraceContext(ctx),
+		Context: &v1.TriggerRequest_Websocket{
+			Websocket: &v1.WebsocketTriggerContext{
+				Socket:       trigger.Socket,
+				Event:        websocketEvents[trigger.Event],
+				ConnectionId: trigger.ConnectionID,
+				QueryParams:  query,
+			},
+		},
+	}
+
+	// construct the message
+	message := &v1.ServerMessage{
+		Id: ID,
+		Content: &v1.ServerMessage_TriggerRequest{
+			TriggerRequest: triggerRequest,
+		},
+	}
+
+	// send the message
+	err = s.send(message)
+	if err != nil {
+		// There was an error enqueuing the message
+		// the ticket will never be resolved so remove it
+		_, _ = s.resolveTicket(ID)
+		return nil, err
+	}
+
+	// wait for the response
+	response := <-returnChan
+
+	websocket := response.GetWebsocket()
+	if websocket == nil {
+		// We don't have the correct response type for this handler
+		return nil, fmt.Errorf("Fatal: Error handling websocket event, incorrect response received from function")
+	}
+
+	return &triggers.WebsocketResponse{
+		Success: websocket.GetSuccess(),
+	}, nil
+}
+
+func NewGrpcAdapter(stream v1.FaasService_TriggerStreamServer, opts ...GrpcAdapterOption) *GrpcAdapter {
+	adapter := &GrpcAdapter{
 		stream:            stream,
 		responseQueueLock: &sync.Mutex{},
 		responseQueue:     make(map[string]chan *v1.TriggerResponse),
+		bodyStreams:       make(map[string]*bodyStream),
 	}
+
+	for _, opt := range opts {
+		opt(adapter)
+	}
+
+	return adapter
 }
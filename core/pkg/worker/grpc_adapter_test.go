@@ -18,7 +18,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -295,6 +297,168 @@ var _ = Describe("grpcAdapter", func() {
 		PWhen("the worker successfully responds", func() {
 			// TODO
 		})
+
+		When("the worker streams the response body", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			stream := mock_nitric.NewMockFaasService_TriggerStreamServer(ctrl)
+			wkr := NewGrpcAdapter(stream, WithMaxConcurrency(1))
+
+			It("should return the body as a stream", func() {
+				sent := make(chan *v1.ServerMessage, 1)
+				recv := make(chan *v1.ClientMessage)
+				errChan := make(chan error)
+
+				stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg *v1.ServerMessage) error {
+					sent <- msg
+					return nil
+				})
+				stream.EXPECT().Recv().DoAndReturn(func() (*v1.ClientMessage, error) {
+					msg, ok := <-recv
+					if !ok {
+						return nil, io.EOF
+					}
+					return msg, nil
+				}).AnyTimes()
+
+				go wkr.Start(errChan)
+
+				go func() {
+					id := (<-sent).GetId()
+
+					By("the worker responding with a streamed body")
+					recv <- &v1.ClientMessage{
+						Id: id,
+						Content: &v1.ClientMessage_TriggerResponse{
+							TriggerResponse: &v1.TriggerResponse{
+								StreamedBody: true,
+								Context: &v1.TriggerResponse_Http{
+									Http: &v1.HttpResponseContext{Status: 200},
+								},
+							},
+						},
+					}
+					recv <- &v1.ClientMessage{
+						Id:      id,
+						Content: &v1.ClientMessage_BodyChunk{BodyChunk: &v1.BodyChunk{Data: []byte("hello ")}},
+					}
+					recv <- &v1.ClientMessage{
+						Id:      id,
+						Content: &v1.ClientMessage_BodyChunk{BodyChunk: &v1.BodyChunk{Data: []byte("world"), Last: true}},
+					}
+					close(recv)
+				}()
+
+				resp, err := wkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(200))
+				Expect(resp.BodyStream).ToNot(BeNil())
+
+				By("reading the chunks from the body stream")
+				body, err := io.ReadAll(resp.BodyStream)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(string(body)).To(Equal("hello world"))
+
+				By("holding the worker's slot until the body stream is closed")
+				Expect(wkr.Saturated()).To(BeTrue())
+
+				drainCtx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+				defer cancel()
+				Expect(wkr.Drain(drainCtx)).Should(HaveOccurred())
+
+				Expect(resp.BodyStream.Close()).To(Succeed())
+				Expect(wkr.Saturated()).To(BeFalse())
+				Expect(wkr.Drain(context.TODO())).ShouldNot(HaveOccurred())
+
+				Expect(<-errChan).To(Equal(io.EOF))
+				Expect(wkr.pending()).To(Equal(0))
+
+				ctrl.Finish()
+			})
+		})
+
+		When("the request body is streamed to a worker that supports body streaming", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			stream := mock_nitric.NewMockFaasService_TriggerStreamServer(ctrl)
+			wkr := NewGrpcAdapter(stream, WithBodyStreaming(true))
+
+			It("should send the body as chunks following the trigger request", func() {
+				sent := make(chan *v1.ServerMessage, 2)
+				recv := make(chan *v1.ClientMessage)
+				errChan := make(chan error)
+
+				stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(msg *v1.ServerMessage) error {
+					sent <- msg
+					return nil
+				}).Times(2)
+				stream.EXPECT().Recv().DoAndReturn(func() (*v1.ClientMessage, error) {
+					msg, ok := <-recv
+					if !ok {
+						return nil, io.EOF
+					}
+					return msg, nil
+				}).AnyTimes()
+
+				go wkr.Start(errChan)
+
+				messages := make(chan []*v1.ServerMessage, 1)
+				go func() {
+					req := <-sent
+					chunk := <-sent
+
+					recv <- &v1.ClientMessage{
+						Id: req.GetId(),
+						Content: &v1.ClientMessage_TriggerResponse{
+							TriggerResponse: &v1.TriggerResponse{
+								Data: []byte("ok"),
+								Context: &v1.TriggerResponse_Http{
+									Http: &v1.HttpResponseContext{Status: 200},
+								},
+							},
+						},
+					}
+					close(recv)
+
+					messages <- []*v1.ServerMessage{req, chunk}
+				}()
+
+				resp, err := wkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					BodyStream: strings.NewReader("request body"),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Body).To(Equal([]byte("ok")))
+
+				msgs := <-messages
+
+				By("flagging the trigger request as having a streamed body")
+				Expect(msgs[0].GetTriggerRequest().GetStreamedBody()).To(BeTrue())
+				Expect(msgs[0].GetTriggerRequest().GetData()).To(BeEmpty())
+
+				By("sending the body as the last chunk")
+				Expect(msgs[1].GetId()).To(Equal(msgs[0].GetId()))
+				Expect(msgs[1].GetBodyChunk().GetData()).To(Equal([]byte("request body")))
+				Expect(msgs[1].GetBodyChunk().GetLast()).To(BeTrue())
+
+				Expect(<-errChan).To(Equal(io.EOF))
+
+				ctrl.Finish()
+			})
+		})
+		When("a large request body is sent to a worker that doesn't support body streaming", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			stream := mock_nitric.NewMockFaasService_TriggerStreamServer(ctrl)
+			wkr := NewGrpcAdapter(stream)
+
+			It("should reject the request without sending it to the worker", func() {
+				resp, err := wkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					BodyStream: io.LimitReader(zeroReader{}, triggers.MaxBufferedBodySize+1),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(413))
+				Expect(wkr.pending()).To(Equal(0))
+
+				ctrl.Finish()
+			})
+		})
 	})
 
 	Context("HandleEvent", func() {
@@ -322,4 +486,130 @@ var _ = Describe("grpcAdapter", func() {
 			// TODO
 		})
 	})
+
+	Context("MaxConcurrency", func() {
+		When("the worker has no concurrency limit", func() {
+			wkr := NewGrpcAdapter(nil)
+
+			It("should never be saturated", func() {
+				release, err := wkr.acquire(context.TODO())
+				Expect(err).ShouldNot(HaveOccurred())
+				defer release()
+
+				Expect(wkr.Saturated()).To(BeFalse())
+			})
+		})
+
+		When("the worker is handling its maximum number of triggers", func() {
+			wkr := NewGrpcAdapter(nil, WithMaxConcurrency(1))
+
+			It("should reject new triggers until one completes", func() {
+				release, err := wkr.acquire(context.TODO())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				By("reporting the worker as saturated")
+				Expect(wkr.Saturated()).To(BeTrue())
+
+				By("rejecting new triggers")
+				Expect(wkr.HandleEvent(context.TODO(), &triggers.Event{})).To(Equal(ErrSaturated))
+
+				By("accepting triggers again once released")
+				release()
+				Expect(wkr.Saturated()).To(BeFalse())
+			})
+		})
+
+		When("the worker has a queue timeout", func() {
+			wkr := NewGrpcAdapter(nil, WithMaxConcurrency(1), WithQueueTimeout(time.Second))
+
+			It("should queue triggers until a slot is released", func() {
+				release, err := wkr.acquire(context.TODO())
+				Expect(err).ShouldNot(HaveOccurred())
+
+				go func() {
+					time.Sleep(10 * time.Millisecond)
+					release()
+				}()
+
+				queuedRelease, err := wkr.acquire(context.TODO())
+				Expect(err).ShouldNot(HaveOccurred())
+				queuedRelease()
+			})
+
+			It("should stop waiting when the context is done", func() {
+				release, err := wkr.acquire(context.TODO())
+				Expect(err).ShouldNot(HaveOccurred())
+				defer release()
+
+				ctx, cancel := context.WithCancel(context.TODO())
+				cancel()
+
+				_, err = wkr.acquire(ctx)
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+
+	Context("Drain", func() {
+		When("there are no outstanding tickets", func() {
+			wkr := &GrpcAdapter{
+				responseQueueLock: &sync.Mutex{},
+				responseQueue:     make(map[string]chan *v1.TriggerResponse),
+			}
+
+			It("should return immediately and reject new triggers", func() {
+				Expect(wkr.Drain(context.TODO())).ShouldNot(HaveOccurred())
+
+				_, err := wkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{})
+				Expect(err).To(Equal(ErrDraining))
+
+				Expect(wkr.HandleEvent(context.TODO(), &triggers.Event{})).To(Equal(ErrDraining))
+			})
+		})
+
+		When("outstanding tickets are resolved", func() {
+			wkr := &GrpcAdapter{
+				responseQueueLock: &sync.Mutex{},
+				responseQueue: map[string]chan *v1.TriggerResponse{
+					"test": make(chan *v1.TriggerResponse),
+				},
+			}
+
+			It("should wait for the ticket to be resolved", func() {
+				go func() {
+					time.Sleep(20 * time.Millisecond)
+					_, _ = wkr.resolveTicket("test")
+				}()
+
+				Expect(wkr.Drain(context.TODO())).ShouldNot(HaveOccurred())
+			})
+		})
+
+		When("outstanding tickets are not resolved within the grace period", func() {
+			wkr := &GrpcAdapter{
+				responseQueueLock: &sync.Mutex{},
+				responseQueue: map[string]chan *v1.TriggerResponse{
+					"test": make(chan *v1.TriggerResponse),
+				},
+			}
+
+			It("should return an error", func() {
+				ctx, cancel := context.WithTimeout(context.TODO(), 20*time.Millisecond)
+				defer cancel()
+
+				Expect(wkr.Drain(ctx)).Should(HaveOccurred())
+			})
+		})
+	})
 })
+
+// zeroReader - an endless reader of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = 0
+	}
+
+	return len(b), nil
+}
@@ -0,0 +1,556 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+const (
+	grpcContentType        = "application/grpc"
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+
+	// Flags the trailer frame of a gRPC-Web response
+	grpcWebTrailerFlag = 0x80
+)
+
+// Headers that only apply to a single connection and aren't forwarded
+var hopHeaders = map[string]bool{
+	"connection":        true,
+	"content-length":    true,
+	"keep-alive":        true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+	"te":                true,
+	"host":              true,
+}
+
+// A Nitric gRPC proxy worker, forwarding requests to a child process serving gRPC over HTTP/2 without TLS (h2c)
+//
+// Gateways serve HTTP/1.1 only, so browsers and other clients should use gRPC-Web.
+// gRPC-Web requests are translated to gRPC and their responses streamed back with trailers encoded in the body.
+// gRPC requests reaching the gateway over HTTP/1.1, e.g. from a proxy bridging HTTP/2 clients, are forwarded as is
+// and their responses streamed back with trailers written after the body.
+// JSON requests to unary methods are transcoded to gRPC when transcoding is enabled,
+// at their gRPC path only as google.api.http annotations are not supported.
+type GrpcProxyWorker struct {
+	address string
+	client  *http.Client
+	// Descriptors of the child process's services, used to transcode JSON requests, nil if transcoding is disabled
+	files *protoregistry.Files
+	// Tracks requests currently being forwarded to the child process
+	inFlight inFlightTracker
+}
+
+var _ Worker = &GrpcProxyWorker{}
+
+type GrpcProxyWorkerOption = func(*GrpcProxyWorker)
+
+// WithTranscoding - transcode JSON requests to the unary methods of the given service descriptors
+func WithTranscoding(files *protoregistry.Files) GrpcProxyWorkerOption {
+	return func(w *GrpcProxyWorker) {
+		w.files = files
+	}
+}
+
+// LoadDescriptorSet - loads a serialized FileDescriptorSet, e.g. created with protoc --include_imports --descriptor_set_out
+func LoadDescriptorSet(path string) (*protoregistry.Files, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading descriptor set: %w", err)
+	}
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(b, fds); err != nil {
+		return nil, fmt.Errorf("error parsing descriptor set: %w", err)
+	}
+
+	return protodesc.NewFiles(fds)
+}
+
+func (g *GrpcProxyWorker) HandlesHttpRequest(trigger *triggers.HttpRequest) bool {
+	return true
+}
+
+func (g *GrpcProxyWorker) HandlesEvent(trigger *triggers.Event) bool {
+	return false
+}
+
+func (g *GrpcProxyWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
+func (g *GrpcProxyWorker) HandleEvent(ctx context.Context, trigger *triggers.Event) error {
+	return fmt.Errorf("grpc proxy workers cannot handle events")
+}
+
+func (g *GrpcProxyWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("grpc proxy workers cannot handle websocket events")
+}
+
+func requestHeader(h map[string][]string, key string) string {
+	for k, v := range h {
+		if strings.EqualFold(k, key) && len(v) > 0 {
+			return v[0]
+		}
+	}
+
+	return ""
+}
+
+func requestBody(trigger *triggers.HttpRequest) io.Reader {
+	if trigger.BodyStream != nil {
+		return trigger.BodyStream
+	}
+
+	return bytes.NewReader(trigger.Body)
+}
+
+// upstreamRequest - creates a gRPC request to the child process for the given trigger
+func (g *GrpcProxyWorker) upstreamRequest(ctx context.Context, trigger *triggers.HttpRequest, path string, contentType string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://%s%s", g.address, path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, val := range trigger.Header {
+		if hopHeaders[strings.ToLower(key)] {
+			continue
+		}
+
+		for _, v := range val {
+			req.Header.Add(key, v)
+		}
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("TE", "trailers")
+
+	return req, nil
+}
+
+func responseHeader(resp *http.Response, skip ...string) *fasthttp.ResponseHeader {
+	header := &fasthttp.ResponseHeader{}
+
+	for key, val := range resp.Header {
+		if hopHeaders[strings.ToLower(key)] || strings.EqualFold(key, "Trailer") {
+			continue
+		}
+
+		for _, v := range val {
+			header.Add(key, v)
+		}
+	}
+
+	for _, key := range skip {
+		header.Del(key)
+	}
+
+	return header
+}
+
+// proxyGrpc - forwards a gRPC request to the child process, streaming the response back as it is received
+func (g *GrpcProxyWorker) proxyGrpc(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	req, err := g.upstreamRequest(ctx, trigger, trigger.Path, requestHeader(trigger.Header, "Content-Type"), requestBody(trigger))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &triggers.HttpResponse{
+		Header:     responseHeader(resp),
+		StatusCode: resp.StatusCode,
+		// Streamed with chunked transfer encoding, which is required to write trailers
+		BodyStream: resp.Body,
+		// Trailers are only available once the body has been read
+		Trailer: func() map[string][]string {
+			return resp.Trailer
+		},
+	}, nil
+}
+
+// grpcWebTrailerFrame - encodes trailers as a gRPC-Web trailer frame
+func grpcWebTrailerFrame(trailer http.Header) []byte {
+	keys := make([]string, 0, len(trailer))
+	for k := range trailer {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	buf := &bytes.Buffer{}
+	for _, k := range keys {
+		for _, v := range trailer[k] {
+			fmt.Fprintf(buf, "%s: %s\r\n", strings.ToLower(k), v)
+		}
+	}
+
+	frame := make([]byte, 5, 5+buf.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(buf.Len()))
+
+	return append(frame, buf.Bytes()...)
+}
+
+// proxyGrpcWeb - translates a gRPC-Web request to gRPC, streaming the response back with its trailers encoded in the body
+func (g *GrpcProxyWorker) proxyGrpcWeb(ctx context.Context, trigger *triggers.HttpRequest, text bool) (*triggers.HttpResponse, error) {
+	contentType := requestHeader(trigger.Header, "Content-Type")
+
+	body := requestBody(trigger)
+	webContentType := grpcWebContentType
+	if text {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+		webContentType = grpcWebTextContentType
+	}
+
+	// Retain the message encoding, e.g. application/grpc-web+proto becomes application/grpc+proto
+	suffix := strings.TrimPrefix(contentType, webContentType)
+
+	req, err := g.upstreamRequest(ctx, trigger, trigger.Path, grpcContentType+suffix, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Del("X-Grpc-Web")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	header := responseHeader(resp, "Content-Type")
+	header.Set("Content-Type", webContentType+suffix)
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer resp.Body.Close()
+
+		var w io.Writer = pw
+		var enc io.WriteCloser
+		if text {
+			enc = base64.NewEncoder(base64.StdEncoding, pw)
+			w = enc
+		}
+
+		_, err := io.Copy(w, resp.Body)
+		if err == nil && len(resp.Trailer) > 0 {
+			_, err = w.Write(grpcWebTrailerFrame(resp.Trailer))
+		}
+		if err == nil && enc != nil {
+			err = enc.Close()
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return &triggers.HttpResponse{
+		Header:     header,
+		StatusCode: resp.StatusCode,
+		BodyStream: pr,
+	}, nil
+}
+
+// Maps gRPC status codes to HTTP status codes for transcoded responses
+var grpcHttpStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+func jsonResponse(status int, body []byte) *triggers.HttpResponse {
+	header := &fasthttp.ResponseHeader{}
+	header.SetContentType("application/json")
+
+	return &triggers.HttpResponse{
+		Header:     header,
+		StatusCode: status,
+		Body:       body,
+	}
+}
+
+func jsonError(code codes.Code, message string) *triggers.HttpResponse {
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":    code,
+		"message": message,
+	})
+
+	status, ok := grpcHttpStatus[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	return jsonResponse(status, body)
+}
+
+// lookupMethod - finds the method for a request path of the form /package.Service/Method
+func (g *GrpcProxyWorker) lookupMethod(path string) (protoreflect.MethodDescriptor, bool) {
+	service, method, ok := strings.Cut(strings.Trim(path, "/"), "/")
+	if !ok {
+		return nil, false
+	}
+
+	d, err := g.files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, false
+	}
+
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+
+	md := sd.Methods().ByName(protoreflect.Name(method))
+
+	return md, md != nil
+}
+
+// JSON request bodies larger than this are rejected, as they are read in full to be transcoded
+const maxTranscodedBodySize = 16 * 1024 * 1024
+
+// transcode - transcodes a JSON request to a call to a unary gRPC method
+func (g *GrpcProxyWorker) transcode(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	md, ok := g.lookupMethod(trigger.Path)
+	if !ok {
+		return jsonError(codes.NotFound, fmt.Sprintf("no method found for path %s", trigger.Path)), nil
+	}
+
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return jsonError(codes.Unimplemented, "only unary methods can be transcoded"), nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(requestBody(trigger), maxTranscodedBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(body) > maxTranscodedBodySize {
+		resp := jsonError(codes.ResourceExhausted, fmt.Sprintf("request body exceeds %d bytes", maxTranscodedBodySize))
+		resp.StatusCode = http.StatusRequestEntityTooLarge
+
+		return resp, nil
+	}
+
+	in := dynamicpb.NewMessage(md.Input())
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := protojson.Unmarshal(body, in); err != nil {
+			return jsonError(codes.InvalidArgument, err.Error()), nil
+		}
+	}
+
+	msg, err := proto.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	frame = append(frame, msg...)
+
+	path := fmt.Sprintf("/%s/%s", md.Parent().FullName(), md.Name())
+	req, err := g.upstreamRequest(ctx, trigger, path, grpcContentType, bytes.NewReader(frame))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return jsonError(codes.Unavailable, fmt.Sprintf("unexpected status %d from child process", resp.StatusCode)), nil
+	}
+
+	// Trailers-only responses carry the status in their headers
+	grpcStatus := resp.Trailer.Get("Grpc-Status")
+	grpcMessage := resp.Trailer.Get("Grpc-Message")
+	if grpcStatus == "" {
+		grpcStatus = resp.Header.Get("Grpc-Status")
+		grpcMessage = resp.Header.Get("Grpc-Message")
+	}
+
+	code, err := strconv.Atoi(grpcStatus)
+	if err != nil {
+		return jsonError(codes.Unknown, "missing grpc status from child process"), nil
+	}
+
+	if codes.Code(code) != codes.OK {
+		message, err := url.PathUnescape(grpcMessage)
+		if err != nil {
+			message = grpcMessage
+		}
+
+		return jsonError(codes.Code(code), message), nil
+	}
+
+	if len(out) < 5 || int(binary.BigEndian.Uint32(out[1:5])) > len(out)-5 {
+		return jsonError(codes.Internal, "malformed response from child process"), nil
+	}
+
+	result := dynamicpb.NewMessage(md.Output())
+	if err := proto.Unmarshal(out[5:5+binary.BigEndian.Uint32(out[1:5])], result); err != nil {
+		return nil, err
+	}
+
+	body, err = protojson.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResponse(http.StatusOK, body), nil
+}
+
+// doneOnClose - a response body stream that reports the request done once it has been closed
+type doneOnClose struct {
+	io.ReadCloser
+	once sync.Once
+	done func()
+}
+
+func (d *doneOnClose) Close() error {
+	err := d.ReadCloser.Close()
+	d.once.Do(d.done)
+
+	return err
+}
+
+// HandleHttpRequest - Forwards gRPC and gRPC-Web requests, and transcodes JSON requests when enabled
+func (g *GrpcProxyWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	if err := g.inFlight.start(); err != nil {
+		return nil, err
+	}
+
+	resp, err := g.handleHttpRequest(ctx, trigger)
+	if err != nil || resp.BodyStream == nil {
+		g.inFlight.done()
+		return resp, err
+	}
+
+	// Streamed responses are in flight until the gateway has finished writing them
+	resp.BodyStream = &doneOnClose{ReadCloser: resp.BodyStream, done: g.inFlight.done}
+
+	return resp, nil
+}
+
+func (g *GrpcProxyWorker) handleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	contentType := requestHeader(trigger.Header, "Content-Type")
+
+	switch {
+	case strings.HasPrefix(contentType, grpcWebTextContentType):
+		return g.proxyGrpcWeb(ctx, trigger, true)
+	case strings.HasPrefix(contentType, grpcWebContentType):
+		return g.proxyGrpcWeb(ctx, trigger, false)
+	case strings.HasPrefix(contentType, grpcContentType):
+		return g.proxyGrpc(ctx, trigger)
+	case g.files != nil && strings.HasPrefix(contentType, "application/json"):
+		return g.transcode(ctx, trigger)
+	}
+
+	return &triggers.HttpResponse{
+		Header:     &fasthttp.ResponseHeader{},
+		StatusCode: http.StatusUnsupportedMediaType,
+		Body:       []byte(fmt.Sprintf("unsupported content type %q, expected a gRPC or gRPC-Web request", contentType)),
+	}, nil
+}
+
+// Saturated - the child process applies its own concurrency limits
+func (g *GrpcProxyWorker) Saturated() bool {
+	return false
+}
+
+// Drain - Stops accepting new triggers and waits for requests to the child process to complete
+func (g *GrpcProxyWorker) Drain(ctx context.Context) error {
+	return g.inFlight.drain(ctx)
+}
+
+// Creates a new GrpcProxyWorker
+// Will wait to ensure that the provided address is dialable
+// before proceeding
+func NewGrpcProxyWorker(address string, opts ...GrpcProxyWorkerOption) (*GrpcProxyWorker, error) {
+	if !waitForAddress(address) {
+		return nil, fmt.Errorf("Unable to dial grpc worker, does it expose a grpc server at: %s?", address)
+	}
+
+	w := &GrpcProxyWorker{
+		address: address,
+		client: &http.Client{
+			// gRPC requires HTTP/2, the child process is expected to serve it without TLS
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, network, addr)
+				},
+			},
+		},
+	}
+
+	for _, o := range opts {
+		o(w)
+	}
+
+	return w, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+func grpcFrame(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	frame := make([]byte, 5, 5+len(b))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(b)))
+
+	return append(frame, b...)
+}
+
+var _ = Describe("GrpcProxyWorker", func() {
+	var lis net.Listener
+	var srv *grpc.Server
+	var wrkr *GrpcProxyWorker
+
+	BeforeEach(func() {
+		var err error
+		lis, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		srv = grpc.NewServer()
+		grpc_health_v1.RegisterHealthServer(srv, health.NewServer())
+		go func() {
+			_ = srv.Serve(lis)
+		}()
+
+		files := &protoregistry.Files{}
+		Expect(files.RegisterFile(grpc_health_v1.File_grpc_health_v1_health_proto)).To(Succeed())
+
+		wrkr, err = NewGrpcProxyWorker(lis.Addr().String(), WithTranscoding(files))
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		srv.Stop()
+	})
+
+	Context("Event", func() {
+		When("calling HandlesEvent", func() {
+			It("should return false", func() {
+				Expect(wrkr.HandlesEvent(&triggers.Event{})).To(BeFalse())
+			})
+		})
+
+		When("calling HandleEvent", func() {
+			It("should return an error", func() {
+				Expect(wrkr.HandleEvent(context.TODO(), &triggers.Event{})).ShouldNot(Succeed())
+			})
+		})
+	})
+
+	Context("Http", func() {
+		When("calling HandlesHttpRequest", func() {
+			It("should return true", func() {
+				Expect(wrkr.HandlesHttpRequest(&triggers.HttpRequest{})).To(BeTrue())
+			})
+		})
+
+		When("forwarding a gRPC request", func() {
+			It("should return the response with its trailers", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Check",
+					Header: map[string][]string{"Content-Type": {"application/grpc"}},
+					Body:   grpcFrame(&grpc_health_v1.HealthCheckRequest{}),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := io.ReadAll(resp.BodyStream)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.BodyStream.Close()).To(Succeed())
+				Expect(resp.Trailer()["Grpc-Status"]).To(Equal([]string{"0"}))
+
+				out := &grpc_health_v1.HealthCheckResponse{}
+				Expect(proto.Unmarshal(body[5:], out)).To(Succeed())
+				Expect(out.Status).To(Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+			})
+		})
+
+		When("forwarding a server streaming gRPC request", func() {
+			watch := func() *triggers.HttpResponse {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Watch",
+					Header: map[string][]string{"Content-Type": {"application/grpc"}},
+					Body:   grpcFrame(&grpc_health_v1.HealthCheckRequest{}),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				return resp
+			}
+
+			It("should stream each message as it is received", func() {
+				resp := watch()
+				defer resp.BodyStream.Close()
+
+				// The stream stays open waiting for status changes, so the first message must arrive before the body ends
+				prefix := make([]byte, 5)
+				_, err := io.ReadFull(resp.BodyStream, prefix)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				msg := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+				_, err = io.ReadFull(resp.BodyStream, msg)
+				Expect(err).ShouldNot(HaveOccurred())
+
+				out := &grpc_health_v1.HealthCheckResponse{}
+				Expect(proto.Unmarshal(msg, out)).To(Succeed())
+				Expect(out.Status).To(Equal(grpc_health_v1.HealthCheckResponse_SERVING))
+			})
+
+			It("should be in flight until the response stream is closed", func() {
+				resp := watch()
+
+				ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+				defer cancel()
+				Expect(wrkr.Drain(ctx)).ShouldNot(Succeed())
+
+				Expect(resp.BodyStream.Close()).To(Succeed())
+				Expect(wrkr.Drain(context.Background())).To(Succeed())
+			})
+		})
+
+		When("forwarding a gRPC-Web text request", func() {
+			It("should encode the trailers in the response body", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Check",
+					Header: map[string][]string{"Content-Type": {"application/grpc-web-text"}},
+					Body:   []byte(base64.StdEncoding.EncodeToString(grpcFrame(&grpc_health_v1.HealthCheckRequest{}))),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				Expect(string(resp.Header.ContentType())).To(Equal("application/grpc-web-text"))
+
+				encoded, err := io.ReadAll(resp.BodyStream)
+				Expect(err).ShouldNot(HaveOccurred())
+				body, err := base64.StdEncoding.DecodeString(string(encoded))
+				Expect(err).ShouldNot(HaveOccurred())
+
+				msgLen := binary.BigEndian.Uint32(body[1:5])
+				trailer := body[5+msgLen:]
+				Expect(trailer[0]).To(Equal(byte(grpcWebTrailerFlag)))
+				Expect(string(trailer[5:])).To(ContainSubstring("grpc-status: 0\r\n"))
+			})
+		})
+
+		When("transcoding a JSON request", func() {
+			It("should return the JSON response", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Check",
+					Header: map[string][]string{"Content-Type": {"application/json"}},
+					Body:   []byte(`{"service": ""}`),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+				out := map[string]interface{}{}
+				Expect(json.Unmarshal(resp.Body, &out)).To(Succeed())
+				Expect(out["status"]).To(Equal("SERVING"))
+			})
+		})
+
+		When("transcoding a JSON request that fails", func() {
+			It("should map the gRPC status to an HTTP status", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Check",
+					Header: map[string][]string{"Content-Type": {"application/json"}},
+					Body:   []byte(`{"service": "unknown"}`),
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("transcoding a JSON request that is too large", func() {
+			It("should reject the request without reading all of it", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method:     http.MethodPost,
+					Path:       "/grpc.health.v1.Health/Check",
+					Header:     map[string][]string{"Content-Type": {"application/json"}},
+					BodyStream: zeroReader{},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusRequestEntityTooLarge))
+			})
+		})
+
+		When("transcoding a JSON request to an unknown method", func() {
+			It("should return not found", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/unknown.Service/Method",
+					Header: map[string][]string{"Content-Type": {"application/json"}},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+
+		When("sending an unsupported content type", func() {
+			It("should return unsupported media type", func() {
+				resp, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: http.MethodPost,
+					Path:   "/grpc.health.v1.Health/Check",
+					Header: map[string][]string{"Content-Type": {"text/plain"}},
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.StatusCode).To(Equal(http.StatusUnsupportedMediaType))
+			})
+		})
+	})
+})
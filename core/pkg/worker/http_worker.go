@@ -16,8 +16,12 @@ package worker
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,9 +30,92 @@ import (
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 )
 
+// DeadLetterHandler - called with events the child process failed to handle after all delivery attempts,
+// returning nil marks the event as handled
+type DeadLetterHandler = func(ctx context.Context, trigger *triggers.Event, cause error) error
+
+// RetryPolicy - controls redelivery of events the child process responds to with a server error
+type RetryPolicy struct {
+	// The total number of delivery attempts, including the first
+	MaxAttempts int
+	// The delay before the first retry, doubling for each subsequent retry
+	InitialBackoff time.Duration
+	// The maximum delay between retries
+	MaxBackoff time.Duration
+}
+
+// backoff - returns the delay before the given retry, starting at 1
+func (r RetryPolicy) backoff(retry int) time.Duration {
+	d := r.InitialBackoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if r.MaxBackoff > 0 && d >= r.MaxBackoff {
+			return r.MaxBackoff
+		}
+	}
+
+	if r.MaxBackoff > 0 && d > r.MaxBackoff {
+		d = r.MaxBackoff
+	}
+
+	return d
+}
+
+// The path events are delivered to when no path has been configured for their topic
+const DefaultEventPath = "/subscriptions/{topic}"
+
 // A Nitric HTTP worker
 type HttpWorker struct {
 	address string
+	// Tracks requests currently being forwarded to the child process
+	inFlight inFlightTracker
+
+	// Child process paths events are delivered to, keyed by topic (or schedule topic) name
+	eventPaths map[string]string
+	// Path template for topics without a configured path, {topic} is replaced with the topic name
+	defaultEventPath string
+	retry            RetryPolicy
+	// Adds CloudEvents binary content mode headers to delivered events
+	cloudEvents bool
+	deadLetter  DeadLetterHandler
+}
+
+type HttpWorkerOption = func(*HttpWorker)
+
+// WithEventPath - delivers events for the given topic or schedule to a path on the child process
+func WithEventPath(topic string, path string) HttpWorkerOption {
+	return func(h *HttpWorker) {
+		h.eventPaths[topic] = path
+	}
+}
+
+// WithDefaultEventPath - sets the path template for events from topics without a configured path,
+// {topic} is replaced with the name of the topic
+func WithDefaultEventPath(path string) HttpWorkerOption {
+	return func(h *HttpWorker) {
+		h.defaultEventPath = path
+	}
+}
+
+// WithEventRetries - retries event delivery with exponential backoff when the child process fails with a server error
+func WithEventRetries(policy RetryPolicy) HttpWorkerOption {
+	return func(h *HttpWorker) {
+		h.retry = policy
+	}
+}
+
+// WithCloudEvents - delivers events using the CloudEvents HTTP binary content mode
+func WithCloudEvents() HttpWorkerOption {
+	return func(h *HttpWorker) {
+		h.cloudEvents = true
+	}
+}
+
+// WithDeadLetter - handles events the child process still failed to handle after all delivery attempts
+func WithDeadLetter(handler DeadLetterHandler) HttpWorkerOption {
+	return func(h *HttpWorker) {
+		h.deadLetter = handler
+	}
 }
 
 func (s *HttpWorker) HandlesHttpRequest(trigger *triggers.HttpRequest) bool {
@@ -39,34 +126,148 @@ func (s *HttpWorker) HandlesEvent(trigger *triggers.Event) bool {
 	return true
 }
 
-// HandleEvent - Handles an event from a subscription by converting it to an HTTP request.
-func (h *HttpWorker) HandleEvent(ctx context.Context, trigger *triggers.Event) error {
-	address := fmt.Sprintf("http://%s/subscriptions/%s", h.address, trigger.Topic)
+func (s *HttpWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
+// HandleWebsocketEvent - websocket events have no HTTP representation to forward
+func (h *HttpWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("http workers cannot handle websocket events")
+}
 
+// eventPath - returns the child process path events for the given topic are delivered to
+func (h *HttpWorker) eventPath(topic string) string {
+	if path, ok := h.eventPaths[topic]; ok {
+		return path
+	}
+
+	return strings.ReplaceAll(h.defaultEventPath, "{topic}", topic)
+}
+
+// CloudEvents extension attribute names are restricted to lowercase letters and digits
+var cloudEventExtension = regexp.MustCompile("^[a-z0-9]+$")
+
+// eventRequest - creates the request delivering an event to the child process
+func (h *HttpWorker) eventRequest(trigger *triggers.Event, attempt int) *fasthttp.Request {
 	httpRequest := fasthttp.AcquireRequest()
-	httpRequest.SetRequestURI(address)
+	httpRequest.SetRequestURI(fmt.Sprintf("http://%s%s", h.address, h.eventPath(trigger.Topic)))
+	httpRequest.Header.SetMethod(fasthttp.MethodPost)
 	httpRequest.Header.Add("x-nitric-request-id", trigger.ID)
-	httpRequest.Header.Add("x-nitric-source-type", triggers.TriggerType_Subscription.String())
+	httpRequest.Header.Add("x-nitric-source-type", trigger.SourceType().String())
 	httpRequest.Header.Add("x-nitric-source", trigger.Topic)
+	httpRequest.Header.Add("x-nitric-delivery-attempt", strconv.Itoa(attempt))
 
-	var resp fasthttp.Response
+	if h.cloudEvents {
+		httpRequest.Header.Add("ce-specversion", "1.0")
+		httpRequest.Header.Add("ce-id", trigger.ID)
+		if trigger.Schedule {
+			httpRequest.Header.Add("ce-source", fmt.Sprintf("/schedules/%s", trigger.Topic))
+			httpRequest.Header.Add("ce-type", "nitric.schedule.event")
+		} else {
+			httpRequest.Header.Add("ce-source", fmt.Sprintf("/topics/%s", trigger.Topic))
+			httpRequest.Header.Add("ce-type", "nitric.topic.event")
+		}
+
+		eventTime := trigger.Time
+		if eventTime.IsZero() {
+			eventTime = time.Now()
+		}
+		httpRequest.Header.Add("ce-time", eventTime.UTC().Format(time.RFC3339Nano))
+
+		for key, val := range trigger.Attributes {
+			name := strings.ToLower(key)
+			// Attributes that can't be represented as extensions, or would replace a required attribute, are dropped
+			if !cloudEventExtension.MatchString(name) || name == "specversion" || name == "id" || name == "source" || name == "type" || name == "time" {
+				continue
+			}
+			httpRequest.Header.Add("ce-"+name, val)
+		}
+
+		if json.Valid(trigger.Payload) {
+			httpRequest.Header.SetContentType("application/json")
+		} else {
+			httpRequest.Header.SetContentType("application/octet-stream")
+		}
+	}
 
 	httpRequest.SetBody(trigger.Payload)
 	httpRequest.Header.SetContentLength(len(trigger.Payload))
 
-	// TODO: Handle response or error and respond appropriately
-	err := fasthttp.Do(httpRequest, &resp)
-	if err == nil && resp.StatusCode() >= 200 && resp.StatusCode() <= 299 {
-		return nil
+	return httpRequest
+}
+
+// deliverEvent - makes a single attempt to deliver an event, reporting whether a failure is worth retrying
+func (h *HttpWorker) deliverEvent(ctx context.Context, trigger *triggers.Event, attempt int) (bool, error) {
+	httpRequest := h.eventRequest(trigger, attempt)
+	defer fasthttp.ReleaseRequest(httpRequest)
+
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	var err error
+	if deadline, ok := ctx.Deadline(); ok {
+		err = fasthttp.DoDeadline(httpRequest, resp, deadline)
+	} else {
+		err = fasthttp.Do(httpRequest, resp)
 	}
+
 	if err != nil {
-		return errors.Wrapf(err, "Error processing event (%d): %s", resp.StatusCode(), string(resp.Body()))
+		return true, errors.Wrap(err, "Error processing event")
+	}
+
+	if resp.StatusCode() >= 200 && resp.StatusCode() <= 299 {
+		return false, nil
+	}
+
+	return resp.StatusCode() >= 500, errors.Errorf("Error processing event (%d): %s", resp.StatusCode(), string(resp.Body()))
+}
+
+// HandleEvent - Handles an event from a subscription or schedule by converting it to an HTTP request.
+func (h *HttpWorker) HandleEvent(ctx context.Context, trigger *triggers.Event) error {
+	if err := h.inFlight.start(); err != nil {
+		return err
+	}
+	defer h.inFlight.done()
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retryable bool
+		retryable, err = h.deliverEvent(ctx, trigger, attempt)
+		if err == nil {
+			return nil
+		}
+
+		if !retryable || attempt >= h.retry.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(h.retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Wrapf(ctx.Err(), "event delivery cancelled after %d attempts: %s", attempt, err)
+		case <-timer.C:
+		}
+	}
+
+	if h.deadLetter != nil {
+		if dlErr := h.deadLetter(ctx, trigger, err); dlErr != nil {
+			return errors.Wrapf(dlErr, "unable to dead letter event: %s", err)
+		}
+
+		return nil
 	}
-	return errors.Errorf("Error processing event (%d): %s", resp.StatusCode(), string(resp.Body()))
+
+	return err
 }
 
 // HandleHttpRequest - Handles an HTTP request by forwarding it as an HTTP request.
 func (h *HttpWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	if err := h.inFlight.start(); err != nil {
+		return nil, err
+	}
+	defer h.inFlight.done()
+
 	address := fmt.Sprintf("http://%s%s", h.address, trigger.Path)
 
 	httpRequest := fasthttp.AcquireRequest()
@@ -85,8 +286,13 @@ func (h *HttpWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.Ht
 	}
 
 	httpRequest.Header.Del("Content-Length")
-	httpRequest.SetBody(trigger.Body)
-	httpRequest.Header.SetContentLength(len(trigger.Body))
+	if trigger.BodyStream != nil {
+		// Sent to the child process with chunked transfer encoding
+		httpRequest.SetBodyStream(trigger.BodyStream, -1)
+	} else {
+		httpRequest.SetBody(trigger.Body)
+		httpRequest.Header.SetContentLength(len(trigger.Body))
+	}
 
 	var resp fasthttp.Response
 	err := fasthttp.Do(httpRequest, &resp)
@@ -97,10 +303,18 @@ func (h *HttpWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.Ht
 	return triggers.FromHttpResponse(&resp), nil
 }
 
-// Creates a new HttpWorker
-// Will wait to ensure that the provided address is dialable
-// before proceeding
-func NewHttpWorker(address string) (*HttpWorker, error) {
+// Saturated - the child process applies its own concurrency limits
+func (h *HttpWorker) Saturated() bool {
+	return false
+}
+
+// Drain - Stops accepting new triggers and waits for requests to the child process to complete
+func (h *HttpWorker) Drain(ctx context.Context) error {
+	return h.inFlight.drain(ctx)
+}
+
+// waitForAddress - waits for the child process to accept connections on the given address, returning false if it doesn't in time
+func waitForAddress(address string) bool {
 	// Dial the child port to see if it's open and ready...
 	maxWaitTime := time.Duration(5) * time.Second
 	// Longer poll times, e.g. 200 milliseconds results in slow lambda cold starts (15s+)
@@ -111,19 +325,39 @@ func NewHttpWorker(address string) (*HttpWorker, error) {
 		conn, _ := net.Dial("tcp", address)
 		if conn != nil {
 			conn.Close()
-			break
+			return true
 		} else {
 			if waitedTime < maxWaitTime {
 				time.Sleep(pollInterval)
 				waitedTime += pollInterval
 			} else {
-				return nil, fmt.Errorf("Unable to dial http worker, does it expose a http server at: %s?", address)
+				return false
 			}
 		}
 	}
+}
+
+// Creates a new HttpWorker
+// Will wait to ensure that the provided address is dialable
+// before proceeding
+func NewHttpWorker(address string, opts ...HttpWorkerOption) (*HttpWorker, error) {
+	if !waitForAddress(address) {
+		return nil, fmt.Errorf("Unable to dial http worker, does it expose a http server at: %s?", address)
+	}
 
 	// Dial the provided address to ensure its availability
-	return &HttpWorker{
-		address: address,
-	}, nil
+	h := &HttpWorker{
+		address:          address,
+		eventPaths:       map[string]string{},
+		defaultEventPath: DefaultEventPath,
+		retry: RetryPolicy{
+			MaxAttempts: 1,
+		},
+	}
+
+	for _, o := range opts {
+		o(h)
+	}
+
+	return h, nil
 }
@@ -0,0 +1,209 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+type receivedEvent struct {
+	path   string
+	header http.Header
+	body   string
+}
+
+var _ = Describe("HttpWorker", func() {
+	var srv *httptest.Server
+	var lock sync.Mutex
+	var received []receivedEvent
+	// Status codes returned for each request in turn, the last is repeated
+	var statuses []int
+
+	BeforeEach(func() {
+		received = []receivedEvent{}
+		statuses = []int{http.StatusOK}
+
+		srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+
+			lock.Lock()
+			defer lock.Unlock()
+			received = append(received, receivedEvent{path: r.URL.Path, header: r.Header, body: string(body)})
+			status := statuses[0]
+			if len(statuses) > 1 {
+				statuses = statuses[1:]
+			}
+			w.WriteHeader(status)
+		}))
+	})
+
+	AfterEach(func() {
+		srv.Close()
+	})
+
+	newWorker := func(opts ...HttpWorkerOption) *HttpWorker {
+		wrkr, err := NewHttpWorker(strings.TrimPrefix(srv.URL, "http://"), opts...)
+		Expect(err).ShouldNot(HaveOccurred())
+		return wrkr
+	}
+
+	evt := &triggers.Event{
+		ID:         "1234",
+		Topic:      "orders",
+		Payload:    []byte(`{"id":"abc"}`),
+		Attributes: map[string]string{"region": "au", "Not-Valid": "dropped"},
+	}
+
+	Context("HandleEvent", func() {
+		When("no path is configured for the topic", func() {
+			It("should deliver to the default path", func() {
+				Expect(newWorker().HandleEvent(context.TODO(), evt)).To(Succeed())
+				Expect(received).To(HaveLen(1))
+				Expect(received[0].path).To(Equal("/subscriptions/orders"))
+				Expect(received[0].body).To(Equal(`{"id":"abc"}`))
+				Expect(received[0].header.Get("x-nitric-source")).To(Equal("orders"))
+				Expect(received[0].header.Get("x-nitric-source-type")).To(Equal("SUBSCRIPTION"))
+				Expect(received[0].header.Get("ce-specversion")).To(BeEmpty())
+			})
+		})
+
+		When("a path is configured for the topic", func() {
+			It("should deliver to the configured path", func() {
+				wrkr := newWorker(WithEventPath("orders", "/hooks/orders"), WithDefaultEventPath("/events/{topic}"))
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).To(Succeed())
+				Expect(wrkr.HandleEvent(context.TODO(), &triggers.Event{ID: "2", Topic: "nightly-report"})).To(Succeed())
+				Expect(received).To(HaveLen(2))
+				Expect(received[0].path).To(Equal("/hooks/orders"))
+				Expect(received[1].path).To(Equal("/events/nightly-report"))
+			})
+		})
+
+		When("CloudEvents are enabled", func() {
+			It("should add binary content mode headers", func() {
+				Expect(newWorker(WithCloudEvents()).HandleEvent(context.TODO(), evt)).To(Succeed())
+				Expect(received).To(HaveLen(1))
+				header := received[0].header
+				Expect(header.Get("ce-specversion")).To(Equal("1.0"))
+				Expect(header.Get("ce-id")).To(Equal("1234"))
+				Expect(header.Get("ce-source")).To(Equal("/topics/orders"))
+				Expect(header.Get("ce-type")).To(Equal("nitric.topic.event"))
+				Expect(header.Get("ce-time")).ToNot(BeEmpty())
+				Expect(header.Get("ce-region")).To(Equal("au"))
+				Expect(header.Get("ce-not-valid")).To(BeEmpty())
+				Expect(header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("should use the time the event occurred at its source", func() {
+				occurred := time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC)
+				Expect(newWorker(WithCloudEvents()).HandleEvent(context.TODO(), &triggers.Event{
+					ID:      "1234",
+					Topic:   "orders",
+					Payload: []byte(`{}`),
+					Time:    occurred,
+				})).To(Succeed())
+				Expect(received).To(HaveLen(1))
+				Expect(received[0].header.Get("ce-time")).To(Equal("2022-03-04T05:06:07Z"))
+			})
+
+			It("should identify events raised by schedules", func() {
+				Expect(newWorker(WithCloudEvents()).HandleEvent(context.TODO(), &triggers.Event{
+					ID:       "1234",
+					Topic:    "nightly-report",
+					Payload:  []byte(`{}`),
+					Schedule: true,
+				})).To(Succeed())
+				Expect(received).To(HaveLen(1))
+				header := received[0].header
+				Expect(header.Get("x-nitric-source-type")).To(Equal("SCHEDULE"))
+				Expect(header.Get("ce-source")).To(Equal("/schedules/nightly-report"))
+				Expect(header.Get("ce-type")).To(Equal("nitric.schedule.event"))
+			})
+		})
+
+		When("the child fails with a server error", func() {
+			It("should retry with backoff until it succeeds", func() {
+				statuses = []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}
+				wrkr := newWorker(WithEventRetries(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).To(Succeed())
+				Expect(received).To(HaveLen(3))
+				Expect(received[2].header.Get("x-nitric-delivery-attempt")).To(Equal("3"))
+			})
+
+			It("should return an error once attempts are exhausted", func() {
+				statuses = []int{http.StatusInternalServerError}
+				wrkr := newWorker(WithEventRetries(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}))
+
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).ShouldNot(Succeed())
+				Expect(received).To(HaveLen(2))
+			})
+		})
+
+		When("the child fails with a client error", func() {
+			It("should not retry", func() {
+				statuses = []int{http.StatusBadRequest}
+				wrkr := newWorker(WithEventRetries(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).ShouldNot(Succeed())
+				Expect(received).To(HaveLen(1))
+			})
+		})
+
+		When("a dead letter handler is configured", func() {
+			It("should dead letter events that failed every attempt", func() {
+				statuses = []int{http.StatusInternalServerError}
+				var deadLettered *triggers.Event
+				wrkr := newWorker(WithDeadLetter(func(ctx context.Context, trigger *triggers.Event, cause error) error {
+					deadLettered = trigger
+					return nil
+				}))
+
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).To(Succeed())
+				Expect(deadLettered).To(Equal(evt))
+			})
+
+			It("should return an error if dead lettering fails", func() {
+				statuses = []int{http.StatusInternalServerError}
+				wrkr := newWorker(WithDeadLetter(func(ctx context.Context, trigger *triggers.Event, cause error) error {
+					return errors.New("unavailable")
+				}))
+
+				Expect(wrkr.HandleEvent(context.TODO(), evt)).ShouldNot(Succeed())
+			})
+		})
+	})
+
+	Context("RetryPolicy", func() {
+		It("should double the backoff up to the maximum", func() {
+			policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+			Expect(policy.backoff(1)).To(Equal(100 * time.Millisecond))
+			Expect(policy.backoff(2)).To(Equal(200 * time.Millisecond))
+			Expect(policy.backoff(4)).To(Equal(800 * time.Millisecond))
+			Expect(policy.backoff(5)).To(Equal(time.Second))
+		})
+	})
+})
@@ -0,0 +1,68 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// inFlightTracker - tracks triggers being handled by a worker, refusing new triggers once draining has begun
+type inFlightTracker struct {
+	// Guards draining and additions to inFlight, so no trigger is added while drain is waiting
+	lock     sync.Mutex
+	draining bool
+	inFlight sync.WaitGroup
+}
+
+// start - Tracks a new trigger, returning ErrDraining if the worker is draining
+func (t *inFlightTracker) start() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.draining {
+		return ErrDraining
+	}
+
+	t.inFlight.Add(1)
+
+	return nil
+}
+
+// done - Marks a trigger tracked by start as complete
+func (t *inFlightTracker) done() {
+	t.inFlight.Done()
+}
+
+// drain - Stops tracking new triggers and waits for in-flight triggers to complete
+func (t *inFlightTracker) drain(ctx context.Context) error {
+	t.lock.Lock()
+	t.draining = true
+	t.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("triggers still in flight: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("inFlightTracker", func() {
+	When("draining with a trigger in flight", func() {
+		It("should wait for the trigger to complete", func() {
+			t := &inFlightTracker{}
+			Expect(t.start()).To(Succeed())
+
+			drained := make(chan error, 1)
+			go func() {
+				drained <- t.drain(context.Background())
+			}()
+
+			Consistently(drained, 50*time.Millisecond).ShouldNot(Receive())
+
+			t.done()
+
+			Eventually(drained).Should(Receive(BeNil()))
+		})
+
+		It("should return an error if the trigger doesn't complete before the context is done", func() {
+			t := &inFlightTracker{}
+			Expect(t.start()).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			Expect(t.drain(ctx)).To(MatchError(ContainSubstring("triggers still in flight")))
+		})
+	})
+
+	When("starting a trigger after draining has begun", func() {
+		It("should return ErrDraining", func() {
+			t := &inFlightTracker{}
+			Expect(t.drain(context.Background())).To(Succeed())
+
+			Expect(t.start()).To(Equal(ErrDraining))
+		})
+	})
+})
@@ -16,6 +16,8 @@ package worker
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
@@ -23,18 +25,29 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/nitrictech/nitric/core/pkg/span"
+	"github.com/nitrictech/nitric/core/pkg/telemetry"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 )
 
 type instrumentedWorker struct {
 	Worker
+	// The route template this worker serves, used to label trigger metrics
+	route string
 }
 
 var _ Worker = &instrumentedWorker{}
 
+// Unwrap - returns the worker being instrumented
+func (a *instrumentedWorker) Unwrap() Worker {
+	return a.Worker
+}
+
 func InstrumentedWorkerFn(w Worker) Worker {
+	_, route := workerRoute(w)
+
 	return &instrumentedWorker{
 		Worker: w,
+		route:  route,
 	}
 }
 
@@ -54,7 +67,14 @@ func (a *instrumentedWorker) HandleEvent(ctx context.Context, trigger *triggers.
 
 	defer s.End()
 
+	start := time.Now()
+
 	err := a.Worker.HandleEvent(ctx, trigger)
+
+	telemetry.RecordTrigger(ctx, start, err,
+		telemetry.TriggerTypeKey.String(triggers.TriggerType_Subscription.String()),
+		telemetry.TriggerNameKey.String(trigger.Topic),
+	)
 	if err != nil {
 		s.SetStatus(codes.Error, "Event Handler returned an error")
 		s.RecordError(err)
@@ -65,6 +85,39 @@ func (a *instrumentedWorker) HandleEvent(ctx context.Context, trigger *triggers.
 	return err
 }
 
+// HandleWebsocketEvent implements worker.Adapter
+func (a *instrumentedWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	var s trace.Span
+
+	ctx, s = otel.Tracer("membrane/pkg/worker", trace.WithInstrumentationVersion(span.MembraneVersion)).
+		Start(ctx, span.Name("websocket-"+trigger.Socket+"-"+strings.ToLower(trigger.Event.String())))
+
+	s.SetAttributes(
+		semconv.CodeFunctionKey.String("HandleWebsocketEvent"),
+		semconv.MessagingDestinationKey.String(trigger.Socket),
+		semconv.MessagingConversationIDKey.String(trigger.ConnectionID),
+	)
+
+	defer s.End()
+
+	start := time.Now()
+
+	resp, err := a.Worker.HandleWebsocketEvent(ctx, trigger)
+
+	telemetry.RecordTrigger(ctx, start, err,
+		telemetry.TriggerTypeKey.String(triggers.TriggerType_Websocket.String()),
+		telemetry.TriggerNameKey.String(trigger.Socket),
+	)
+	if err != nil {
+		s.SetStatus(codes.Error, "Websocket Handler returned an error")
+		s.RecordError(err)
+	} else {
+		s.SetStatus(codes.Ok, "Websocket Event Handled Successfully")
+	}
+
+	return resp, err
+}
+
 // HandleHttpRequest implements worker.Adapter
 func (a *instrumentedWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
 	var s trace.Span
@@ -81,7 +134,15 @@ func (a *instrumentedWorker) HandleHttpRequest(ctx context.Context, trigger *tri
 
 	defer s.End()
 
+	start := time.Now()
+
 	resp, err := a.Worker.HandleHttpRequest(ctx, trigger)
+
+	telemetry.RecordTrigger(ctx, start, err,
+		telemetry.TriggerTypeKey.String(triggers.TriggerType_Request.String()),
+		telemetry.TriggerNameKey.String(a.route),
+		semconv.HTTPMethodKey.String(trigger.Method),
+	)
 	if err != nil {
 		s.SetStatus(codes.Error, "Request Handler returned an error")
 		s.RecordError(err)
@@ -0,0 +1,113 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/nitrictech/nitric/core/pkg/metrics"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+type meteredWorker struct {
+	Worker
+	// Labels identifying the route this worker serves
+	api   string
+	route string
+}
+
+var _ Worker = &meteredWorker{}
+
+// Unwrap - returns the worker being metered
+func (a *meteredWorker) Unwrap() Worker {
+	return a.Worker
+}
+
+// MeteredWorkerFn - Wraps a worker, recording trigger counts, latencies and errors
+func MeteredWorkerFn(w Worker) Worker {
+	api, route := workerRoute(w)
+
+	return &meteredWorker{
+		Worker: w,
+		api:    api,
+		route:  route,
+	}
+}
+
+// workerRoute - returns the api and route template a worker serves, used to label metrics.
+// Labelling by route template rather than request path keeps metric cardinality bounded.
+func workerRoute(w Worker) (string, string) {
+	if rw, ok := unwrapWorker(w).(*RouteWorker); ok {
+		return rw.Api(), rw.Path()
+	}
+
+	// Catch all workers are not bound to a specific route
+	return "", "*"
+}
+
+// HandleEvent implements worker.Adapter
+func (a *meteredWorker) HandleEvent(ctx context.Context, trigger *triggers.Event) error {
+	inFlight := metrics.TriggersInFlight.WithLabelValues(triggers.TriggerType_Subscription.String())
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	start := time.Now()
+
+	err := a.Worker.HandleEvent(ctx, trigger)
+
+	metrics.EventDuration.WithLabelValues(trigger.Topic).Observe(time.Since(start).Seconds())
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	metrics.Events.WithLabelValues(trigger.Topic, status).Inc()
+
+	return err
+}
+
+// HandleWebsocketEvent implements worker.Adapter
+func (a *meteredWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	inFlight := metrics.TriggersInFlight.WithLabelValues(triggers.TriggerType_Websocket.String())
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	return a.Worker.HandleWebsocketEvent(ctx, trigger)
+}
+
+// HandleHttpRequest implements worker.Adapter
+func (a *meteredWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
+	inFlight := metrics.TriggersInFlight.WithLabelValues(triggers.TriggerType_Request.String())
+	inFlight.Inc()
+	defer inFlight.Dec()
+
+	start := time.Now()
+
+	resp, err := a.Worker.HandleHttpRequest(ctx, trigger)
+
+	metrics.HttpRequestDuration.WithLabelValues(a.api, a.route, trigger.Method).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil && resp != nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	metrics.HttpRequests.WithLabelValues(a.api, a.route, trigger.Method, status).Inc()
+
+	return resp, err
+}
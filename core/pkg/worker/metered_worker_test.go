@@ -0,0 +1,102 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	mock_worker "github.com/nitrictech/nitric/core/mocks/worker"
+	"github.com/nitrictech/nitric/core/pkg/metrics"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+var _ = Describe("meteredWorker", func() {
+	Context("HandleHttpRequest", func() {
+		When("wrapping a route worker", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			adapter := mock_worker.NewMockAdapter(ctrl)
+			rw := NewRouteWorker(adapter, &RouteWorkerOptions{
+				Api:     "metered",
+				Path:    "/customers/:id",
+				Methods: []string{"GET"},
+			})
+			mw := MeteredWorkerFn(rw)
+
+			It("should count the request against the route template", func() {
+				adapter.EXPECT().HandleHttpRequest(gomock.Any(), gomock.Any()).Return(&triggers.HttpResponse{
+					StatusCode: 200,
+				}, nil)
+
+				_, err := mw.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: "GET",
+					Path:   "/customers/1",
+				})
+				Expect(err).ShouldNot(HaveOccurred())
+
+				Expect(testutil.ToFloat64(metrics.HttpRequests.WithLabelValues("metered", "/customers/:id", "GET", "200"))).To(Equal(1.0))
+
+				ctrl.Finish()
+			})
+		})
+	})
+
+	Context("HandleEvent", func() {
+		When("the wrapped worker returns an error", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			wkr := mock_worker.NewMockWorker(ctrl)
+			mw := MeteredWorkerFn(wkr)
+
+			It("should count the event as an error", func() {
+				wkr.EXPECT().HandleEvent(gomock.Any(), gomock.Any()).Return(fmt.Errorf("mock error"))
+
+				err := mw.HandleEvent(context.TODO(), &triggers.Event{Topic: "metered-topic"})
+				Expect(err).Should(HaveOccurred())
+
+				Expect(testutil.ToFloat64(metrics.Events.WithLabelValues("metered-topic", "error"))).To(Equal(1.0))
+
+				ctrl.Finish()
+			})
+		})
+	})
+})
+
+var _ = Describe("InstrumentedWorkerPool", func() {
+	Context("RemoveWorker", func() {
+		When("removing a worker that was wrapped when added", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			wkr := mock_worker.NewMockWorker(ctrl)
+			pool := &InstrumentedWorkerPool{
+				WorkerPool: NewProcessPool(&ProcessPoolOptions{MaxWorkers: 1}),
+				Wrapper:    MeteredWorkerFn,
+			}
+
+			It("should remove the wrapped worker", func() {
+				Expect(pool.AddWorker(wkr)).ShouldNot(HaveOccurred())
+				Expect(pool.GetWorkerCount()).To(Equal(1))
+
+				Expect(pool.RemoveWorker(wkr)).ShouldNot(HaveOccurred())
+				Expect(pool.GetWorkerCount()).To(Equal(0))
+
+				ctrl.Finish()
+			})
+		})
+	})
+})
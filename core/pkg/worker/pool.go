@@ -15,7 +15,9 @@
 package worker
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -31,20 +33,27 @@ type WorkerPool interface {
 	AddWorker(Worker) error
 	RemoveWorker(Worker) error
 	Monitor() error
+	// Drain - Stop handing out workers and block until in-flight triggers have completed
+	Drain(ctx context.Context) error
 }
 
 type ProcessPoolOptions struct {
 	MinWorkers int
 	MaxWorkers int
+	// QueueWhenSaturated - return a saturated worker from GetWorker rather than ErrSaturated,
+	// so the trigger waits for the worker to have capacity within the worker's own queue timeout
+	QueueWhenSaturated bool
 }
 
 // ProcessPool - A worker pool that represent co-located processes
 type ProcessPool struct {
-	minWorkers int
-	maxWorkers int
-	workerLock sync.Locker
-	workers    []Worker
-	poolErr    chan error
+	minWorkers         int
+	maxWorkers         int
+	queueWhenSaturated bool
+	workerLock         sync.Locker
+	workers            []Worker
+	poolErr            chan error
+	draining           bool
 }
 
 func (p *ProcessPool) GetWorkerCount() int {
@@ -57,26 +66,59 @@ func prepend(slice []Worker, elems ...Worker) []Worker {
 	return append(elems, slice...)
 }
 
+// wrappedWorker - a worker decorating another worker, e.g. with telemetry
+type wrappedWorker interface {
+	Unwrap() Worker
+}
+
+// unwrapWorker - returns the innermost worker of any wrapped workers
+func unwrapWorker(w Worker) Worker {
+	for {
+		ww, ok := w.(wrappedWorker)
+		if !ok {
+			return w
+		}
+		w = ww.Unwrap()
+	}
+}
+
 // return route workers
 func (p *ProcessPool) getHttpWorkers() []Worker {
+	rws := make([]Worker, 0)
 	hws := make([]Worker, 0)
 
 	for _, w := range p.workers {
-		switch w.(type) {
+		switch unwrapWorker(w).(type) {
 		case *ScheduleWorker:
 			break
 		case *SubscriptionWorker:
 			break
 		case *RouteWorker:
 			// Prioritise Route Workers
-			hws = prepend(hws, w)
-			break
+			rws = append(rws, w)
 		default:
 			hws = append(hws, w)
 		}
 	}
 
-	return hws
+	// Order routes most specific first, so requests matching several routes resolve to the same one regardless of registration order
+	sort.SliceStable(rws, func(i, j int) bool {
+		return unwrapWorker(rws[i]).(*RouteWorker).moreSpecificThan(unwrapWorker(rws[j]).(*RouteWorker))
+	})
+
+	return append(rws, hws...)
+}
+
+// sameRoute - returns true if both workers serve the same API route, or are both not route workers
+func sameRoute(a Worker, b Worker) bool {
+	ar, aok := unwrapWorker(a).(*RouteWorker)
+	br, bok := unwrapWorker(b).(*RouteWorker)
+
+	if !aok || !bok {
+		return aok == bok
+	}
+
+	return ar.api == br.api && ar.path == br.path
 }
 
 // return route workers
@@ -84,7 +126,7 @@ func (p *ProcessPool) getEventWorkers() []Worker {
 	hws := make([]Worker, 0)
 
 	for _, w := range p.workers {
-		switch w.(type) {
+		switch unwrapWorker(w).(type) {
 		case *RouteWorker:
 			// Ignore route workers
 			break
@@ -144,9 +186,10 @@ func (p *ProcessPool) WaitForMinimumWorkers(timeout int) error {
 }
 
 type GetWorkerOptions struct {
-	Http   *triggers.HttpRequest
-	Event  *triggers.Event
-	Filter func(w Worker) bool
+	Http      *triggers.HttpRequest
+	Event     *triggers.Event
+	Websocket *triggers.WebsocketEvent
+	Filter    func(w Worker) bool
 }
 
 func filterWorkers(ws []Worker, f func(w Worker) bool) []Worker {
@@ -180,6 +223,12 @@ func (p *ProcessPool) GetWorkers(opts *GetWorkerOptions) []Worker {
 		})
 	}
 
+	if opts.Websocket != nil {
+		workers = filterWorkers(workers, func(w Worker) bool {
+			return w.HandlesWebsocketEvent(opts.Websocket)
+		})
+	}
+
 	if opts.Filter != nil {
 		workers = filterWorkers(workers, opts.Filter)
 	}
@@ -187,11 +236,19 @@ func (p *ProcessPool) GetWorkers(opts *GetWorkerOptions) []Worker {
 	return workers
 }
 
-// GetWorker - Retrieves a worker from this pool
+// GetWorker - Retrieves a worker from this pool. If matching workers are all at capacity ErrSaturated is returned,
+// or the first of them when the pool queues triggers on saturated workers.
 func (p *ProcessPool) GetWorker(opts *GetWorkerOptions) (Worker, error) {
 	p.workerLock.Lock()
 	defer p.workerLock.Unlock()
 
+	if p.draining {
+		return nil, ErrDraining
+	}
+
+	// The first worker that could handle the trigger but is already handling as many triggers as it can
+	var saturated Worker
+
 	if opts.Http != nil {
 		ws := p.getHttpWorkers()
 
@@ -199,10 +256,25 @@ func (p *ProcessPool) GetWorker(opts *GetWorkerOptions) (Worker, error) {
 			ws = filterWorkers(ws, opts.Filter)
 		}
 
+		var matched Worker
 		for _, w := range ws {
-			if w.HandlesHttpRequest(opts.Http) {
-				return w, nil
+			if !w.HandlesHttpRequest(opts.Http) {
+				continue
+			}
+
+			// Only fall back to workers serving the same route, less specific routes must not handle the request
+			if matched != nil && !sameRoute(matched, w) {
+				break
+			}
+			matched = w
+
+			if w.Saturated() {
+				if saturated == nil {
+					saturated = w
+				}
+				continue
 			}
+			return w, nil
 		}
 	}
 
@@ -215,11 +287,45 @@ func (p *ProcessPool) GetWorker(opts *GetWorkerOptions) (Worker, error) {
 
 		for _, w := range ws {
 			if w.HandlesEvent(opts.Event) {
+				if w.Saturated() {
+					if saturated == nil {
+						saturated = w
+					}
+					continue
+				}
 				return w, nil
 			}
 		}
 	}
 
+	if opts.Websocket != nil {
+		ws := p.workers
+
+		if opts.Filter != nil {
+			ws = filterWorkers(ws, opts.Filter)
+		}
+
+		for _, w := range ws {
+			if w.HandlesWebsocketEvent(opts.Websocket) {
+				if w.Saturated() {
+					if saturated == nil {
+						saturated = w
+					}
+					continue
+				}
+				return w, nil
+			}
+		}
+	}
+
+	if saturated != nil {
+		if p.queueWhenSaturated {
+			return saturated, nil
+		}
+
+		return nil, ErrSaturated
+	}
+
 	return nil, fmt.Errorf("no valid workers available")
 }
 
@@ -231,7 +337,8 @@ func (p *ProcessPool) RemoveWorker(wrkr Worker) error {
 	for i, w := range p.workers {
 		if wrkr == w {
 			p.workers = append(p.workers[:i], p.workers[i+1:]...)
-			if len(p.workers) < p.minWorkers {
+			// Workers are expected to leave a draining pool
+			if !p.draining && len(p.workers) < p.minWorkers {
 				p.poolErr <- fmt.Errorf("insufficient workers in pool, need minimum of %d, %d available", p.minWorkers, len(p.workers))
 			}
 
@@ -247,6 +354,10 @@ func (p *ProcessPool) AddWorker(wrkr Worker) error {
 	p.workerLock.Lock()
 	defer p.workerLock.Unlock()
 
+	if p.draining {
+		return ErrDraining
+	}
+
 	workerCount := len(p.workers)
 
 	// Ensure we haven't reached the maximum number of workers
@@ -259,6 +370,30 @@ func (p *ProcessPool) AddWorker(wrkr Worker) error {
 	return nil
 }
 
+// Drain - Stops handing out workers and waits for all workers in this pool to complete their in-flight triggers
+func (p *ProcessPool) Drain(ctx context.Context) error {
+	p.workerLock.Lock()
+	p.draining = true
+	workers := append([]Worker{}, p.workers...)
+	p.workerLock.Unlock()
+
+	errs := make(chan error, len(workers))
+	for _, w := range workers {
+		go func(w Worker) {
+			errs <- w.Drain(ctx)
+		}(w)
+	}
+
+	var drainErr error
+	for range workers {
+		if err := <-errs; err != nil && drainErr == nil {
+			drainErr = err
+		}
+	}
+
+	return drainErr
+}
+
 // NewProcessPool - Creates a new process pool
 func NewProcessPool(opts *ProcessPoolOptions) WorkerPool {
 	if opts.MaxWorkers < 1 {
@@ -266,10 +401,11 @@ func NewProcessPool(opts *ProcessPoolOptions) WorkerPool {
 	}
 
 	return &ProcessPool{
-		minWorkers: opts.MinWorkers,
-		maxWorkers: opts.MaxWorkers,
-		workerLock: &sync.Mutex{},
-		workers:    make([]Worker, 0),
-		poolErr:    make(chan error),
+		minWorkers:         opts.MinWorkers,
+		maxWorkers:         opts.MaxWorkers,
+		queueWhenSaturated: opts.QueueWhenSaturated,
+		workerLock:         &sync.Mutex{},
+		workers:            make([]Worker, 0),
+		poolErr:            make(chan error),
 	}
 }
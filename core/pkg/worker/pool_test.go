@@ -15,6 +15,7 @@
 package worker
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -79,6 +80,47 @@ var _ = Describe("ProcessPool", func() {
 					Expect(wrkrs[1]).To(Equal(fw))
 				})
 			})
+
+			When("pool contains wrapped workers", func() {
+				hw := &RouteWorker{}
+				ew := &SubscriptionWorker{}
+				fw := &FaasWorker{}
+
+				pp := &ProcessPool{
+					maxWorkers: 3,
+					workerLock: &sync.Mutex{},
+					workers:    []Worker{MeteredWorkerFn(fw), MeteredWorkerFn(ew), MeteredWorkerFn(hw)},
+				}
+
+				wrkrs := pp.getHttpWorkers()
+
+				It("should prioritise the wrapped route workers", func() {
+					Expect(wrkrs).To(HaveLen(2))
+					Expect(unwrapWorker(wrkrs[0])).To(Equal(hw))
+					Expect(unwrapWorker(wrkrs[1])).To(Equal(fw))
+				})
+			})
+
+			When("pool contains route workers matching the same request", func() {
+				wildcard := NewRouteWorker(nil, &RouteWorkerOptions{Path: "/users/*rest"})
+				param := NewRouteWorker(nil, &RouteWorkerOptions{Path: "/users/:id"})
+				static := NewRouteWorker(nil, &RouteWorkerOptions{Path: "/users/me"})
+
+				pp := &ProcessPool{
+					maxWorkers: 3,
+					workerLock: &sync.Mutex{},
+					workers:    []Worker{wildcard, MeteredWorkerFn(param), static},
+				}
+
+				wrkrs := pp.getHttpWorkers()
+
+				It("should order the routes by specificity", func() {
+					Expect(wrkrs).To(HaveLen(3))
+					Expect(wrkrs[0]).To(Equal(static))
+					Expect(unwrapWorker(wrkrs[1])).To(Equal(param))
+					Expect(wrkrs[2]).To(Equal(wildcard))
+				})
+			})
 		})
 
 		Context("getEventWorkers", func() {
@@ -221,6 +263,9 @@ var _ = Describe("ProcessPool", func() {
 						By("Querying testing the worker with the trigger")
 						hw.EXPECT().HandlesHttpRequest(tr).Return(true).Times(1)
 
+						By("checking the worker has capacity")
+						hw.EXPECT().Saturated().Return(false).Times(1)
+
 						By("returning a nil worker")
 						wrkr, err := pp.GetWorker(&GetWorkerOptions{Http: tr})
 						Expect(wrkr).To(Equal(hw))
@@ -231,6 +276,83 @@ var _ = Describe("ProcessPool", func() {
 				})
 			})
 
+			Context("Getting a worker for a Http trigger when workers are saturated", func() {
+				When("another worker serving the same route has capacity", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					busy := mock_worker.NewMockAdapter(ctrl)
+					idle := mock_worker.NewMockAdapter(ctrl)
+					busyRoute := NewRouteWorker(busy, &RouteWorkerOptions{Path: "/users/:id", Methods: []string{"GET"}})
+					idleRoute := NewRouteWorker(idle, &RouteWorkerOptions{Path: "/users/:id", Methods: []string{"GET"}})
+					pp := &ProcessPool{workers: []Worker{busyRoute, idleRoute}, workerLock: &sync.Mutex{}}
+
+					It("should return the worker with capacity", func() {
+						busy.EXPECT().Saturated().Return(true).Times(1)
+						idle.EXPECT().Saturated().Return(false).Times(1)
+
+						wrkr, err := pp.GetWorker(&GetWorkerOptions{Http: &triggers.HttpRequest{Method: "GET", Path: "/users/1"}})
+						Expect(err).ShouldNot(HaveOccurred())
+						Expect(wrkr).To(Equal(idleRoute))
+
+						ctrl.Finish()
+					})
+				})
+
+				When("only a less specific route has capacity", func() {
+					ctrl := gomock.NewController(GinkgoT())
+					busy := mock_worker.NewMockAdapter(ctrl)
+					idle := mock_worker.NewMockAdapter(ctrl)
+					busyRoute := NewRouteWorker(busy, &RouteWorkerOptions{Path: "/users/:id", Methods: []string{"GET"}})
+					idleRoute := NewRouteWorker(idle, &RouteWorkerOptions{Path: "/users/*rest", Methods: []string{"GET"}})
+					pp := &ProcessPool{workers: []Worker{idleRoute, busyRoute}, workerLock: &sync.Mutex{}}
+
+					It("should return ErrSaturated", func() {
+						busy.EXPECT().Saturated().Return(true).Times(1)
+
+						wrkr, err := pp.GetWorker(&GetWorkerOptions{Http: &triggers.HttpRequest{Method: "GET", Path: "/users/1"}})
+						Expect(wrkr).To(BeNil())
+						Expect(err).To(Equal(ErrSaturated))
+
+						ctrl.Finish()
+					})
+				})
+			})
+
+			Context("Getting a worker for an Event trigger when every worker is saturated", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hw := mock_worker.NewMockWorker(ctrl)
+				pp := &ProcessPool{workers: []Worker{hw}, workerLock: &sync.Mutex{}}
+				tr := &triggers.Event{}
+
+				It("should return ErrSaturated", func() {
+					hw.EXPECT().HandlesEvent(tr).Return(true).Times(1)
+					hw.EXPECT().Saturated().Return(true).Times(1)
+
+					wrkr, err := pp.GetWorker(&GetWorkerOptions{Event: tr})
+					Expect(wrkr).To(BeNil())
+					Expect(err).To(Equal(ErrSaturated))
+
+					ctrl.Finish()
+				})
+			})
+
+			Context("Getting a worker for an Event trigger when the pool queues triggers on saturated workers", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hw := mock_worker.NewMockWorker(ctrl)
+				pp := &ProcessPool{workers: []Worker{hw}, workerLock: &sync.Mutex{}, queueWhenSaturated: true}
+				tr := &triggers.Event{}
+
+				It("should return the saturated worker for the trigger to wait on", func() {
+					hw.EXPECT().HandlesEvent(tr).Return(true).Times(1)
+					hw.EXPECT().Saturated().Return(true).Times(1)
+
+					wrkr, err := pp.GetWorker(&GetWorkerOptions{Event: tr})
+					Expect(err).ShouldNot(HaveOccurred())
+					Expect(wrkr).To(Equal(hw))
+
+					ctrl.Finish()
+				})
+			})
+
 			Context("Getting a worker for an Event trigger", func() {
 				When("no compatible event workers are available", func() {
 					When("no compatible workers are available", func() {
@@ -261,6 +383,9 @@ var _ = Describe("ProcessPool", func() {
 							By("Querying testing the worker with the trigger")
 							hw.EXPECT().HandlesEvent(tr).Return(true).Times(1)
 
+							By("checking the worker has capacity")
+							hw.EXPECT().Saturated().Return(false).Times(1)
+
 							By("returning a nil worker")
 							wrkr, err := pp.GetWorker(&GetWorkerOptions{Event: tr})
 							Expect(wrkr).To(Equal(hw))
@@ -389,5 +514,50 @@ var _ = Describe("ProcessPool", func() {
 				})
 			})
 		})
+
+		Context("Drain", func() {
+			When("draining a pool with workers", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				wkr := mock_worker.NewMockWorker(ctrl)
+				pp := &ProcessPool{minWorkers: 1, maxWorkers: 2, workers: []Worker{wkr}, workerLock: &sync.Mutex{}}
+
+				It("should drain each worker and stop handing out workers", func() {
+					By("draining the worker")
+					wkr.EXPECT().Drain(gomock.Any()).Return(nil).Times(1)
+
+					err := pp.Drain(context.TODO())
+
+					By("not returning an error")
+					Expect(err).ShouldNot(HaveOccurred())
+
+					By("returning ErrDraining for new triggers")
+					_, err = pp.GetWorker(&GetWorkerOptions{Http: &triggers.HttpRequest{}})
+					Expect(err).To(Equal(ErrDraining))
+
+					By("refusing new workers")
+					Expect(pp.AddWorker(wkr)).To(Equal(ErrDraining))
+
+					By("allowing workers to leave without a pool error")
+					Expect(pp.RemoveWorker(wkr)).ShouldNot(HaveOccurred())
+
+					ctrl.Finish()
+				})
+			})
+
+			When("a worker fails to drain", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				wkr := mock_worker.NewMockWorker(ctrl)
+				pp := &ProcessPool{workers: []Worker{wkr}, workerLock: &sync.Mutex{}}
+
+				It("should return the error", func() {
+					wkr.EXPECT().Drain(gomock.Any()).Return(fmt.Errorf("mock error")).Times(1)
+
+					err := pp.Drain(context.TODO())
+					Expect(err).Should(HaveOccurred())
+
+					ctrl.Finish()
+				})
+			})
+		})
 	})
 })
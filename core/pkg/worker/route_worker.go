@@ -17,10 +17,10 @@ package worker
 import (
 	"context"
 	"fmt"
-	"strings"
 
+	"github.com/nitrictech/nitric/core/pkg/auth"
+	"github.com/nitrictech/nitric/core/pkg/routes"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
-	"github.com/nitrictech/nitric/core/pkg/utils"
 )
 
 // RouteWorker - Worker representation for an http api route handler
@@ -28,6 +28,13 @@ type RouteWorker struct {
 	api     string
 	methods []string
 	path    string
+	// Parsed form of path, nil if the path is not a valid route template
+	template *routes.Template
+
+	// Security requirements of this route, overriding the root security of the API when set
+	security         auth.Requirements
+	securityDisabled bool
+	authorizer       *auth.Authorizer
 
 	Adapter
 }
@@ -40,25 +47,61 @@ func (s *RouteWorker) Api() string {
 	return s.api
 }
 
+// WorkerApi - Retrieve the API of a route worker, seeing through any wrappers, or "" for other workers
+func WorkerApi(w Worker) string {
+	if rw, ok := unwrapWorker(w).(*RouteWorker); ok {
+		return rw.Api()
+	}
+
+	return ""
+}
+
+// Path - Retrieve the path template this
+// route worker was registered for
+func (s *RouteWorker) Path() string {
+	return s.path
+}
+
+func (s *RouteWorker) routeTemplate() (*routes.Template, error) {
+	if s.template != nil {
+		return s.template, nil
+	}
+
+	return routes.Parse(s.path)
+}
+
 func (s *RouteWorker) extractPathParams(trigger *triggers.HttpRequest) (map[string]string, error) {
-	requestPathSegments := utils.SplitPath(trigger.Path)
-	pathSegments := utils.SplitPath(s.path)
-	params := make(map[string]string)
+	template, err := s.routeTemplate()
+	if err != nil {
+		return nil, err
+	}
 
-	// TODO: Filter for trailing/leading slashes
-	if len(requestPathSegments) != len(pathSegments) {
+	params, ok := template.Match(trigger.Path)
+	if !ok {
 		return nil, fmt.Errorf("path template mismatch")
 	}
 
-	for i, p := range pathSegments {
-		if !strings.HasPrefix(p, ":") && p != requestPathSegments[i] {
-			return nil, fmt.Errorf("path template mismatch")
-		} else if strings.HasPrefix(p, ":") {
-			params[strings.Replace(p, ":", "", 1)] = requestPathSegments[i]
-		}
+	return params, nil
+}
+
+// moreSpecificThan - returns true if this route should take precedence over the given route when both match a request
+func (s *RouteWorker) moreSpecificThan(o *RouteWorker) bool {
+	t, err := s.routeTemplate()
+	if err != nil {
+		return false
 	}
 
-	return params, nil
+	ot, err := o.routeTemplate()
+	if err != nil {
+		return true
+	}
+
+	if c := t.Compare(ot); c != 0 {
+		return c > 0
+	}
+
+	// Routes of equal specificity are ordered by their templates to keep precedence independent of registration order
+	return s.path < o.path
 }
 
 func (s *RouteWorker) hasMethod(method string) bool {
@@ -76,6 +119,10 @@ func (s *RouteWorker) HandlesHttpRequest(trigger *triggers.HttpRequest) bool {
 		return false
 	}
 
+	if trigger.Api != "" && trigger.Api != s.api {
+		return false
+	}
+
 	_, err := s.extractPathParams(trigger)
 
 	return err == nil
@@ -93,6 +140,22 @@ func (s *RouteWorker) HandleHttpRequest(ctx context.Context, trigger *triggers.H
 
 	trigger.Params = params
 
+	if s.authorizer != nil && !s.securityDisabled {
+		if trigger.Claims != nil {
+			// The token was already validated by the provider's API gateway
+			if err := s.authorizer.AuthorizeClaims(s.api, s.security, trigger.Claims); err != nil {
+				return nil, err
+			}
+		} else {
+			claims, err := s.authorizer.Authorize(ctx, s.api, s.security, trigger.Header)
+			if err != nil {
+				return nil, err
+			}
+
+			trigger.Claims = claims
+		}
+	}
+
 	return s.Adapter.HandleHttpRequest(ctx, trigger)
 }
 
@@ -100,19 +163,41 @@ func (s *RouteWorker) HandleEvent(ctx context.Context, trigger *triggers.Event)
 	return fmt.Errorf("route workers cannot handle events")
 }
 
+func (s *RouteWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
+func (s *RouteWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("route workers cannot handle websocket events")
+}
+
 type RouteWorkerOptions struct {
 	Api     string
 	Path    string
 	Methods []string
+
+	// Security requirements of the route, when nil the root security of the API applies
+	Security         auth.Requirements
+	SecurityDisabled bool
+	// Validates request tokens against the declared API security, no validation is performed when nil
+	Authorizer *auth.Authorizer
 }
 
 // Package private method
 // Only a pool may create a new faas worker
 func NewRouteWorker(adapter Adapter, opts *RouteWorkerOptions) *RouteWorker {
+	// Invalid templates never match a request, servers are expected to check paths with routes.Parse
+	template, _ := routes.Parse(opts.Path)
+
 	return &RouteWorker{
-		api:     opts.Api,
-		path:    opts.Path,
-		methods: opts.Methods,
-		Adapter: adapter,
+		template: template,
+		api:      opts.Api,
+		path:     opts.Path,
+		methods:  opts.Methods,
+		Adapter:  adapter,
+
+		security:         opts.Security,
+		securityDisabled: opts.SecurityDisabled,
+		authorizer:       opts.Authorizer,
 	}
 }
@@ -16,12 +16,14 @@ package worker
 
 import (
 	"context"
+	"errors"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	mock "github.com/nitrictech/nitric/core/mocks/worker"
+	"github.com/nitrictech/nitric/core/pkg/auth"
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 )
 
@@ -59,6 +61,30 @@ var _ = Describe("RouteWorker", func() {
 			})
 		})
 
+		When("calling HandlesHttpRequest for another API", func() {
+			apiWrkr := &RouteWorker{
+				api:     "orders",
+				methods: []string{"GET"},
+				path:    "/test/:param",
+			}
+
+			It("should return false", func() {
+				Expect(apiWrkr.HandlesHttpRequest(&triggers.HttpRequest{
+					Api:    "users",
+					Method: "GET",
+					Path:   "/test/test",
+				})).To(BeFalse())
+			})
+
+			It("should return true for requests to its own API", func() {
+				Expect(apiWrkr.HandlesHttpRequest(&triggers.HttpRequest{
+					Api:    "orders",
+					Method: "GET",
+					Path:   "/test/test",
+				})).To(BeTrue())
+			})
+		})
+
 		When("calling HandleHttpRequest", func() {
 			It("should call the base grpc workers HandleEvent with augmented trigger", func() {
 				ctrl := gomock.NewController(GinkgoT())
@@ -88,6 +114,97 @@ var _ = Describe("RouteWorker", func() {
 				ctrl.Finish()
 			})
 		})
+
+		When("calling HandleHttpRequest on a secured route without a token", func() {
+			authorizer := auth.NewAuthorizer()
+			authorizer.DeclareApi("test-api", map[string]*auth.JwtDefinition{
+				"user": {Issuer: "https://example.com"},
+			}, auth.Requirements{"user": {}})
+
+			It("should return ErrUnauthenticated without calling the adapter", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hndlr := mock.NewMockAdapter(ctrl)
+
+				hndlr.EXPECT().HandleHttpRequest(gomock.Any(), gomock.Any()).Times(0)
+
+				wrkr := NewRouteWorker(hndlr, &RouteWorkerOptions{
+					Api:        "test-api",
+					Path:       "/test",
+					Methods:    []string{"GET"},
+					Authorizer: authorizer,
+				})
+
+				_, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: "GET",
+					Path:   "/test",
+				})
+
+				Expect(errors.Is(err, auth.ErrUnauthenticated)).To(BeTrue())
+				ctrl.Finish()
+			})
+
+			It("should call the adapter when security is disabled for the route", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hndlr := mock.NewMockAdapter(ctrl)
+
+				hndlr.EXPECT().HandleHttpRequest(gomock.Any(), gomock.Any()).Times(1)
+
+				wrkr := NewRouteWorker(hndlr, &RouteWorkerOptions{
+					Api:              "test-api",
+					Path:             "/test",
+					Methods:          []string{"GET"},
+					SecurityDisabled: true,
+					Authorizer:       authorizer,
+				})
+
+				_, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: "GET",
+					Path:   "/test",
+				})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				ctrl.Finish()
+			})
+
+			It("should call the adapter with claims already validated by the provider's API gateway", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hndlr := mock.NewMockAdapter(ctrl)
+
+				hndlr.EXPECT().HandleHttpRequest(gomock.Any(), gomock.Any()).Times(1)
+
+				wrkr := NewRouteWorker(hndlr, &RouteWorkerOptions{
+					Api:        "test-api",
+					Path:       "/test",
+					Methods:    []string{"GET"},
+					Authorizer: authorizer,
+				})
+
+				_, err := wrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{
+					Method: "GET",
+					Path:   "/test",
+					Claims: map[string]interface{}{"iss": "https://example.com"},
+				})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				ctrl.Finish()
+			})
+		})
+	})
+
+	When("calling WorkerApi", func() {
+		It("should return the api of route workers wrapped by the pool", func() {
+			wrkr := NewRouteWorker(nil, &RouteWorkerOptions{
+				Api:     "test-api",
+				Path:    "/test",
+				Methods: []string{"GET"},
+			})
+
+			Expect(WorkerApi(&meteredWorker{Worker: wrkr})).To(Equal("test-api"))
+		})
+
+		It("should return an empty api for other workers", func() {
+			Expect(WorkerApi(&SubscriptionWorker{})).To(Equal(""))
+		})
 	})
 
 	Context("Event", func() {
@@ -53,6 +53,14 @@ func (s *ScheduleWorker) HandleHttpRequest(ctx context.Context, trigger *trigger
 	return nil, fmt.Errorf("schedule workers cannot handle HTTP requests")
 }
 
+func (s *ScheduleWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
+func (s *ScheduleWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("schedule workers cannot handle websocket events")
+}
+
 type ScheduleWorkerOptions struct {
 	Key string
 }
@@ -47,6 +47,14 @@ func (s *SubscriptionWorker) HandleHttpRequest(ctx context.Context, trigger *tri
 	return nil, fmt.Errorf("subscription workers cannot handle HTTP requests")
 }
 
+func (s *SubscriptionWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
+func (s *SubscriptionWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("subscription workers cannot handle websocket events")
+}
+
 type SubscriptionWorkerOptions struct {
 	Topic string
 }
@@ -0,0 +1,108 @@
+// Copyright 2021 Nitric Pty Ltd.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	mock "github.com/nitrictech/nitric/core/mocks/worker"
+	"github.com/nitrictech/nitric/core/pkg/triggers"
+)
+
+var _ = Describe("WebsocketWorker", func() {
+	wsWrkr := NewWebsocketWorker(nil, &WebsocketWorkerOptions{
+		Socket: "test",
+		Event:  triggers.WebsocketEventType_Message,
+	})
+
+	Context("Http", func() {
+		When("calling HandlesHttpRequest", func() {
+			It("should return false", func() {
+				Expect(wsWrkr.HandlesHttpRequest(&triggers.HttpRequest{})).To(BeFalse())
+			})
+		})
+
+		When("calling HandleHttpRequest", func() {
+			It("should return an error", func() {
+				_, err := wsWrkr.HandleHttpRequest(context.TODO(), &triggers.HttpRequest{})
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Context("Event", func() {
+		When("calling HandlesEvent", func() {
+			It("should return false", func() {
+				Expect(wsWrkr.HandlesEvent(&triggers.Event{})).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Websocket", func() {
+		When("calling HandlesWebsocketEvent with the wrong socket", func() {
+			It("should return false", func() {
+				Expect(wsWrkr.HandlesWebsocketEvent(&triggers.WebsocketEvent{
+					Socket: "bad",
+					Event:  triggers.WebsocketEventType_Message,
+				})).To(BeFalse())
+			})
+		})
+
+		When("calling HandlesWebsocketEvent with the wrong event type", func() {
+			It("should return false", func() {
+				Expect(wsWrkr.HandlesWebsocketEvent(&triggers.WebsocketEvent{
+					Socket: "test",
+					Event:  triggers.WebsocketEventType_Connect,
+				})).To(BeFalse())
+			})
+		})
+
+		When("calling HandlesWebsocketEvent with the correct socket and event type", func() {
+			It("should return true", func() {
+				Expect(wsWrkr.HandlesWebsocketEvent(&triggers.WebsocketEvent{
+					Socket: "test",
+					Event:  triggers.WebsocketEventType_Message,
+				})).To(BeTrue())
+			})
+		})
+
+		When("calling HandleWebsocketEvent", func() {
+			It("should call the base adapters HandleWebsocketEvent", func() {
+				ctrl := gomock.NewController(GinkgoT())
+				hndlr := mock.NewMockAdapter(ctrl)
+
+				By("calling the base adapter HandleWebsocketEvent method")
+				hndlr.EXPECT().HandleWebsocketEvent(gomock.Any(), gomock.Any()).Return(&triggers.WebsocketResponse{
+					Success: true,
+				}, nil).Times(1)
+
+				wrkr := NewWebsocketWorker(hndlr, &WebsocketWorkerOptions{
+					Socket: "test",
+					Event:  triggers.WebsocketEventType_Message,
+				})
+
+				resp, err := wrkr.HandleWebsocketEvent(context.TODO(), &triggers.WebsocketEvent{})
+
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(resp.Success).To(BeTrue())
+				ctrl.Finish()
+			})
+		})
+	})
+})
@@ -15,14 +15,22 @@
 package worker
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nitrictech/nitric/core/pkg/triggers"
 )
 
+// ErrDraining - returned when a trigger is offered to a worker or pool that is shutting down
+var ErrDraining = fmt.Errorf("worker is draining and not accepting new triggers")
+
+// ErrSaturated - returned when every worker able to handle a trigger is already handling as many triggers as it can
+var ErrSaturated = fmt.Errorf("worker is at its maximum concurrency")
+
 type Delegate interface {
 	HandlesHttpRequest(trigger *triggers.HttpRequest) bool
 	HandlesEvent(trigger *triggers.Event) bool
+	HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool
 }
 
 type Worker interface {
@@ -40,6 +48,10 @@ func (*UnimplementedWorker) HandlesHttpRequest(trigger *triggers.HttpRequest) bo
 	return false
 }
 
+func (*UnimplementedWorker) HandlesWebsocketEvent(trigger *triggers.WebsocketEvent) bool {
+	return false
+}
+
 func (*UnimplementedWorker) HandleEvent(trigger *triggers.Event) error {
 	return fmt.Errorf("worker does not handle events")
 }
@@ -47,3 +59,15 @@ func (*UnimplementedWorker) HandleEvent(trigger *triggers.Event) error {
 func (*UnimplementedWorker) HandleHttpRequest(trigger *triggers.HttpRequest) (*triggers.HttpResponse, error) {
 	return nil, fmt.Errorf("worker does not handle http requests")
 }
+
+func (*UnimplementedWorker) HandleWebsocketEvent(trigger *triggers.WebsocketEvent) (*triggers.WebsocketResponse, error) {
+	return nil, fmt.Errorf("worker does not handle websocket events")
+}
+
+func (*UnimplementedWorker) Drain(ctx context.Context) error {
+	return nil
+}
+
+func (*UnimplementedWorker) Saturated() bool {
+	return false
+}
@@ -23,22 +23,28 @@ import (
 type MockWorkerOptions struct {
 	ReturnHttp *triggers2.HttpResponse
 	HttpError  error
-	eventError error
+	// Returns the error to fail the given event with, events succeed if nil
+	EventError func(trigger *triggers2.Event) error
 }
 
 // MockWorker - A mock worker interface for testing
 type MockWorker struct {
 	returnHttp       *triggers2.HttpResponse
 	httpError        error
-	eventError       error
+	eventError       func(trigger *triggers2.Event) error
 	ReceivedEvents   []*triggers2.Event
 	ReceivedRequests []*triggers2.HttpRequest
+	ReceivedSockets  []*triggers2.WebsocketEvent
 }
 
 func (m *MockWorker) HandleEvent(ctx context.Context, trigger *triggers2.Event) error {
 	m.ReceivedEvents = append(m.ReceivedEvents, trigger)
 
-	return m.eventError
+	if m.eventError != nil {
+		return m.eventError(trigger)
+	}
+
+	return nil
 }
 
 func (m *MockWorker) HandlesEvent(trigger *triggers2.Event) bool {
@@ -55,16 +61,35 @@ func (m *MockWorker) HandleHttpRequest(ctx context.Context, trigger *triggers2.H
 	return m.returnHttp, m.httpError
 }
 
+func (m *MockWorker) HandlesWebsocketEvent(trigger *triggers2.WebsocketEvent) bool {
+	return true
+}
+
+func (m *MockWorker) HandleWebsocketEvent(ctx context.Context, trigger *triggers2.WebsocketEvent) (*triggers2.WebsocketResponse, error) {
+	m.ReceivedSockets = append(m.ReceivedSockets, trigger)
+
+	return &triggers2.WebsocketResponse{Success: true}, nil
+}
+
+func (m *MockWorker) Drain(ctx context.Context) error {
+	return nil
+}
+
+func (m *MockWorker) Saturated() bool {
+	return false
+}
+
 func (m *MockWorker) Reset() {
 	m.ReceivedEvents = make([]*triggers2.Event, 0)
 	m.ReceivedRequests = make([]*triggers2.HttpRequest, 0)
+	m.ReceivedSockets = make([]*triggers2.WebsocketEvent, 0)
 }
 
 func NewMockWorker(opts *MockWorkerOptions) *MockWorker {
 	return &MockWorker{
 		httpError:        opts.HttpError,
 		returnHttp:       opts.ReturnHttp,
-		eventError:       opts.eventError,
+		eventError:       opts.EventError,
 		ReceivedEvents:   make([]*triggers2.Event, 0),
 		ReceivedRequests: make([]*triggers2.HttpRequest, 0),
 	}